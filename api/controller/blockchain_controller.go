@@ -0,0 +1,96 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/littlecheny/go-backend/domain"
+)
+
+type BlockchainController struct {
+	BlockchainUsecase domain.BlockchainUsecase
+}
+
+func (bc *BlockchainController) Networks(c *gin.Context) {
+	networks, err := bc.BlockchainUsecase.GetSupportedNetworks(c)
+	if err != nil {
+		blockchainError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, networks)
+}
+
+func (bc *BlockchainController) Status(c *gin.Context) {
+	status, err := bc.BlockchainUsecase.GetNetworkStatus(c, c.Param("name"))
+	if err != nil {
+		blockchainError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, status)
+}
+
+func (bc *BlockchainController) Blocks(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+
+	blocks, err := bc.BlockchainUsecase.GetLatestBlocks(c, c.Param("name"), limit)
+	if err != nil {
+		blockchainError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, blocks)
+}
+
+func (bc *BlockchainController) Block(c *gin.Context) {
+	number, ok := blockNumber(c)
+	if !ok {
+		return
+	}
+
+	block, err := bc.BlockchainUsecase.GetBlock(c, c.Param("name"), number)
+	if err != nil {
+		blockchainError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, block)
+}
+
+func (bc *BlockchainController) BlockTransactions(c *gin.Context) {
+	number, ok := blockNumber(c)
+	if !ok {
+		return
+	}
+
+	transactions, err := bc.BlockchainUsecase.GetBlockTransactions(c, c.Param("name"), number)
+	if err != nil {
+		blockchainError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, transactions)
+}
+
+// blockNumber 解析路径中的区块号，失败时已经写入400响应
+func blockNumber(c *gin.Context) (uint64, bool) {
+	number, err := strconv.ParseUint(c.Param("number"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: "invalid block number"})
+		return 0, false
+	}
+	return number, true
+}
+
+// blockchainError 网络名和区块号来自URL路径，不存在时返回404
+func blockchainError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, domain.ErrNetworkNotSupported), errors.Is(err, domain.ErrBlockNotFound):
+		c.JSON(http.StatusNotFound, domain.ErrorResponse{Message: err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, domain.ErrorResponse{Message: err.Error()})
+	}
+}
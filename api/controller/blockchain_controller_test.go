@@ -0,0 +1,65 @@
+package controller_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/littlecheny/go-backend/api/controller"
+	"github.com/littlecheny/go-backend/domain"
+	"github.com/littlecheny/go-backend/domain/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestBlockchainController(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	newRouter := func(bc *controller.BlockchainController) *gin.Engine {
+		r := gin.New()
+		r.GET("/networks", bc.Networks)
+		r.GET("/networks/:name/status", bc.Status)
+		r.GET("/networks/:name/blocks", bc.Blocks)
+		r.GET("/networks/:name/blocks/:number", bc.Block)
+		return r
+	}
+
+	t.Run("status", func(t *testing.T) {
+		mockBlockchainUsecase := new(mocks.BlockchainUsecase)
+		mockBlockchainUsecase.On("GetNetworkStatus", mock.Anything, "sepolia").Return(&domain.BlockchainStatus{Network: "sepolia", LatestBlock: 100}, nil).Once()
+
+		bc := &controller.BlockchainController{BlockchainUsecase: mockBlockchainUsecase}
+
+		rec := httptest.NewRecorder()
+		newRouter(bc).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/networks/sepolia/status", nil))
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"latest_block":100`)
+
+		mockBlockchainUsecase.AssertExpectations(t)
+	})
+
+	t.Run("unknown network", func(t *testing.T) {
+		mockBlockchainUsecase := new(mocks.BlockchainUsecase)
+		mockBlockchainUsecase.On("GetLatestBlocks", mock.Anything, "unknown", 5).Return(nil, domain.ErrNetworkNotSupported).Once()
+
+		bc := &controller.BlockchainController{BlockchainUsecase: mockBlockchainUsecase}
+
+		rec := httptest.NewRecorder()
+		newRouter(bc).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/networks/unknown/blocks?limit=5", nil))
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+
+		mockBlockchainUsecase.AssertExpectations(t)
+	})
+
+	t.Run("invalid block number", func(t *testing.T) {
+		bc := &controller.BlockchainController{BlockchainUsecase: new(mocks.BlockchainUsecase)}
+
+		rec := httptest.NewRecorder()
+		newRouter(bc).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/networks/sepolia/blocks/latest", nil))
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
@@ -0,0 +1,25 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/littlecheny/go-backend/domain"
+	"github.com/littlecheny/go-backend/internal/tokenutil"
+)
+
+// JwksController Keys为nil表示使用HS256共享密钥
+type JwksController struct {
+	Keys *tokenutil.KeySet
+}
+
+// Jwks 发布访问令牌的校验公钥，使用HS256共享密钥时返回空集合
+func (jc *JwksController) Jwks(c *gin.Context) {
+	keySet := domain.JSONWebKeySet{Keys: []domain.JSONWebKey{}}
+	if jc.Keys != nil {
+		keySet = jc.Keys.JWKS()
+	}
+
+	c.Header("Cache-Control", "public, max-age=300")
+	c.JSON(http.StatusOK, keySet)
+}
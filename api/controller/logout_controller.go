@@ -0,0 +1,51 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/littlecheny/go-backend/bootstrap"
+	"github.com/littlecheny/go-backend/domain"
+)
+
+type LogoutController struct {
+	LogoutUsecase domain.LogoutUsecase
+	Env           *bootstrap.Env
+}
+
+func (lc *LogoutController) Logout(c *gin.Context) {
+	var request domain.LogoutRequest
+
+	err := c.ShouldBind(&request)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	err = lc.LogoutUsecase.RevokeAccessToken(c, c.GetString(domain.ContextTokenIDKey), c.GetInt64(domain.ContextTokenExpiresAtKey))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, domain.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	if request.RefreshToken != "" {
+		err = lc.LogoutUsecase.RevokeRefreshToken(c, c.GetString(domain.ContextUserIDKey), request.RefreshToken, lc.Env.RefreshTokenSecret)
+		if err != nil && !errors.Is(err, domain.ErrRefreshTokenInvalid) {
+			c.JSON(http.StatusInternalServerError, domain.ErrorResponse{Message: err.Error()})
+			return
+		}
+	}
+
+	c.JSON(http.StatusOK, domain.SuccessResponse{Message: "Logged out"})
+}
+
+func (lc *LogoutController) LogoutAll(c *gin.Context) {
+	err := lc.LogoutUsecase.RevokeAllTokens(c, c.GetString(domain.ContextUserIDKey), lc.Env.AccessTokenExpiryHour)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, domain.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, domain.SuccessResponse{Message: "Logged out from all sessions"})
+}
@@ -0,0 +1,107 @@
+package controller_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/littlecheny/go-backend/api/controller"
+	"github.com/littlecheny/go-backend/bootstrap"
+	"github.com/littlecheny/go-backend/domain"
+	"github.com/littlecheny/go-backend/domain/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestLogout(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	env := &bootstrap.Env{
+		RefreshTokenSecret:    "refresh-secret",
+		AccessTokenExpiryHour: 2,
+	}
+
+	userID := "66f000000000000000000001"
+	tokenID := "token-id"
+	expiresAt := int64(1900000000)
+
+	newRouter := func(lc *controller.LogoutController) *gin.Engine {
+		r := gin.New()
+		r.Use(func(c *gin.Context) {
+			c.Set(domain.ContextUserIDKey, userID)
+			c.Set(domain.ContextTokenIDKey, tokenID)
+			c.Set(domain.ContextTokenExpiresAtKey, expiresAt)
+			c.Next()
+		})
+		r.POST("/logout", lc.Logout)
+		r.POST("/logout-all", lc.LogoutAll)
+		return r
+	}
+
+	newRequest := func(path string, body string) *http.Request {
+		req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		return req
+	}
+
+	t.Run("logout", func(t *testing.T) {
+		mockLogoutUsecase := new(mocks.LogoutUsecase)
+		mockLogoutUsecase.On("RevokeAccessToken", mock.Anything, tokenID, expiresAt).Return(nil).Once()
+		mockLogoutUsecase.On("RevokeRefreshToken", mock.Anything, userID, "refresh-token", env.RefreshTokenSecret).Return(nil).Once()
+
+		lc := &controller.LogoutController{LogoutUsecase: mockLogoutUsecase, Env: env}
+
+		rec := httptest.NewRecorder()
+		newRouter(lc).ServeHTTP(rec, newRequest("/logout", `{"refreshToken":"refresh-token"}`))
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		mockLogoutUsecase.AssertExpectations(t)
+	})
+
+	t.Run("logout with stale refresh token", func(t *testing.T) {
+		mockLogoutUsecase := new(mocks.LogoutUsecase)
+		mockLogoutUsecase.On("RevokeAccessToken", mock.Anything, tokenID, expiresAt).Return(nil).Once()
+		mockLogoutUsecase.On("RevokeRefreshToken", mock.Anything, userID, "expired-token", env.RefreshTokenSecret).Return(domain.ErrRefreshTokenInvalid).Once()
+
+		lc := &controller.LogoutController{LogoutUsecase: mockLogoutUsecase, Env: env}
+
+		rec := httptest.NewRecorder()
+		newRouter(lc).ServeHTTP(rec, newRequest("/logout", `{"refreshToken":"expired-token"}`))
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		mockLogoutUsecase.AssertExpectations(t)
+	})
+
+	t.Run("logout error", func(t *testing.T) {
+		mockLogoutUsecase := new(mocks.LogoutUsecase)
+		mockLogoutUsecase.On("RevokeAccessToken", mock.Anything, tokenID, expiresAt).Return(errors.New("Unexpected")).Once()
+
+		lc := &controller.LogoutController{LogoutUsecase: mockLogoutUsecase, Env: env}
+
+		rec := httptest.NewRecorder()
+		newRouter(lc).ServeHTTP(rec, newRequest("/logout", `{}`))
+
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+
+		mockLogoutUsecase.AssertExpectations(t)
+	})
+
+	t.Run("logout all", func(t *testing.T) {
+		mockLogoutUsecase := new(mocks.LogoutUsecase)
+		mockLogoutUsecase.On("RevokeAllTokens", mock.Anything, userID, env.AccessTokenExpiryHour).Return(nil).Once()
+
+		lc := &controller.LogoutController{LogoutUsecase: mockLogoutUsecase, Env: env}
+
+		rec := httptest.NewRecorder()
+		newRouter(lc).ServeHTTP(rec, newRequest("/logout-all", ``))
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		mockLogoutUsecase.AssertExpectations(t)
+	})
+}
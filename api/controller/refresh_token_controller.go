@@ -0,0 +1,65 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/littlecheny/go-backend/bootstrap"
+	"github.com/littlecheny/go-backend/domain"
+)
+
+type RefreshTokenController struct {
+	RefreshTokenUsecase domain.RefreshTokenUsecase
+	Env                 *bootstrap.Env
+}
+
+func (rtc *RefreshTokenController) RefreshToken(c *gin.Context) {
+	var request domain.RefreshTokenRequest
+
+	err := c.ShouldBind(&request)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	claims, err := rtc.RefreshTokenUsecase.ValidateRefreshToken(c, request.RefreshToken, rtc.Env.RefreshTokenSecret)
+	if err != nil {
+		refreshTokenError(c, err)
+		return
+	}
+
+	user, err := rtc.RefreshTokenUsecase.GetUserByID(c, claims.ID)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, domain.ErrorResponse{Message: "User not found"})
+		return
+	}
+
+	accessToken, err := rtc.RefreshTokenUsecase.CreateAccessToken(&user, rtc.Env.AccessTokenSecret, rtc.Env.AccessTokenExpiryHour)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, domain.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	refreshToken, err := rtc.RefreshTokenUsecase.RotateRefreshToken(c, &user, claims, rtc.Env.RefreshTokenSecret, rtc.Env.RefreshTokenExpiryHour)
+	if err != nil {
+		refreshTokenError(c, err)
+		return
+	}
+
+	refreshTokenResponse := domain.RefreshTokenResponse{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+	}
+
+	c.JSON(http.StatusOK, refreshTokenResponse)
+}
+
+// refreshTokenError 令牌无效或被重用时返回401，其他错误返回500
+func refreshTokenError(c *gin.Context, err error) {
+	if errors.Is(err, domain.ErrRefreshTokenInvalid) || errors.Is(err, domain.ErrRefreshTokenReused) {
+		c.JSON(http.StatusUnauthorized, domain.ErrorResponse{Message: err.Error()})
+		return
+	}
+	c.JSON(http.StatusInternalServerError, domain.ErrorResponse{Message: err.Error()})
+}
@@ -0,0 +1,205 @@
+package controller_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/littlecheny/go-backend/api/controller"
+	"github.com/littlecheny/go-backend/bootstrap"
+	"github.com/littlecheny/go-backend/domain"
+	"github.com/littlecheny/go-backend/domain/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestRefreshToken(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	env := &bootstrap.Env{
+		AccessTokenSecret:      "access-secret",
+		RefreshTokenSecret:     "refresh-secret",
+		AccessTokenExpiryHour:  2,
+		RefreshTokenExpiryHour: 168,
+	}
+
+	mockUser := domain.User{
+		ID:    primitive.NewObjectID(),
+		Name:  "Test",
+		Email: "test@gmail.com",
+	}
+	userID := mockUser.ID.Hex()
+	mockClaims := &domain.JwtCustomRefreshClaims{
+		ID:       userID,
+		FamilyID: "family-id",
+	}
+
+	newRequest := func(body string) *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "/refresh", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		return req
+	}
+
+	t.Run("success", func(t *testing.T) {
+		mockRefreshTokenUsecase := new(mocks.RefreshTokenUsecase)
+
+		mockRefreshTokenUsecase.On("ValidateRefreshToken", mock.Anything, "old-refresh-token", env.RefreshTokenSecret).Return(mockClaims, nil).Once()
+		mockRefreshTokenUsecase.On("GetUserByID", mock.Anything, userID).Return(mockUser, nil).Once()
+		mockRefreshTokenUsecase.On("CreateAccessToken", mock.AnythingOfType("*domain.User"), env.AccessTokenSecret, env.AccessTokenExpiryHour).Return("new-access-token", nil).Once()
+		mockRefreshTokenUsecase.On("RotateRefreshToken", mock.Anything, mock.AnythingOfType("*domain.User"), mockClaims, env.RefreshTokenSecret, env.RefreshTokenExpiryHour).Return("new-refresh-token", nil).Once()
+
+		rtc := &controller.RefreshTokenController{
+			RefreshTokenUsecase: mockRefreshTokenUsecase,
+			Env:                 env,
+		}
+
+		r := gin.New()
+		r.POST("/refresh", rtc.RefreshToken)
+
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, newRequest(`{"refreshToken":"old-refresh-token"}`))
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		var response domain.RefreshTokenResponse
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+		assert.Equal(t, "new-access-token", response.AccessToken)
+		assert.Equal(t, "new-refresh-token", response.RefreshToken)
+
+		mockRefreshTokenUsecase.AssertExpectations(t)
+	})
+
+	t.Run("missing token", func(t *testing.T) {
+		mockRefreshTokenUsecase := new(mocks.RefreshTokenUsecase)
+
+		rtc := &controller.RefreshTokenController{
+			RefreshTokenUsecase: mockRefreshTokenUsecase,
+			Env:                 env,
+		}
+
+		r := gin.New()
+		r.POST("/refresh", rtc.RefreshToken)
+
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, newRequest(`{}`))
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+		mockRefreshTokenUsecase.AssertExpectations(t)
+	})
+
+	t.Run("invalid token", func(t *testing.T) {
+		mockRefreshTokenUsecase := new(mocks.RefreshTokenUsecase)
+
+		mockRefreshTokenUsecase.On("ValidateRefreshToken", mock.Anything, "bad-token", env.RefreshTokenSecret).Return(nil, domain.ErrRefreshTokenInvalid).Once()
+
+		rtc := &controller.RefreshTokenController{
+			RefreshTokenUsecase: mockRefreshTokenUsecase,
+			Env:                 env,
+		}
+
+		r := gin.New()
+		r.POST("/refresh", rtc.RefreshToken)
+
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, newRequest(`{"refreshToken":"bad-token"}`))
+
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+		mockRefreshTokenUsecase.AssertExpectations(t)
+	})
+
+	t.Run("reused token", func(t *testing.T) {
+		mockRefreshTokenUsecase := new(mocks.RefreshTokenUsecase)
+
+		mockRefreshTokenUsecase.On("ValidateRefreshToken", mock.Anything, "rotated-token", env.RefreshTokenSecret).Return(nil, domain.ErrRefreshTokenReused).Once()
+
+		rtc := &controller.RefreshTokenController{
+			RefreshTokenUsecase: mockRefreshTokenUsecase,
+			Env:                 env,
+		}
+
+		r := gin.New()
+		r.POST("/refresh", rtc.RefreshToken)
+
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, newRequest(`{"refreshToken":"rotated-token"}`))
+
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+		mockRefreshTokenUsecase.AssertExpectations(t)
+	})
+
+	t.Run("concurrent refresh", func(t *testing.T) {
+		mockRefreshTokenUsecase := new(mocks.RefreshTokenUsecase)
+
+		mockRefreshTokenUsecase.On("ValidateRefreshToken", mock.Anything, "old-refresh-token", env.RefreshTokenSecret).Return(mockClaims, nil).Once()
+		mockRefreshTokenUsecase.On("GetUserByID", mock.Anything, userID).Return(mockUser, nil).Once()
+		mockRefreshTokenUsecase.On("CreateAccessToken", mock.AnythingOfType("*domain.User"), env.AccessTokenSecret, env.AccessTokenExpiryHour).Return("new-access-token", nil).Once()
+		mockRefreshTokenUsecase.On("RotateRefreshToken", mock.Anything, mock.AnythingOfType("*domain.User"), mockClaims, env.RefreshTokenSecret, env.RefreshTokenExpiryHour).Return("", domain.ErrRefreshTokenReused).Once()
+
+		rtc := &controller.RefreshTokenController{
+			RefreshTokenUsecase: mockRefreshTokenUsecase,
+			Env:                 env,
+		}
+
+		r := gin.New()
+		r.POST("/refresh", rtc.RefreshToken)
+
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, newRequest(`{"refreshToken":"old-refresh-token"}`))
+
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+		assert.NotContains(t, rec.Body.String(), "new-access-token")
+
+		mockRefreshTokenUsecase.AssertExpectations(t)
+	})
+
+	t.Run("store unavailable", func(t *testing.T) {
+		mockRefreshTokenUsecase := new(mocks.RefreshTokenUsecase)
+
+		mockRefreshTokenUsecase.On("ValidateRefreshToken", mock.Anything, "old-refresh-token", env.RefreshTokenSecret).Return(nil, errors.New("failed to check existence")).Once()
+
+		rtc := &controller.RefreshTokenController{
+			RefreshTokenUsecase: mockRefreshTokenUsecase,
+			Env:                 env,
+		}
+
+		r := gin.New()
+		r.POST("/refresh", rtc.RefreshToken)
+
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, newRequest(`{"refreshToken":"old-refresh-token"}`))
+
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+
+		mockRefreshTokenUsecase.AssertExpectations(t)
+	})
+
+	t.Run("user not found", func(t *testing.T) {
+		mockRefreshTokenUsecase := new(mocks.RefreshTokenUsecase)
+
+		mockRefreshTokenUsecase.On("ValidateRefreshToken", mock.Anything, "old-refresh-token", env.RefreshTokenSecret).Return(mockClaims, nil).Once()
+		mockRefreshTokenUsecase.On("GetUserByID", mock.Anything, userID).Return(domain.User{}, errors.New("mongo: no documents in result")).Once()
+
+		rtc := &controller.RefreshTokenController{
+			RefreshTokenUsecase: mockRefreshTokenUsecase,
+			Env:                 env,
+		}
+
+		r := gin.New()
+		r.POST("/refresh", rtc.RefreshToken)
+
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, newRequest(`{"refreshToken":"old-refresh-token"}`))
+
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+		mockRefreshTokenUsecase.AssertExpectations(t)
+	})
+}
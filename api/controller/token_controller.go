@@ -0,0 +1,23 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/littlecheny/go-backend/domain"
+)
+
+type TokenController struct {
+	TokenUsecase domain.TokenUsecase
+}
+
+// Fetch 查询已登记的代币，可按network过滤
+func (tc *TokenController) Fetch(c *gin.Context) {
+	tokens, err := tc.TokenUsecase.GetTokens(c, c.Query("network"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, domain.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, tokens)
+}
@@ -0,0 +1,132 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/littlecheny/go-backend/bootstrap"
+	"github.com/littlecheny/go-backend/domain"
+)
+
+type TransactionController struct {
+	TransactionUsecase domain.TransactionUsecase
+	Env                *bootstrap.Env
+}
+
+func (tc *TransactionController) Send(c *gin.Context) {
+	var request domain.TransactionSendRequest
+
+	err := c.ShouldBind(&request)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	transaction, err := tc.TransactionUsecase.SendTransaction(c, c.GetString(domain.ContextUserIDKey), &request)
+	if err != nil {
+		transactionError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, transaction)
+}
+
+func (tc *TransactionController) Fetch(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+
+	transactions, err := tc.TransactionUsecase.GetTransactions(c, c.GetString(domain.ContextUserIDKey), limit, offset)
+	if err != nil {
+		transactionError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, transactions)
+}
+
+// History 钱包的交易记录，pending交易附带链上最新状态
+func (tc *TransactionController) History(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+
+	transactions, err := tc.TransactionUsecase.GetWalletHistory(c, c.GetString(domain.ContextUserIDKey), c.Param("id"), limit, offset)
+	if err != nil {
+		transactionError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, transactions)
+}
+
+func (tc *TransactionController) Get(c *gin.Context) {
+	transaction, err := tc.TransactionUsecase.GetTransaction(c, c.GetString(domain.ContextUserIDKey), c.Param("id"))
+	if err != nil {
+		transactionError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, transaction)
+}
+
+func (tc *TransactionController) GetByHash(c *gin.Context) {
+	transaction, err := tc.TransactionUsecase.GetTransactionByHash(c, c.GetString(domain.ContextUserIDKey), c.Param("hash"))
+	if err != nil {
+		transactionError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, transaction)
+}
+
+func (tc *TransactionController) SpeedUp(c *gin.Context) {
+	var request domain.TransactionReplaceRequest
+
+	err := c.ShouldBind(&request)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	transaction, err := tc.TransactionUsecase.SpeedUpTransaction(c, c.GetString(domain.ContextUserIDKey), c.Param("id"), &request)
+	if err != nil {
+		transactionError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, transaction)
+}
+
+func (tc *TransactionController) Cancel(c *gin.Context) {
+	var request domain.TransactionReplaceRequest
+
+	err := c.ShouldBind(&request)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	transaction, err := tc.TransactionUsecase.CancelTransaction(c, c.GetString(domain.ContextUserIDKey), c.Param("id"), &request)
+	if err != nil {
+		transactionError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, transaction)
+}
+
+// transactionError 将交易用例的错误映射为HTTP状态码，钱包相关错误沿用walletError
+func transactionError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, domain.ErrTransactionNotFound):
+		c.JSON(http.StatusNotFound, domain.ErrorResponse{Message: err.Error()})
+	case errors.Is(err, domain.ErrInvalidAddress), errors.Is(err, domain.ErrInvalidAmount), errors.Is(err, domain.ErrTokenNotFound),
+		errors.Is(err, domain.ErrReplacementUnderpriced):
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+	case errors.Is(err, domain.ErrWalletBusy), errors.Is(err, domain.ErrNotReplaceable):
+		c.JSON(http.StatusConflict, domain.ErrorResponse{Message: err.Error()})
+	default:
+		walletError(c, err)
+	}
+}
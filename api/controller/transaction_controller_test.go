@@ -0,0 +1,113 @@
+package controller_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/littlecheny/go-backend/api/controller"
+	"github.com/littlecheny/go-backend/domain"
+	"github.com/littlecheny/go-backend/domain/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestTransactionController(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	userID := primitive.NewObjectID().Hex()
+	transactionID := primitive.NewObjectID()
+
+	newRouter := func(tc *controller.TransactionController) *gin.Engine {
+		r := gin.New()
+		r.Use(func(c *gin.Context) {
+			c.Set(domain.ContextUserIDKey, userID)
+			c.Next()
+		})
+		r.POST("/transactions", tc.Send)
+		r.GET("/transactions", tc.Fetch)
+		r.GET("/transactions/:id", tc.Get)
+		r.GET("/transactions/hash/:hash", tc.GetByHash)
+		return r
+	}
+
+	t.Run("send", func(t *testing.T) {
+		mockTransactionUsecase := new(mocks.TransactionUsecase)
+		mockTransactionUsecase.On("SendTransaction", mock.Anything, userID, mock.AnythingOfType("*domain.TransactionSendRequest")).Return(&domain.TransactionResponse{ID: transactionID, Hash: "0xhash"}, nil).Once()
+
+		tc := &controller.TransactionController{TransactionUsecase: mockTransactionUsecase}
+
+		req := httptest.NewRequest(http.MethodPost, "/transactions", strings.NewReader(`{"wallet_id":"abc","to":"0x2222222222222222222222222222222222222222","amount":"0.1","password":"password123"}`))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		newRouter(tc).ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusCreated, rec.Code)
+
+		mockTransactionUsecase.AssertExpectations(t)
+	})
+
+	t.Run("send wrong password", func(t *testing.T) {
+		mockTransactionUsecase := new(mocks.TransactionUsecase)
+		mockTransactionUsecase.On("SendTransaction", mock.Anything, userID, mock.AnythingOfType("*domain.TransactionSendRequest")).Return(nil, domain.ErrInvalidPassword).Once()
+
+		tc := &controller.TransactionController{TransactionUsecase: mockTransactionUsecase}
+
+		req := httptest.NewRequest(http.MethodPost, "/transactions", strings.NewReader(`{"wallet_id":"abc","to":"0x2222222222222222222222222222222222222222","amount":"0.1","password":"wrong"}`))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		newRouter(tc).ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+		mockTransactionUsecase.AssertExpectations(t)
+	})
+
+	t.Run("fetch", func(t *testing.T) {
+		mockTransactionUsecase := new(mocks.TransactionUsecase)
+		mockTransactionUsecase.On("GetTransactions", mock.Anything, userID, 10, 20).Return([]domain.TransactionResponse{}, nil).Once()
+
+		tc := &controller.TransactionController{TransactionUsecase: mockTransactionUsecase}
+
+		req := httptest.NewRequest(http.MethodGet, "/transactions?limit=10&offset=20", nil)
+		rec := httptest.NewRecorder()
+		newRouter(tc).ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		mockTransactionUsecase.AssertExpectations(t)
+	})
+
+	t.Run("get not found", func(t *testing.T) {
+		mockTransactionUsecase := new(mocks.TransactionUsecase)
+		mockTransactionUsecase.On("GetTransaction", mock.Anything, userID, transactionID.Hex()).Return(nil, domain.ErrTransactionNotFound).Once()
+
+		tc := &controller.TransactionController{TransactionUsecase: mockTransactionUsecase}
+
+		req := httptest.NewRequest(http.MethodGet, "/transactions/"+transactionID.Hex(), nil)
+		rec := httptest.NewRecorder()
+		newRouter(tc).ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+
+		mockTransactionUsecase.AssertExpectations(t)
+	})
+
+	t.Run("get by hash", func(t *testing.T) {
+		mockTransactionUsecase := new(mocks.TransactionUsecase)
+		mockTransactionUsecase.On("GetTransactionByHash", mock.Anything, userID, "0xhash").Return(&domain.TransactionResponse{Hash: "0xhash"}, nil).Once()
+
+		tc := &controller.TransactionController{TransactionUsecase: mockTransactionUsecase}
+
+		req := httptest.NewRequest(http.MethodGet, "/transactions/hash/0xhash", nil)
+		rec := httptest.NewRecorder()
+		newRouter(tc).ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		mockTransactionUsecase.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,169 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/littlecheny/go-backend/bootstrap"
+	"github.com/littlecheny/go-backend/domain"
+)
+
+type WalletController struct {
+	WalletUsecase domain.WalletUsecase
+	Env           *bootstrap.Env
+}
+
+func (wc *WalletController) Create(c *gin.Context) {
+	var request domain.WalletCreateRequest
+
+	err := c.ShouldBind(&request)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	wallet, err := wc.WalletUsecase.CreateWallet(c, c.GetString(domain.ContextUserIDKey), &request)
+	if err != nil {
+		walletError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, wallet)
+}
+
+func (wc *WalletController) Import(c *gin.Context) {
+	var request domain.WalletImportRequest
+
+	err := c.ShouldBind(&request)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	wallet, err := wc.WalletUsecase.ImportWallet(c, c.GetString(domain.ContextUserIDKey), &request)
+	if err != nil {
+		walletError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, wallet)
+}
+
+func (wc *WalletController) Fetch(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "20"))
+
+	wallets, err := wc.WalletUsecase.GetWallets(c, c.GetString(domain.ContextUserIDKey), page, pageSize)
+	if err != nil {
+		walletError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, wallets)
+}
+
+func (wc *WalletController) Get(c *gin.Context) {
+	wallet, err := wc.WalletUsecase.GetWallet(c, c.GetString(domain.ContextUserIDKey), c.Param("id"))
+	if err != nil {
+		walletError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, wallet)
+}
+
+func (wc *WalletController) Rename(c *gin.Context) {
+	var request domain.WalletUpdateRequest
+
+	err := c.ShouldBind(&request)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	wallet, err := wc.WalletUsecase.UpdateWallet(c, c.GetString(domain.ContextUserIDKey), c.Param("id"), request.Name)
+	if err != nil {
+		walletError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, wallet)
+}
+
+func (wc *WalletController) Delete(c *gin.Context) {
+	err := wc.WalletUsecase.DeleteWallet(c, c.GetString(domain.ContextUserIDKey), c.Param("id"))
+	if err != nil {
+		walletError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, domain.SuccessResponse{Message: "Wallet deleted"})
+}
+
+func (wc *WalletController) Derive(c *gin.Context) {
+	var request domain.WalletDeriveRequest
+
+	err := c.ShouldBind(&request)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	wallet, err := wc.WalletUsecase.DeriveWallet(c, c.GetString(domain.ContextUserIDKey), c.Param("id"), &request)
+	if err != nil {
+		walletError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, wallet)
+}
+
+func (wc *WalletController) SetDefault(c *gin.Context) {
+	err := wc.WalletUsecase.SetDefaultWallet(c, c.GetString(domain.ContextUserIDKey), c.Param("id"))
+	if err != nil {
+		walletError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, domain.SuccessResponse{Message: "Default wallet updated"})
+}
+
+// TokenBalances 查询钱包在所在网络已登记代币的余额
+func (wc *WalletController) TokenBalances(c *gin.Context) {
+	balances, err := wc.WalletUsecase.GetTokenBalances(c, c.GetString(domain.ContextUserIDKey), c.Param("id"))
+	if err != nil {
+		walletError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, balances)
+}
+
+// Stats 按网络汇总当前用户的钱包数和余额
+func (wc *WalletController) Stats(c *gin.Context) {
+	stats, err := wc.WalletUsecase.GetWalletStats(c, c.GetString(domain.ContextUserIDKey))
+	if err != nil {
+		walletError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, stats)
+}
+
+// walletError 将钱包用例的错误映射为HTTP状态码
+func walletError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, domain.ErrWalletNotFound):
+		c.JSON(http.StatusNotFound, domain.ErrorResponse{Message: err.Error()})
+	case errors.Is(err, domain.ErrWalletExists):
+		c.JSON(http.StatusConflict, domain.ErrorResponse{Message: err.Error()})
+	case errors.Is(err, domain.ErrInvalidPassword):
+		c.JSON(http.StatusUnauthorized, domain.ErrorResponse{Message: err.Error()})
+	case errors.Is(err, domain.ErrInvalidWalletType), errors.Is(err, domain.ErrWalletIsWatchOnly), errors.Is(err, domain.ErrWalletNoMnemonic), errors.Is(err, domain.ErrNetworkNotSupported):
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{Message: err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, domain.ErrorResponse{Message: err.Error()})
+	}
+}
@@ -0,0 +1,161 @@
+package controller_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/littlecheny/go-backend/api/controller"
+	"github.com/littlecheny/go-backend/domain"
+	"github.com/littlecheny/go-backend/domain/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestWalletController(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	userID := primitive.NewObjectID().Hex()
+	walletID := primitive.NewObjectID()
+
+	newRouter := func(wc *controller.WalletController) *gin.Engine {
+		r := gin.New()
+		r.Use(func(c *gin.Context) {
+			c.Set(domain.ContextUserIDKey, userID)
+			c.Next()
+		})
+		r.POST("/wallets", wc.Create)
+		r.GET("/wallets", wc.Fetch)
+		r.GET("/wallets/stats", wc.Stats)
+		r.GET("/wallets/:id", wc.Get)
+		r.PATCH("/wallets/:id", wc.Rename)
+		r.DELETE("/wallets/:id", wc.Delete)
+		return r
+	}
+
+	t.Run("create", func(t *testing.T) {
+		mockWalletUsecase := new(mocks.WalletUsecase)
+		mockWalletUsecase.On("CreateWallet", mock.Anything, userID, mock.MatchedBy(func(req *domain.WalletCreateRequest) bool {
+			return req.Name == "Main" && req.Type == domain.WalletTypeHD && req.Network == "sepolia"
+		})).Return(&domain.WalletResponse{ID: walletID, Name: "Main"}, nil).Once()
+
+		wc := &controller.WalletController{WalletUsecase: mockWalletUsecase}
+
+		req := httptest.NewRequest(http.MethodPost, "/wallets", strings.NewReader(`{"name":"Main","type":"hd","network":"sepolia","password":"password123"}`))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		newRouter(wc).ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusCreated, rec.Code)
+
+		var response domain.WalletResponse
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+		assert.Equal(t, walletID, response.ID)
+
+		mockWalletUsecase.AssertExpectations(t)
+	})
+
+	t.Run("create short password", func(t *testing.T) {
+		mockWalletUsecase := new(mocks.WalletUsecase)
+
+		wc := &controller.WalletController{WalletUsecase: mockWalletUsecase}
+
+		req := httptest.NewRequest(http.MethodPost, "/wallets", strings.NewReader(`{"name":"Main","type":"hd","network":"sepolia","password":"short"}`))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		newRouter(wc).ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+		mockWalletUsecase.AssertExpectations(t)
+	})
+
+	t.Run("fetch", func(t *testing.T) {
+		mockWalletUsecase := new(mocks.WalletUsecase)
+		mockWalletUsecase.On("GetWallets", mock.Anything, userID, 2, 10).Return(&domain.WalletListResponse{Page: 2, PageSize: 10}, nil).Once()
+
+		wc := &controller.WalletController{WalletUsecase: mockWalletUsecase}
+
+		req := httptest.NewRequest(http.MethodGet, "/wallets?page=2&page_size=10", nil)
+		rec := httptest.NewRecorder()
+		newRouter(wc).ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		mockWalletUsecase.AssertExpectations(t)
+	})
+
+	t.Run("stats", func(t *testing.T) {
+		mockWalletUsecase := new(mocks.WalletUsecase)
+		mockWalletUsecase.On("GetWalletStats", mock.Anything, userID).Return(&domain.WalletStatsResponse{
+			TotalWallets: 2,
+			TotalBalance: "1.5",
+			Networks:     []domain.NetworkStats{{Network: "mainnet", WalletCount: 2, TotalBalance: "1.5"}},
+		}, nil).Once()
+
+		wc := &controller.WalletController{WalletUsecase: mockWalletUsecase}
+
+		req := httptest.NewRequest(http.MethodGet, "/wallets/stats", nil)
+		rec := httptest.NewRecorder()
+		newRouter(wc).ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		var response domain.WalletStatsResponse
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+		assert.Equal(t, 2, response.TotalWallets)
+		assert.Len(t, response.Networks, 1)
+
+		mockWalletUsecase.AssertExpectations(t)
+	})
+
+	t.Run("get not found", func(t *testing.T) {
+		mockWalletUsecase := new(mocks.WalletUsecase)
+		mockWalletUsecase.On("GetWallet", mock.Anything, userID, walletID.Hex()).Return(nil, domain.ErrWalletNotFound).Once()
+
+		wc := &controller.WalletController{WalletUsecase: mockWalletUsecase}
+
+		req := httptest.NewRequest(http.MethodGet, "/wallets/"+walletID.Hex(), nil)
+		rec := httptest.NewRecorder()
+		newRouter(wc).ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+
+		mockWalletUsecase.AssertExpectations(t)
+	})
+
+	t.Run("rename", func(t *testing.T) {
+		mockWalletUsecase := new(mocks.WalletUsecase)
+		mockWalletUsecase.On("UpdateWallet", mock.Anything, userID, walletID.Hex(), "Savings").Return(&domain.WalletResponse{ID: walletID, Name: "Savings"}, nil).Once()
+
+		wc := &controller.WalletController{WalletUsecase: mockWalletUsecase}
+
+		req := httptest.NewRequest(http.MethodPatch, "/wallets/"+walletID.Hex(), strings.NewReader(`{"name":"Savings"}`))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		newRouter(wc).ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		mockWalletUsecase.AssertExpectations(t)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		mockWalletUsecase := new(mocks.WalletUsecase)
+		mockWalletUsecase.On("DeleteWallet", mock.Anything, userID, walletID.Hex()).Return(nil).Once()
+
+		wc := &controller.WalletController{WalletUsecase: mockWalletUsecase}
+
+		req := httptest.NewRequest(http.MethodDelete, "/wallets/"+walletID.Hex(), nil)
+		rec := httptest.NewRecorder()
+		newRouter(wc).ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		mockWalletUsecase.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,51 @@
+package middleware
+
+import (
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/littlecheny/go-backend/domain"
+	"github.com/littlecheny/go-backend/internal/tokenutil"
+)
+
+// JwtAuthMiddleware keys为nil时使用secret按HS256校验访问令牌
+func JwtAuthMiddleware(secret string, keys *tokenutil.KeySet, revocations domain.TokenRevocationRepository) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		authHeader := c.Request.Header.Get("Authorization")
+		t := strings.Split(authHeader, " ")
+		if len(t) != 2 || t[0] != "Bearer" || t[1] == "" {
+			c.JSON(http.StatusUnauthorized, domain.ErrorResponse{Message: "Not authorized"})
+			c.Abort()
+			return
+		}
+
+		claims, err := tokenutil.ExtractClaimsFromToken(t[1], keys, secret)
+		if err != nil {
+			c.JSON(http.StatusUnauthorized, domain.ErrorResponse{Message: err.Error()})
+			c.Abort()
+			return
+		}
+
+		// 吊销记录查询失败不代表令牌无效，返回500且不暴露Redis错误
+		revoked, err := revocations.IsRevoked(c, claims)
+		if err != nil {
+			log.Printf("failed to check revocation of token %s: %v", claims.Id, err)
+			c.JSON(http.StatusInternalServerError, domain.ErrorResponse{Message: "Failed to verify token"})
+			c.Abort()
+			return
+		}
+		if revoked {
+			c.JSON(http.StatusUnauthorized, domain.ErrorResponse{Message: "Token has been revoked"})
+			c.Abort()
+			return
+		}
+
+		c.Set(domain.ContextUserIDKey, claims.ID)
+		c.Set(domain.ContextUserNameKey, claims.Name)
+		c.Set(domain.ContextTokenIDKey, claims.Id)
+		c.Set(domain.ContextTokenExpiresAtKey, claims.ExpiresAt)
+		c.Next()
+	}
+}
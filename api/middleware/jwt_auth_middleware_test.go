@@ -0,0 +1,127 @@
+package middleware_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/littlecheny/go-backend/api/middleware"
+	"github.com/littlecheny/go-backend/domain"
+	"github.com/littlecheny/go-backend/domain/mocks"
+	"github.com/littlecheny/go-backend/internal/tokenutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestJwtAuthMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	secret := "access-secret"
+	user := &domain.User{
+		ID:   primitive.NewObjectID(),
+		Name: "Test",
+	}
+
+	newRouter := func(revocations domain.TokenRevocationRepository) *gin.Engine {
+		r := gin.New()
+		r.Use(middleware.JwtAuthMiddleware(secret, nil, revocations))
+		r.GET("/me", func(c *gin.Context) {
+			c.JSON(http.StatusOK, gin.H{
+				"id":   c.GetString(domain.ContextUserIDKey),
+				"name": c.GetString(domain.ContextUserNameKey),
+			})
+		})
+		return r
+	}
+
+	t.Run("success", func(t *testing.T) {
+		accessToken, err := tokenutil.CreateAccessToken(user, nil, secret, 1)
+		assert.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodGet, "/me", nil)
+		req.Header.Set("Authorization", "Bearer "+accessToken)
+		mockTokenRevocationRepository := new(mocks.TokenRevocationRepository)
+		mockTokenRevocationRepository.On("IsRevoked", mock.Anything, mock.AnythingOfType("*domain.JwtCustomClaims")).Return(false, nil).Once()
+
+		rec := httptest.NewRecorder()
+		newRouter(mockTokenRevocationRepository).ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), user.ID.Hex())
+		assert.Contains(t, rec.Body.String(), user.Name)
+
+		mockTokenRevocationRepository.AssertExpectations(t)
+	})
+
+	t.Run("revoked", func(t *testing.T) {
+		accessToken, err := tokenutil.CreateAccessToken(user, nil, secret, 1)
+		assert.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodGet, "/me", nil)
+		req.Header.Set("Authorization", "Bearer "+accessToken)
+
+		mockTokenRevocationRepository := new(mocks.TokenRevocationRepository)
+		mockTokenRevocationRepository.On("IsRevoked", mock.Anything, mock.AnythingOfType("*domain.JwtCustomClaims")).Return(true, nil).Once()
+
+		rec := httptest.NewRecorder()
+		newRouter(mockTokenRevocationRepository).ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+		mockTokenRevocationRepository.AssertExpectations(t)
+	})
+
+	t.Run("revocation store unavailable", func(t *testing.T) {
+		accessToken, err := tokenutil.CreateAccessToken(user, nil, secret, 1)
+		assert.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodGet, "/me", nil)
+		req.Header.Set("Authorization", "Bearer "+accessToken)
+
+		mockTokenRevocationRepository := new(mocks.TokenRevocationRepository)
+		mockTokenRevocationRepository.On("IsRevoked", mock.Anything, mock.AnythingOfType("*domain.JwtCustomClaims")).Return(false, errors.New("dial tcp 10.0.0.5:6379: connection refused")).Once()
+
+		rec := httptest.NewRecorder()
+		newRouter(mockTokenRevocationRepository).ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+		assert.NotContains(t, rec.Body.String(), "6379")
+
+		mockTokenRevocationRepository.AssertExpectations(t)
+	})
+
+	t.Run("missing header", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/me", nil)
+		rec := httptest.NewRecorder()
+		newRouter(new(mocks.TokenRevocationRepository)).ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	})
+
+	t.Run("wrong secret", func(t *testing.T) {
+		accessToken, err := tokenutil.CreateAccessToken(user, nil, "other-secret", 1)
+		assert.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodGet, "/me", nil)
+		req.Header.Set("Authorization", "Bearer "+accessToken)
+		rec := httptest.NewRecorder()
+		newRouter(new(mocks.TokenRevocationRepository)).ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		accessToken, err := tokenutil.CreateAccessToken(user, nil, secret, -1)
+		assert.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodGet, "/me", nil)
+		req.Header.Set("Authorization", "Bearer "+accessToken)
+		rec := httptest.NewRecorder()
+		newRouter(new(mocks.TokenRevocationRepository)).ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	})
+}
@@ -0,0 +1,22 @@
+package route
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/littlecheny/go-backend/api/controller"
+	"github.com/littlecheny/go-backend/domain"
+	"github.com/littlecheny/go-backend/usecase"
+)
+
+func NewBlockchainRouter(cache domain.RedisService, networks domain.NetworkRegistry, timeout time.Duration, group *gin.RouterGroup) {
+	bc := controller.BlockchainController{
+		BlockchainUsecase: usecase.NewBlockchainUsecase(networks, cache, timeout),
+	}
+
+	group.GET("/networks", bc.Networks)
+	group.GET("/networks/:name/status", bc.Status)
+	group.GET("/networks/:name/blocks", bc.Blocks)
+	group.GET("/networks/:name/blocks/:number", bc.Block)
+	group.GET("/networks/:name/blocks/:number/transactions", bc.BlockTransactions)
+}
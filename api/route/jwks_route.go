@@ -0,0 +1,13 @@
+package route
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/littlecheny/go-backend/api/controller"
+	"github.com/littlecheny/go-backend/internal/tokenutil"
+)
+
+func NewJwksRouter(accessTokenKeys *tokenutil.KeySet, group *gin.RouterGroup) {
+	jc := controller.JwksController{Keys: accessTokenKeys}
+
+	group.GET("/.well-known/jwks.json", jc.Jwks)
+}
@@ -8,13 +8,15 @@ import(
 	"github.com/gin-gonic/gin"
 	"github.com/littlecheny/go-backend/bootstrap"
 	"github.com/littlecheny/go-backend/api/controller"
+	"github.com/littlecheny/go-backend/internal/tokenutil"
 	"github.com/littlecheny/go-backend/usecase"
 )
 
-func NewLoginRouter(env *bootstrap.Env, db mongo.Database, timeout time.Duration, group *gin.RouterGroup){
+func NewLoginRouter(env *bootstrap.Env, accessTokenKeys *tokenutil.KeySet, db mongo.Database, cache domain.RedisService, timeout time.Duration, group *gin.RouterGroup){
 	ur := repository.NewUserRepository(db, domain.CollectionUser)
+	rtr := repository.NewRefreshTokenRepository(cache)
 	sc := controller.LoginController{
-		LoginUsecase: usecase.NewLoginUsecase(ur, timeout),
+		LoginUsecase: usecase.NewLoginUsecase(ur, rtr, accessTokenKeys, timeout),
 		Env: env,
 	}
 
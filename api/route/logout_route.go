@@ -0,0 +1,25 @@
+package route
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/littlecheny/go-backend/api/controller"
+	"github.com/littlecheny/go-backend/bootstrap"
+	"github.com/littlecheny/go-backend/domain"
+	"github.com/littlecheny/go-backend/mongo"
+	"github.com/littlecheny/go-backend/repository"
+	"github.com/littlecheny/go-backend/usecase"
+)
+
+func NewLogoutRouter(env *bootstrap.Env, db mongo.Database, cache domain.RedisService, timeout time.Duration, group *gin.RouterGroup) {
+	tr := repository.NewTokenRevocationRepository(cache)
+	rtr := repository.NewRefreshTokenRepository(cache)
+	lc := controller.LogoutController{
+		LogoutUsecase: usecase.NewLogoutUsecase(tr, rtr, timeout),
+		Env:           env,
+	}
+
+	group.POST("/logout", lc.Logout)
+	group.POST("/logout-all", lc.LogoutAll)
+}
@@ -1,19 +1,22 @@
 package route
 
 import(
+	"time"
 	"github.com/littlecheny/go-backend/bootstrap"
 	"github.com/littlecheny/go-backend/mongo"
 	"github.com/littlecheny/go-backend/repository"
 	"github.com/littlecheny/go-backend/domain"
 	"github.com/gin-gonic/gin"
 	"github.com/littlecheny/go-backend/api/controller"
+	"github.com/littlecheny/go-backend/internal/tokenutil"
 	"github.com/littlecheny/go-backend/usecase"
 )
 
-func NewRefreshTokenRouter(env *bootstrap.Env, db mongo.Database, timeout time.Duration, group *gin.RouterGroup){
+func NewRefreshTokenRouter(env *bootstrap.Env, accessTokenKeys *tokenutil.KeySet, db mongo.Database, cache domain.RedisService, timeout time.Duration, group *gin.RouterGroup){
 	ur := repository.NewUserRepository(db, domain.CollectionUser)
+	rtr := repository.NewRefreshTokenRepository(cache)
 	rtc := controller.RefreshTokenController{
-		RefreshTokenUsecase: usecase.NewRefreshTokenUsecase(ur, timeout),
+		RefreshTokenUsecase: usecase.NewRefreshTokenUsecase(ur, rtr, accessTokenKeys, timeout),
 		Env: env,
 	}
 
@@ -5,12 +5,27 @@ import (
 	"github.com/littlecheny/go-backend/bootstrap"
 	"github.com/gin-gonic/gin"
 	"github.com/littlecheny/go-backend/mongo"
+	"github.com/littlecheny/go-backend/domain"
+	"github.com/littlecheny/go-backend/api/middleware"
+	"github.com/littlecheny/go-backend/repository"
+	"github.com/littlecheny/go-backend/internal/tokenutil"
 )
 
-func Setup(env *bootstrap.Env, db mongo.Database, gin *gin.Engine, timeout time.Duration){
+func Setup(env *bootstrap.Env, accessTokenKeys *tokenutil.KeySet, db mongo.Database, cache domain.RedisService, networks domain.NetworkRegistry, walletKeys domain.WalletKeyManager, tokens domain.TokenRegistry, prices domain.PriceProvider, nonces domain.NonceManager, gin *gin.Engine, timeout time.Duration){
 	publicRouter := gin.Group("")
 
-	NewSignupRouter(env, db, timeout, publicRouter)
-	NewLoginRouter(env, db, timeout, publicRouter)
-	NewRefreshTokenRouter(env, db, timeout, publicRouter)
+	NewSignupRouter(env, accessTokenKeys, db, cache, timeout, publicRouter)
+	NewLoginRouter(env, accessTokenKeys, db, cache, timeout, publicRouter)
+	NewRefreshTokenRouter(env, accessTokenKeys, db, cache, timeout, publicRouter)
+	NewJwksRouter(accessTokenKeys, publicRouter)
+
+	protectedRouter := gin.Group("")
+	// 校验AccessToken，钱包、交易、任务等私有接口挂载在此分组下
+	protectedRouter.Use(middleware.JwtAuthMiddleware(env.AccessTokenSecret, accessTokenKeys, repository.NewTokenRevocationRepository(cache)))
+
+	NewLogoutRouter(env, db, cache, timeout, protectedRouter)
+	NewWalletRouter(env, db, networks, walletKeys, tokens, prices, timeout, protectedRouter)
+	NewTransactionRouter(env, db, cache, networks, walletKeys, tokens, prices, nonces, timeout, protectedRouter)
+	NewTokenRouter(tokens, protectedRouter)
+	NewBlockchainRouter(cache, networks, timeout, protectedRouter)
 }
\ No newline at end of file
@@ -8,13 +8,15 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/littlecheny/go-backend/domain"
 	"github.com/littlecheny/go-backend/api/controller"
+	"github.com/littlecheny/go-backend/internal/tokenutil"
 	"github.com/littlecheny/go-backend/usecase"
 )
 
-func NewSignupRouter(env *bootstrap.Env, db mongo.Database, timeout time.Duration, group *gin.RouterGroup) {
+func NewSignupRouter(env *bootstrap.Env, accessTokenKeys *tokenutil.KeySet, db mongo.Database, cache domain.RedisService, timeout time.Duration, group *gin.RouterGroup) {
 	ur := repository.NewUserRepository(db, domain.CollectionUser)
+	rtr := repository.NewRefreshTokenRepository(cache)
 	sc := controller.SignupController{
-		SignupUsecase: usecase.NewSignupUsecase(ur, timeout),
+		SignupUsecase: usecase.NewSignupUsecase(ur, rtr, accessTokenKeys, timeout),
 		Env: env,
 	}
 	
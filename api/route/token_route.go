@@ -0,0 +1,16 @@
+package route
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/littlecheny/go-backend/api/controller"
+	"github.com/littlecheny/go-backend/domain"
+	"github.com/littlecheny/go-backend/usecase"
+)
+
+func NewTokenRouter(tokens domain.TokenRegistry, group *gin.RouterGroup) {
+	tc := controller.TokenController{
+		TokenUsecase: usecase.NewTokenUsecase(tokens),
+	}
+
+	group.GET("/tokens", tc.Fetch)
+}
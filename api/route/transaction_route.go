@@ -0,0 +1,32 @@
+package route
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/littlecheny/go-backend/api/controller"
+	"github.com/littlecheny/go-backend/bootstrap"
+	"github.com/littlecheny/go-backend/domain"
+	"github.com/littlecheny/go-backend/mongo"
+	"github.com/littlecheny/go-backend/repository"
+	"github.com/littlecheny/go-backend/services"
+	"github.com/littlecheny/go-backend/usecase"
+)
+
+func NewTransactionRouter(env *bootstrap.Env, db mongo.Database, cache domain.RedisService, networks domain.NetworkRegistry, walletKeys domain.WalletKeyManager, tokens domain.TokenRegistry, prices domain.PriceProvider, nonces domain.NonceManager, timeout time.Duration, group *gin.RouterGroup) {
+	wr := repository.NewWalletRepository(db, domain.CollectionWallet, domain.CollectionWalletPrivateData)
+	wu := usecase.NewWalletUsecase(wr, networks, services.NewCryptoService(), walletKeys, tokens, prices, timeout)
+	tr := repository.NewTransactionRepository(db, domain.CollectionTransaction)
+	tc := controller.TransactionController{
+		TransactionUsecase: usecase.NewTransactionUsecase(tr, wu, networks, cache, tokens, nonces, timeout),
+		Env:                env,
+	}
+
+	group.POST("/transactions", tc.Send)
+	group.GET("/transactions", tc.Fetch)
+	group.GET("/transactions/:id", tc.Get)
+	group.POST("/transactions/:id/speedup", tc.SpeedUp)
+	group.POST("/transactions/:id/cancel", tc.Cancel)
+	group.GET("/transactions/hash/:hash", tc.GetByHash)
+	group.GET("/wallets/:id/transactions", tc.History)
+}
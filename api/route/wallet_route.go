@@ -0,0 +1,33 @@
+package route
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/littlecheny/go-backend/api/controller"
+	"github.com/littlecheny/go-backend/bootstrap"
+	"github.com/littlecheny/go-backend/domain"
+	"github.com/littlecheny/go-backend/mongo"
+	"github.com/littlecheny/go-backend/repository"
+	"github.com/littlecheny/go-backend/services"
+	"github.com/littlecheny/go-backend/usecase"
+)
+
+func NewWalletRouter(env *bootstrap.Env, db mongo.Database, networks domain.NetworkRegistry, walletKeys domain.WalletKeyManager, tokens domain.TokenRegistry, prices domain.PriceProvider, timeout time.Duration, group *gin.RouterGroup) {
+	wr := repository.NewWalletRepository(db, domain.CollectionWallet, domain.CollectionWalletPrivateData)
+	wc := controller.WalletController{
+		WalletUsecase: usecase.NewWalletUsecase(wr, networks, services.NewCryptoService(), walletKeys, tokens, prices, timeout),
+		Env:           env,
+	}
+
+	group.POST("/wallets", wc.Create)
+	group.POST("/wallets/import", wc.Import)
+	group.GET("/wallets", wc.Fetch)
+	group.GET("/wallets/stats", wc.Stats)
+	group.GET("/wallets/:id", wc.Get)
+	group.PATCH("/wallets/:id", wc.Rename)
+	group.DELETE("/wallets/:id", wc.Delete)
+	group.POST("/wallets/:id/default", wc.SetDefault)
+	group.POST("/wallets/:id/accounts", wc.Derive)
+	group.GET("/wallets/:id/tokens", wc.TokenBalances)
+}
@@ -1,19 +1,51 @@
 package bootstrap
 
-import "github.com/littlecheny/go-backend/mongo"
+import (
+	"context"
+	"log"
+
+	"github.com/littlecheny/go-backend/domain"
+	"github.com/littlecheny/go-backend/internal/tokenutil"
+	"github.com/littlecheny/go-backend/mongo"
+	"github.com/littlecheny/go-backend/services"
+	"github.com/redis/go-redis/v9"
+)
 
 type Application struct{
 	Env *Env
+	AccessTokenKeys *tokenutil.KeySet // 未配置非对称密钥时为nil，使用HS256共享密钥
 	Mongo mongo.Client
+	Redis *redis.Client
+	WalletKeys domain.WalletKeyManager
+	Tokens domain.TokenRegistry
+	Networks domain.NetworkRegistry
+	Prices domain.PriceProvider
 }
 
 func App() Application{
 	app := &Application{}
 	app.Env = NewEnv()
+	app.AccessTokenKeys = NewAccessTokenKeys(app.Env)
+	app.WalletKeys = NewWalletKeyManager(app.Env)
+	app.Tokens = NewTokenRegistry(app.Env)
+	err := InitBlockchain(context.Background(), app.Env, app)
+	if err != nil {
+		log.Fatal("Failed to initialize blockchain: ", err)
+	}
 	app.Mongo = NewMongoDatabase(app.Env)
+	app.Redis = NewRedisClient(app.Env)
+	app.Prices = NewPriceProvider(app.Env, app.Networks, services.NewRedisService(app.Redis))
 	return *app
 }
 
 func (app *Application) CloseDBConnection(){
 	CloseMongoDBConnection(app.Mongo)
+}
+
+func (app *Application) CloseNetworks(){
+	app.Networks.Close()
+}
+
+func (app *Application) CloseRedisConnection(){
+	CloseRedisConnection(app.Redis)
 }
\ No newline at end of file
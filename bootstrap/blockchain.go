@@ -2,15 +2,106 @@ package bootstrap
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"log"
+	"math/big"
+	"os"
+	"time"
 
-	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
 	"github.com/littlecheny/go-backend/domain"
-	"github.com/littlecheny/go-backend/service"
+	"github.com/littlecheny/go-backend/services"
 )
 
+const (
+	// healthCheckAttempts 默认网络健康检查的最大尝试次数，间隔从healthCheckBackoff开始每次翻倍
+	healthCheckAttempts = 5
+	healthCheckBackoff  = time.Second
+	// simulatedBlockPeriod 模拟链自动出块间隔
+	simulatedBlockPeriod = 5 * time.Second
+	// simulatedNetwork 模拟链使用单独的网络名，避免开发环境的钱包和交易记在真实网络名下
+	simulatedNetwork = "local"
+)
+
+// devAccount 模拟链预置余额的账户，即Hardhat/Anvil测试助记词
+// "test test test test test test test test test test test junk"的m/44'/60'/0'/0/0，可直接导入钱包使用
+var devAccount = common.HexToAddress("0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266")
+
+// InitBlockchain 建立各网络的以太坊客户端。默认网络先做带退避重试的健康检查；
+// 默认网络未配置RPC时，仅开发环境在进程内运行模拟链(网络名local)代替，其他环境启动失败。
+// 使用模拟链时DEFAULT_NETWORK只能不设置或设为local，否则默认网络指向不存在的网络
 func InitBlockchain(ctx context.Context, env *Env, app *Application) error {
+	configs, err := loadNetworkConfigs(env)
+	if err != nil {
+		return err
+	}
+
+	network := selectDefaultNetwork(env)
+	rpcURL, isMock, err := resolveNetworkRPC(env, network)
+	if err != nil {
+		return err
+	}
+
+	var svc domain.EthereumService
+	if isMock {
+		if env.AppEnv != "development" {
+			return fmt.Errorf("no RPC configured for default network %s", network)
+		}
+		if env.DefaultNetwork != "" && env.DefaultNetwork != simulatedNetwork {
+			return fmt.Errorf("no RPC configured for DEFAULT_NETWORK %s, unset it or set it to %s to use the simulated chain", env.DefaultNetwork, simulatedNetwork)
+		}
+		log.Printf("No RPC configured for network %s, using an in-process simulated chain as network %s", network, simulatedNetwork)
+		svc, err = newSimulatedEthereum()
+		if err != nil {
+			return err
+		}
+		network = simulatedNetwork
+		configs = append(configs, domain.NetworkConfig{Name: network, ChainID: services.SimulatedChainID, Symbol: "ETH", Decimals: 18, Testnet: true})
+	} else {
+		svc, err = connectEthereumWithRetry(ctx, rpcURL)
+		if err != nil {
+			return err
+		}
+	}
+
+	app.Networks = services.NewNetworkRegistry(configs, map[string]domain.EthereumService{network: svc})
+	return nil
+}
+
+func newSimulatedEthereum() (domain.EthereumService, error) {
+	balance := new(big.Int).Mul(big.NewInt(10000), big.NewInt(params.Ether))
+	svc, _, err := services.NewSimulatedEthereumService(types.GenesisAlloc{devAccount: {Balance: balance}}, simulatedBlockPeriod)
+	return svc, err
+}
 
+// connectEthereumWithRetry 连接并检查节点，失败时按指数退避重试
+func connectEthereumWithRetry(ctx context.Context, rpcURL string) (domain.EthereumService, error) {
+	backoff := healthCheckBackoff
+	for attempt := 1; ; attempt++ {
+		svc, err := connectEthereum(ctx, rpcURL)
+		if err == nil {
+			err = healthCheckEthereum(ctx, svc)
+			if err == nil {
+				return svc, nil
+			}
+			svc.Disconnect()
+		}
+
+		if attempt == healthCheckAttempts {
+			return nil, err
+		}
+		log.Printf("Ethereum node health check failed (attempt %d/%d), retrying in %s: %v", attempt, healthCheckAttempts, backoff, err)
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
 }
 
 func selectDefaultNetwork(env *Env) (network string) {
@@ -21,38 +112,75 @@ func selectDefaultNetwork(env *Env) (network string) {
 	return network
 }
 
-func resolveNetworkRPC(env *Env, network string) (rpcURL string, isMock bool){
-	switch network {
-	case "mainnet":
-		rpcURL = env.EthereumMainnetRPC
-	case "sepolia":
-		rpcURL = env.EthereumSepoliaRPC
-	case "goerli":
-		rpcURL = env.EthereumGoerliRPC
-	default:
-		isMock = true
-		rpcURL = "http://localhost:8545"
+// loadNetworkConfigs 配置了NETWORK_CONFIG_FILE时从JSON数组读取，
+// 否则由ETHEREUM_*_RPC生成内置网络，未配置RPC的网络不启用
+func loadNetworkConfigs(env *Env) ([]domain.NetworkConfig, error) {
+	if env.NetworkConfigFile != "" {
+		data, err := os.ReadFile(env.NetworkConfigFile)
+		if err != nil {
+			return nil, err
+		}
+
+		var configs []domain.NetworkConfig
+		err = json.Unmarshal(data, &configs)
+		if err != nil {
+			return nil, fmt.Errorf("invalid network config %s: %v", env.NetworkConfigFile, err)
+		}
+
+		seen := map[string]bool{}
+		for _, config := range configs {
+			if config.Name == "" || config.RPC == "" || seen[config.Name] {
+				return nil, fmt.Errorf("invalid network %q in %s", config.Name, env.NetworkConfigFile)
+			}
+			seen[config.Name] = true
+		}
+		return configs, nil
+	}
+
+	builtin := []domain.NetworkConfig{
+		{Name: "mainnet", ChainID: 1, RPC: env.EthereumMainnetRPC, Explorer: "https://etherscan.io", Symbol: "ETH", Decimals: 18},
+		{Name: "sepolia", ChainID: 11155111, RPC: env.EthereumSepoliaRPC, Explorer: "https://sepolia.etherscan.io", Symbol: "ETH", Decimals: 18, Testnet: true},
+		{Name: "goerli", ChainID: 5, RPC: env.EthereumGoerliRPC, Explorer: "https://goerli.etherscan.io", Symbol: "ETH", Decimals: 18, Testnet: true},
 	}
-	return rpcURL, isMock
+
+	var configs []domain.NetworkConfig
+	for _, config := range builtin {
+		if config.RPC != "" {
+			configs = append(configs, config)
+		}
+	}
+	return configs, nil
 }
 
-func connectEthereum(rpcURL string) (svc domain.EthereumService, err error) {
-	//使用以太坊服务建立客户端连接（利用service/ethereum_service.go)
-	ethClient, err := ethclient.DialContext( rpcURL)
+// resolveNetworkRPC 未配置RPC的网络返回isMock，网络配置本身有误时返回错误
+func resolveNetworkRPC(env *Env, network string) (rpcURL string, isMock bool, err error) {
+	configs, err := loadNetworkConfigs(env)
 	if err != nil {
+		return "", false, err
+	}
+
+	for _, config := range configs {
+		if config.Name == network {
+			return config.RPC, false, nil
+		}
+	}
+	return "", true, nil
+}
+
+func connectEthereum(ctx context.Context, rpcURL string) (svc domain.EthereumService, err error) {
+	//使用以太坊服务建立客户端连接（利用services/ethereum_service.go)
+	svc = services.NewEthereumService()
+	if err = svc.Connect(ctx, rpcURL); err != nil {
 		return nil, fmt.Errorf("failed to connect to Ethereum node: %v", err)
 	}
-	svc = service.NewEthereumService(ethClient)
 	return svc, nil
 }
 
-func healthCheckEthereum(ctx context.Context, svc service.EthereumService) (err error) {
+func healthCheckEthereum(ctx context.Context, svc domain.EthereumService) (err error) {
 	// 检查以太坊节点是否可访问
-	_, err = svc.Client().BlockNumber(ctx)
+	_, err = svc.GetLatestBlock(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to get block number: %v", err)
 	}
 	return nil
 }
-
-func regi
@@ -18,6 +18,13 @@ type Env struct{
 	RefreshTokenExpiryHour int    `mapstructure:"REFRESH_TOKEN_EXPIRY_HOUR"`
 	AccessTokenSecret      string `mapstructure:"ACCESS_TOKEN_SECRET"`
 	RefreshTokenSecret     string `mapstructure:"REFRESH_TOKEN_SECRET"`
+
+	// 访问令牌非对称签名（RS256/EdDSA），未配置密钥文件时使用AccessTokenSecret(HS256)
+	AccessTokenKeyID        string `mapstructure:"ACCESS_TOKEN_KEY_ID"`
+	AccessTokenKeyFile      string `mapstructure:"ACCESS_TOKEN_KEY_FILE"`       // 当前签名私钥PEM
+	AccessTokenPreviousKeys string `mapstructure:"ACCESS_TOKEN_PREVIOUS_KEYS"`  // 轮换前的密钥，格式 kid=path,kid=path
+	AccessTokenKeyRotatedAt string `mapstructure:"ACCESS_TOKEN_KEY_ROTATED_AT"` // 轮换时间(RFC3339)，配置旧密钥时必填；为空时不接受HS256令牌
+	AccessTokenKeyGraceHour int    `mapstructure:"ACCESS_TOKEN_KEY_GRACE_HOUR"` // 旧密钥保留校验的小时数，默认等于访问令牌有效期
 	
 	// Redis配置
 	RedisHost     string `mapstructure:"REDIS_HOST"`
@@ -30,9 +37,21 @@ type Env struct{
 	EthereumSepoliaRPC string `mapstructure:"ETHEREUM_SEPOLIA_RPC"`
 	EthereumGoerliRPC  string `mapstructure:"ETHEREUM_GOERLI_RPC"`
 	DefaultNetwork     string `mapstructure:"DEFAULT_NETWORK"`
+	NetworkConfigFile  string `mapstructure:"NETWORK_CONFIG_FILE"` // 网络列表，JSON数组，字段同domain.NetworkConfig；配置后忽略ETHEREUM_*_RPC
+	TokenListFile      string `mapstructure:"TOKEN_LIST_FILE"` // 额外登记的ERC-20代币，JSON数组
+
+	// 价格配置
+	PriceFile     string `mapstructure:"PRICE_FILE"`      // 离线价格，JSON对象，代币符号到USD价格，例如{"ETH": "3000.5"}；配置后不查询Chainlink
+	PriceCacheTTL int    `mapstructure:"PRICE_CACHE_TTL"` // 价格在Redis中的缓存秒数，默认60
+
+	// 交易确认跟踪
+	TransactionConfirmations int `mapstructure:"TRANSACTION_CONFIRMATIONS"`  // 交易确认所需区块数，默认12
+	TransactionPollInterval  int `mapstructure:"TRANSACTION_POLL_INTERVAL"`  // 轮询pending交易收据的间隔秒数，默认15
 	
 	// 加密配置
-	WalletEncryptionKey string `mapstructure:"WALLET_ENCRYPTION_KEY"`
+	WalletEncryptionKey    string `mapstructure:"WALLET_ENCRYPTION_KEY"`     // 未配置WALLET_MASTER_KEYS时作为版本1主密钥
+	WalletMasterKeys       string `mapstructure:"WALLET_MASTER_KEYS"`        // 钱包主密钥，格式 version=base64,version=base64
+	WalletMasterKeyVersion int    `mapstructure:"WALLET_MASTER_KEY_VERSION"` // 包裹新数据密钥的主密钥版本，默认取最大版本
 }
 
 func NewEnv() *Env {
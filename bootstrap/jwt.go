@@ -0,0 +1,68 @@
+package bootstrap
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/littlecheny/go-backend/internal/tokenutil"
+)
+
+// NewAccessTokenKeys 加载访问令牌的非对称签名密钥，未配置时返回nil，使用HS256共享密钥
+func NewAccessTokenKeys(env *Env) *tokenutil.KeySet {
+	if env.AccessTokenKeyFile == "" {
+		return nil
+	}
+
+	keys, err := NewAccessTokenKeySet(env)
+	if err != nil {
+		log.Fatal("Access token signing keys can't be loaded: ", err)
+	}
+
+	log.Printf("Access tokens are signed with key %s", env.AccessTokenKeyID)
+	return keys
+}
+
+func NewAccessTokenKeySet(env *Env) (*tokenutil.KeySet, error) {
+	previous, err := parsePreviousKeys(env.AccessTokenPreviousKeys)
+	if err != nil {
+		return nil, err
+	}
+
+	// 宽限期从固定的轮换时间起算，不能取启动时间，否则每次重启都会延长旧密钥的有效期；
+	// 未配置时不接受旧密钥和HS256令牌
+	var rotatedAt time.Time
+	if env.AccessTokenKeyRotatedAt != "" {
+		rotatedAt, err = time.Parse(time.RFC3339, env.AccessTokenKeyRotatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ACCESS_TOKEN_KEY_ROTATED_AT: %v", err)
+		}
+	} else if len(previous) > 0 {
+		return nil, fmt.Errorf("ACCESS_TOKEN_KEY_ROTATED_AT is required when ACCESS_TOKEN_PREVIOUS_KEYS is set")
+	}
+
+	graceHour := env.AccessTokenKeyGraceHour
+	if graceHour <= 0 {
+		graceHour = env.AccessTokenExpiryHour
+	}
+
+	return tokenutil.LoadKeySet(env.AccessTokenKeyID, env.AccessTokenKeyFile, previous, rotatedAt, time.Hour*time.Duration(graceHour))
+}
+
+// parsePreviousKeys 解析 kid=path,kid=path 格式的旧密钥列表
+func parsePreviousKeys(value string) ([]tokenutil.PreviousKey, error) {
+	var previous []tokenutil.PreviousKey
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		id, file, ok := strings.Cut(entry, "=")
+		if !ok || id == "" || file == "" {
+			return nil, fmt.Errorf("invalid ACCESS_TOKEN_PREVIOUS_KEYS entry %q", entry)
+		}
+		previous = append(previous, tokenutil.PreviousKey{ID: id, File: file})
+	}
+	return previous, nil
+}
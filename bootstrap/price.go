@@ -0,0 +1,72 @@
+package bootstrap
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/littlecheny/go-backend/domain"
+	"github.com/littlecheny/go-backend/internal/units"
+	"github.com/littlecheny/go-backend/services"
+)
+
+const (
+	// defaultPriceCacheTTL 价格默认缓存时间
+	defaultPriceCacheTTL = 60 * time.Second
+	// priceFeedNetwork Chainlink喂价合约所在的网络
+	priceFeedNetwork = "mainnet"
+)
+
+// chainlinkFeeds 以太坊主网上的Chainlink USD喂价合约
+var chainlinkFeeds = map[string]string{
+	"ETH": "0x5f4eC3Df9cbd43714FE2740f5E3616155c5b8419",
+}
+
+// NewPriceProvider 配置了PRICE_FILE时使用文件中的固定价格，否则通过主网的Chainlink喂价合约查询；
+// 主网为模拟链时没有喂价合约，USD价值为空。结果缓存在Redis中
+func NewPriceProvider(env *Env, networks domain.NetworkRegistry, cache domain.RedisService) domain.PriceProvider {
+	ttl := time.Duration(env.PriceCacheTTL) * time.Second
+	if ttl <= 0 {
+		ttl = defaultPriceCacheTTL
+	}
+
+	var provider domain.PriceProvider
+	if env.PriceFile != "" {
+		prices, err := loadPriceFile(env.PriceFile)
+		if err != nil {
+			log.Fatal("Price file can't be loaded: ", err)
+		}
+		provider = services.NewStaticPriceProvider(prices)
+	} else if config, err := networks.GetNetwork(priceFeedNetwork); err == nil && !config.Testnet {
+		provider = services.NewChainlinkPriceProvider(networks, priceFeedNetwork, chainlinkFeeds)
+	} else {
+		log.Printf("No %s node or PRICE_FILE configured, USD values are unavailable", priceFeedNetwork)
+		provider = services.NewStaticPriceProvider(nil)
+	}
+
+	return services.NewCachedPriceProvider(provider, cache, ttl)
+}
+
+// loadPriceFile 读取代币符号到USD价格的JSON对象，价格为十进制字符串
+func loadPriceFile(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var prices map[string]string
+	err = json.Unmarshal(data, &prices)
+	if err != nil {
+		return nil, fmt.Errorf("invalid price file %s: %v", path, err)
+	}
+
+	for symbol, price := range prices {
+		value, err := units.ParseDecimal(price)
+		if symbol == "" || err != nil || value.Sign() < 0 {
+			return nil, fmt.Errorf("invalid price %q for %q in %s", price, symbol, path)
+		}
+	}
+	return prices, nil
+}
@@ -0,0 +1,55 @@
+package bootstrap
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/littlecheny/go-backend/domain"
+	"github.com/littlecheny/go-backend/services"
+)
+
+// defaultTokens 内置的常用稳定币
+var defaultTokens = []domain.Token{
+	{Network: "mainnet", Symbol: "USDC", Name: "USD Coin", ContractAddress: "0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48", Decimals: 6},
+	{Network: "mainnet", Symbol: "USDT", Name: "Tether USD", ContractAddress: "0xdAC17F958D2ee523a2206206994597C13D831ec7", Decimals: 6},
+	{Network: "mainnet", Symbol: "DAI", Name: "Dai Stablecoin", ContractAddress: "0x6B175474E89094C44Da98b954EedeAC495271d0F", Decimals: 18},
+	{Network: "sepolia", Symbol: "USDC", Name: "USD Coin", ContractAddress: "0x1c7D4B196Cb0C7B01d743Fbc6116a902379C7238", Decimals: 6},
+}
+
+// NewTokenRegistry 内置代币加上TOKEN_LIST_FILE中的代币，文件中的同地址代币覆盖内置配置
+func NewTokenRegistry(env *Env) domain.TokenRegistry {
+	tokens, err := loadTokenList(env.TokenListFile)
+	if err != nil {
+		log.Fatal("Token list can't be loaded: ", err)
+	}
+
+	return services.NewTokenRegistry(append(defaultTokens, tokens...))
+}
+
+// loadTokenList 读取JSON数组格式的代币列表，字段同domain.Token
+func loadTokenList(path string) ([]domain.Token, error) {
+	if path == "" {
+		return nil, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var tokens []domain.Token
+	err = json.Unmarshal(data, &tokens)
+	if err != nil {
+		return nil, fmt.Errorf("invalid token list %s: %v", path, err)
+	}
+
+	for _, token := range tokens {
+		if token.Network == "" || token.Symbol == "" || !common.IsHexAddress(token.ContractAddress) || token.Decimals < 0 {
+			return nil, fmt.Errorf("invalid token %q on network %q in %s", token.Symbol, token.Network, path)
+		}
+	}
+	return tokens, nil
+}
@@ -0,0 +1,74 @@
+package bootstrap
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/littlecheny/go-backend/domain"
+	"github.com/littlecheny/go-backend/services"
+)
+
+// NewWalletKeyManager 加载钱包信封加密使用的带版本主密钥
+func NewWalletKeyManager(env *Env) domain.WalletKeyManager {
+	masterKeys, version, err := parseWalletMasterKeys(env)
+	if err != nil {
+		log.Fatal("Wallet master keys can't be loaded: ", err)
+	}
+
+	keyManager, err := services.NewWalletKeyManager(masterKeys, version, env.WalletEncryptionKey)
+	if err != nil {
+		log.Fatal("Wallet master keys can't be loaded: ", err)
+	}
+
+	return keyManager
+}
+
+// parseWalletMasterKeys 解析 version=base64,version=base64 格式的主密钥列表
+func parseWalletMasterKeys(env *Env) (map[int][]byte, int, error) {
+	masterKeys := map[int][]byte{}
+	latest := 0
+
+	for _, entry := range strings.Split(env.WalletMasterKeys, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		versionText, encoded, ok := strings.Cut(entry, "=")
+		if !ok {
+			return nil, 0, fmt.Errorf("invalid WALLET_MASTER_KEYS entry for version %q", versionText)
+		}
+		version, err := strconv.Atoi(versionText)
+		if err != nil {
+			return nil, 0, fmt.Errorf("invalid WALLET_MASTER_KEYS version %q", versionText)
+		}
+		key, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, 0, fmt.Errorf("invalid WALLET_MASTER_KEYS key for version %d: %v", version, err)
+		}
+		masterKeys[version] = key
+		if version > latest {
+			latest = version
+		}
+	}
+
+	// 兼容只配置了单个密钥的部署
+	if len(masterKeys) == 0 {
+		if env.WalletEncryptionKey == "" {
+			return nil, 0, fmt.Errorf("WALLET_MASTER_KEYS or WALLET_ENCRYPTION_KEY is required")
+		}
+		key := sha256.Sum256([]byte(env.WalletEncryptionKey))
+		masterKeys[1] = key[:]
+		latest = 1
+	}
+
+	version := env.WalletMasterKeyVersion
+	if version == 0 {
+		version = latest
+	}
+
+	return masterKeys, version, nil
+}
@@ -1,10 +1,16 @@
 package main
 
 import(
+	"context"
+	"log"
 	"time"
 	"github.com/gin-gonic/gin"
 	route "github.com/littlecheny/go-backend/api/route"
 	"github.com/littlecheny/go-backend/bootstrap"
+	"github.com/littlecheny/go-backend/domain"
+	"github.com/littlecheny/go-backend/repository"
+	"github.com/littlecheny/go-backend/services"
+	"github.com/littlecheny/go-backend/usecase"
 )
 
 func main(){
@@ -15,11 +21,66 @@ func main(){
 	db := app.Mongo.Database(env.DBName)
 	defer app.CloseDBConnection()
 
+	cache := services.NewRedisService(app.Redis)
+	defer app.CloseRedisConnection()
+
+	networks := app.Networks
+	defer app.CloseNetworks()
+
 	timeout := time.Duration(env.ContextTimeout) * time.Second
 
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	tr := repository.NewTransactionRepository(db, domain.CollectionTransaction)
+	wr := repository.NewWalletRepository(db, domain.CollectionWallet, domain.CollectionWalletPrivateData)
+
+	indexCtx, indexCancel := context.WithTimeout(ctx, timeout)
+	err := wr.EnsureIndexes(indexCtx)
+	indexCancel()
+	if err != nil {
+		log.Fatal("Failed to create wallet indexes: ", err)
+	}
+
+	tracker := usecase.NewTransactionTracker(
+		tr,
+		networks,
+		cache,
+		env.TransactionConfirmations,
+		time.Duration(env.TransactionPollInterval)*time.Second,
+		timeout,
+	)
+	go tracker.Run(ctx)
+
+	// 启动时未连接的网络也启动watcher和follower，它们等到网络按需连接后再订阅，
+	// 保证区块缓存在链重组时能被清除
+	for _, network := range networks.GetNetworks() {
+		eth, err := networks.GetClient(network.Name)
+		if err != nil {
+			log.Printf("Network %s unavailable, transaction watcher and chain follower disabled: %v", network.Name, err)
+			continue
+		}
+
+		watcher := usecase.NewTransactionWatcher(network.Name, wr, tr, eth, app.Tokens, cache, timeout)
+		go func(network string) {
+			if err := watcher.Run(ctx); err != nil {
+				log.Printf("transaction watcher for %s stopped: %v", network, err)
+			}
+		}(network.Name)
+
+		follower := usecase.NewChainFollower(network.Name, tr, eth, cache, timeout)
+		go func(network string) {
+			if err := follower.Run(ctx); err != nil {
+				log.Printf("chain follower for %s stopped: %v", network, err)
+			}
+		}(network.Name)
+	}
+
 	r := gin.Default()
+	// 控制器直接把*gin.Context传给用例，开启后其Done/Deadline来自请求的context，客户端断开时取消RPC和Redis调用
+	r.ContextWithFallback = true
 
-	route.Setup(env, db, r, timeout)
+	route.Setup(env, app.AccessTokenKeys, db, cache, networks, app.WalletKeys, app.Tokens, app.Prices, services.NewNonceManager(cache), r, timeout)
 
 	r.Run(env.ServerAddress)
 }
\ No newline at end of file
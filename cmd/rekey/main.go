@@ -0,0 +1,45 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"time"
+
+	"github.com/littlecheny/go-backend/bootstrap"
+	"github.com/littlecheny/go-backend/domain"
+	"github.com/littlecheny/go-backend/repository"
+	"github.com/littlecheny/go-backend/usecase"
+)
+
+// 将钱包数据密钥迁移到当前主密钥(WALLET_MASTER_KEY_VERSION)，全部迁移完成后才能下线旧主密钥
+func main() {
+	batchSize := flag.Int("batch", 100, "number of records re-keyed per batch")
+	flag.Parse()
+	if *batchSize < 1 {
+		log.Fatalf("-batch must be positive, got %d", *batchSize)
+	}
+
+	// 只需要数据库和主密钥，不连接Redis和区块链节点
+	env := bootstrap.NewEnv()
+	walletKeys := bootstrap.NewWalletKeyManager(env)
+
+	client := bootstrap.NewMongoDatabase(env)
+	defer bootstrap.CloseMongoDBConnection(client)
+
+	db := client.Database(env.DBName)
+	timeout := time.Duration(env.ContextTimeout) * time.Second
+
+	wr := repository.NewWalletRepository(db, domain.CollectionWallet, domain.CollectionWalletPrivateData)
+	ru := usecase.NewWalletRekeyUsecase(wr, walletKeys, timeout)
+
+	result, err := ru.RekeyPrivateData(context.Background(), *batchSize)
+	if err != nil {
+		log.Fatalf("Re-keying stopped after %d records: %v", result.Rewrapped, err)
+	}
+
+	log.Printf("Re-keyed %d records to master key version %d, %d skipped, %d failed", result.Rewrapped, result.Version, result.Skipped, result.Failed)
+	if result.Legacy > 0 {
+		log.Printf("%d legacy records are not envelope-encrypted yet and are migrated when their owners next unlock them", result.Legacy)
+	}
+}
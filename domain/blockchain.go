@@ -2,17 +2,24 @@ package domain
 
 import (
 	"context"
+	"errors"
 	"time"
 )
 
+var (
+	ErrNetworkNotSupported = errors.New("network not supported")
+	ErrBlockNotFound       = errors.New("block not found")
+)
+
 // NetworkConfig 网络配置
 type NetworkConfig struct {
 	Name     string `json:"name"`
 	ChainID  int64  `json:"chain_id"`
-	RPC      string `json:"rpc"`
+	RPC      string `json:"rpc,omitempty"` // 可能包含API Key，不对外返回
 	Explorer string `json:"explorer"`
 	Symbol   string `json:"symbol"`
 	Decimals int    `json:"decimals"`
+	Testnet  bool   `json:"testnet,omitempty"` // 测试网代币没有市场价格，USD价值按0计算
 }
 
 // BlockchainStatus 区块链状态
@@ -36,56 +43,126 @@ type BlockInfo struct {
 	GasLimit     uint64    `json:"gas_limit"`
 }
 
+// TransactionReceipt 交易收据，Status为confirmed或failed，EffectiveGasPrice为Wei格式
+type TransactionReceipt struct {
+	Hash              string            `json:"hash"`
+	Status            TransactionStatus `json:"status"`
+	BlockNumber       uint64            `json:"block_number"`
+	BlockHash         string            `json:"block_hash"`
+	GasUsed           uint64            `json:"gas_used"`
+	EffectiveGasPrice string            `json:"effective_gas_price"`
+	Logs              []TransactionLog  `json:"logs,omitempty"`
+}
+
+// TransactionLog 收据中的事件日志，Data为0x开头的十六进制
+type TransactionLog struct {
+	Address  string   `json:"address"`
+	Topics   []string `json:"topics"`
+	Data     string   `json:"data"`
+	LogIndex uint     `json:"log_index"`
+}
+
 // EthereumService 以太坊服务接口
 type EthereumService interface {
 	// 网络连接
-	Connect(rpcURL string) error
+	Connect(ctx context.Context, rpcURL string) error
 	Disconnect() error
 	IsConnected() bool
 	
 	// 账户管理
-	CreateAccount() (address, privateKey, mnemonic string, err error)
-	ImportAccount(mnemonic string) (address, privateKey string, err error)
-	GetBalance(address string) (string, error)
+	CreateAccount(passphrase string) (address, privateKey, mnemonic string, err error)
+	ImportAccount(mnemonic, passphrase string) (address, privateKey string, err error)
+	DeriveAccount(mnemonic, passphrase string, index uint32) (address, privateKey string, err error)
+	// GetBalance 返回ETH格式的余额
+	GetBalance(ctx context.Context, address string) (string, error)
+	// GetTokenBalance 返回代币最小单位的余额
+	GetTokenBalance(ctx context.Context, contractAddress, address string) (string, error)
 	
-	// 交易操作
-	SendTransaction(from, to, privateKey, value string, gasPrice *string) (string, error)
-	GetTransaction(hash string) (*TransactionResponse, error)
-	EstimateGas(from, to, value string) (uint64, error)
-	GetGasPrice() (string, error)
-	GetNonce(address string) (uint64, error)
+	// 交易操作，value和amount为wei或代币最小单位的整数，由units包从十进制金额转换
+	SendTransaction(ctx context.Context, from, to, privateKey, value string, fees *TransactionFees) (*SignedTransaction, error)
+	SendTokenTransfer(ctx context.Context, from, contractAddress, to, privateKey, amount string, fees *TransactionFees) (*SignedTransaction, error)
+	GetTransaction(ctx context.Context, hash string) (*TransactionResponse, error)
+	GetTransactionReceipt(ctx context.Context, hash string) (*TransactionReceipt, error)
+	EstimateGas(ctx context.Context, from, to, value string) (uint64, error)
+	// GetGasPrice 返回Gwei格式的建议Gas价格
+	GetGasPrice(ctx context.Context) (string, error)
+	GetNonce(ctx context.Context, address string) (uint64, error)
 	
 	// 区块链信息
-	GetLatestBlock() (*BlockInfo, error)
-	GetBlockByNumber(number uint64) (*BlockInfo, error)
+	GetLatestBlock(ctx context.Context) (*BlockInfo, error)
+	GetBlockByNumber(ctx context.Context, number uint64) (*BlockInfo, error)
+	// GetBlockTransactions 按区块内顺序返回区块中的交易及其收据日志，无法恢复发送方的交易被跳过
+	GetBlockTransactions(ctx context.Context, number uint64) ([]TransactionResponse, error)
 	GetNetworkID() (int64, error)
+	// GetLatestPrice 读取Chainlink AggregatorV3喂价合约的最新报价
+	GetLatestPrice(ctx context.Context, aggregatorAddress string) (*PriceRound, error)
 	
 	// 监控
 	SubscribeNewHeads(ctx context.Context) (<-chan *BlockInfo, error)
-	WatchTransactions(ctx context.Context, addresses []string) (<-chan *TransactionResponse, error)
+	// ScanTransactions 返回区块中from或to属于addresses的交易
+	ScanTransactions(ctx context.Context, number uint64, addresses []string) ([]TransactionResponse, error)
+	// GetTokenTransfers 返回[fromBlock, toBlock]区间内转入addresses的代币Transfer事件
+	GetTokenTransfers(ctx context.Context, fromBlock, toBlock uint64, contractAddresses []string, addresses []string) ([]TokenTransfer, error)
 }
 
 // RedisService Redis服务接口
 type RedisService interface {
 	// 基础操作
-	Set(key string, value interface{}, expiration time.Duration) error
-	Get(key string) (string, error)
-	Del(key string) error
-	Exists(key string) (bool, error)
+	Set(ctx context.Context, key string, value interface{}, expiration time.Duration) error
+	Get(ctx context.Context, key string) (string, error)
+	Del(ctx context.Context, key string) error
+	Exists(ctx context.Context, key string) (bool, error)
+	SetExpiration(ctx context.Context, key string, expiration time.Duration) error
+	// CompareAndSwap 当前值等于old时原子地替换为value并重设过期时间，键不存在或值不同时返回false
+	CompareAndSwap(ctx context.Context, key string, old, value interface{}, expiration time.Duration) (bool, error)
+	// SetNX 键不存在时写入，已存在时返回false
+	SetNX(ctx context.Context, key string, value interface{}, expiration time.Duration) (bool, error)
+	// CompareAndDelete 当前值等于value时原子地删除，键不存在或值不同时返回false
+	CompareAndDelete(ctx context.Context, key string, value interface{}) (bool, error)
+	
+	// 集合操作
+	AddToSet(ctx context.Context, key string, members ...interface{}) error
+	GetSetMembers(ctx context.Context, key string) ([]string, error)
+	RemoveFromSet(ctx context.Context, key string, members ...interface{}) error
 	
 	// 缓存操作
-	SetBalance(address string, balance string, expiration time.Duration) error
-	GetBalance(address string) (string, error)
-	SetGasPrice(network string, gasPrice string, expiration time.Duration) error
-	GetGasPrice(network string) (string, error)
+	SetBalance(ctx context.Context, address string, balance string, expiration time.Duration) error
+	GetBalance(ctx context.Context, address string) (string, error)
+	SetGasPrice(ctx context.Context, network string, gasPrice string, expiration time.Duration) error
+	GetGasPrice(ctx context.Context, network string) (string, error)
 	
 	// 交易缓存
-	SetTransaction(hash string, tx *TransactionResponse, expiration time.Duration) error
-	GetTransaction(hash string) (*TransactionResponse, error)
+	SetTransaction(ctx context.Context, hash string, tx *TransactionResponse, expiration time.Duration) error
+	GetTransaction(ctx context.Context, hash string) (*TransactionResponse, error)
+	DeleteTransaction(ctx context.Context, hash string) error
 	
 	// 区块缓存
-	SetBlock(number uint64, block *BlockInfo, expiration time.Duration) error
-	GetBlock(number uint64) (*BlockInfo, error)
+	SetBlock(ctx context.Context, network string, number uint64, block *BlockInfo, expiration time.Duration) error
+	GetBlock(ctx context.Context, network string, number uint64) (*BlockInfo, error)
+	DeleteBlock(ctx context.Context, network string, number uint64) error
+
+	// 网络状态缓存
+	SetNetworkStatus(ctx context.Context, network string, status *BlockchainStatus, expiration time.Duration) error
+	GetNetworkStatus(ctx context.Context, network string) (*BlockchainStatus, error)
+
+	// 价格缓存
+	SetPrice(ctx context.Context, symbol string, price string, expiration time.Duration) error
+	GetPrice(ctx context.Context, symbol string) (string, error)
+}
+
+// NetworkRegistry 按网络名管理各链的EthereumService，每个网络保持一个连接，断开后自动重连
+type NetworkRegistry interface {
+	GetClient(network string) (EthereumService, error)
+	GetNetwork(network string) (*NetworkConfig, error)
+	GetNetworks() []NetworkConfig
+	Close() error
+}
+
+// ChainFollower 跟随链头，通过ParentHash连续性检测链重组，
+// 清除失效的区块缓存并把重组区块中的交易回滚为pending
+type ChainFollower interface {
+	Run(ctx context.Context) error
+	HandleHead(c context.Context, head *BlockInfo) error
 }
 
 // BlockchainUsecase 区块链用例接口
@@ -93,6 +170,7 @@ type BlockchainUsecase interface {
 	GetNetworkStatus(c context.Context, network string) (*BlockchainStatus, error)
 	GetLatestBlocks(c context.Context, network string, limit int) ([]BlockInfo, error)
 	GetBlock(c context.Context, network string, number uint64) (*BlockInfo, error)
+	GetBlockTransactions(c context.Context, network string, number uint64) ([]TransactionResponse, error)
 	GetSupportedNetworks(c context.Context) ([]NetworkConfig, error)
 	SwitchNetwork(c context.Context, network string) error
 }
\ No newline at end of file
@@ -1,5 +1,5 @@
 package domain
 
 type ErrorResponse struct {
-	Message string `json: "message"`
+	Message string `json:"message"`
 }
\ No newline at end of file
@@ -0,0 +1,18 @@
+package domain
+
+// JSONWebKey 访问令牌校验公钥（RFC 7517）
+type JSONWebKey struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	N         string `json:"n,omitempty"`   // RSA模数
+	E         string `json:"e,omitempty"`   // RSA指数
+	Curve     string `json:"crv,omitempty"` // OKP曲线
+	X         string `json:"x,omitempty"`   // OKP公钥
+}
+
+// JSONWebKeySet 公钥集合，由 /.well-known/jwks.json 发布
+type JSONWebKeySet struct {
+	Keys []JSONWebKey `json:"keys"`
+}
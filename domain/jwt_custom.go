@@ -4,13 +4,24 @@ import (
 	"github.com/golang-jwt/jwt/v4"
 )
 
+// gin上下文中保存认证用户信息的键
+const (
+	ContextUserIDKey         = "x-user-id"
+	ContextUserNameKey       = "x-user-name"
+	// 当前访问令牌的jti与过期时间，供退出登录时吊销
+	ContextTokenIDKey        = "x-token-id"
+	ContextTokenExpiresAtKey = "x-token-exp"
+)
+
 type JwtCustomClaims struct {
 	Name string `json:"name"`
 	ID   string `json:"id"`
 	jwt.StandardClaims
 }
 
+// JwtCustomRefreshClaims 刷新令牌声明，jti(StandardClaims.Id)标识单个令牌，FamilyID标识同一次登录轮换出的令牌家族
 type JwtCustomRefreshClaims struct {
-	ID string `json:"id"`
+	ID       string `json:"id"`
+	FamilyID string `json:"fid"`
 	jwt.StandardClaims
 }
\ No newline at end of file
@@ -0,0 +1,23 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+type LogoutRequest struct {
+	RefreshToken string `form:"refreshToken" json:"refreshToken"`
+}
+
+// TokenRevocationRepository 访问令牌吊销列表（Redis存储），按jti吊销单个令牌，按用户吊销某时刻之前签发的全部令牌
+type TokenRevocationRepository interface {
+	RevokeToken(c context.Context, tokenID string, expiresAt int64) error
+	RevokeUserTokens(c context.Context, userID string, before int64, expiration time.Duration) error
+	IsRevoked(c context.Context, claims *JwtCustomClaims) (bool, error)
+}
+
+type LogoutUsecase interface {
+	RevokeAccessToken(c context.Context, tokenID string, expiresAt int64) error
+	RevokeRefreshToken(c context.Context, userID string, refreshToken string, secret string) error
+	RevokeAllTokens(c context.Context, userID string, expiry int) error
+}
@@ -0,0 +1,159 @@
+// Code generated by mockery v2.16.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/littlecheny/go-backend/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// BlockchainUsecase is an autogenerated mock type for the BlockchainUsecase type
+type BlockchainUsecase struct {
+	mock.Mock
+}
+
+// GetBlock provides a mock function with given fields: c, network, number
+func (_m *BlockchainUsecase) GetBlock(c context.Context, network string, number uint64) (*domain.BlockInfo, error) {
+	ret := _m.Called(c, network, number)
+
+	var r0 *domain.BlockInfo
+	if rf, ok := ret.Get(0).(func(context.Context, string, uint64) *domain.BlockInfo); ok {
+		r0 = rf(c, network, number)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.BlockInfo)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, uint64) error); ok {
+		r1 = rf(c, network, number)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetBlockTransactions provides a mock function with given fields: c, network, number
+func (_m *BlockchainUsecase) GetBlockTransactions(c context.Context, network string, number uint64) ([]domain.TransactionResponse, error) {
+	ret := _m.Called(c, network, number)
+
+	var r0 []domain.TransactionResponse
+	if rf, ok := ret.Get(0).(func(context.Context, string, uint64) []domain.TransactionResponse); ok {
+		r0 = rf(c, network, number)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.TransactionResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, uint64) error); ok {
+		r1 = rf(c, network, number)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetLatestBlocks provides a mock function with given fields: c, network, limit
+func (_m *BlockchainUsecase) GetLatestBlocks(c context.Context, network string, limit int) ([]domain.BlockInfo, error) {
+	ret := _m.Called(c, network, limit)
+
+	var r0 []domain.BlockInfo
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) []domain.BlockInfo); ok {
+		r0 = rf(c, network, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.BlockInfo)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
+		r1 = rf(c, network, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetNetworkStatus provides a mock function with given fields: c, network
+func (_m *BlockchainUsecase) GetNetworkStatus(c context.Context, network string) (*domain.BlockchainStatus, error) {
+	ret := _m.Called(c, network)
+
+	var r0 *domain.BlockchainStatus
+	if rf, ok := ret.Get(0).(func(context.Context, string) *domain.BlockchainStatus); ok {
+		r0 = rf(c, network)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.BlockchainStatus)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(c, network)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSupportedNetworks provides a mock function with given fields: c
+func (_m *BlockchainUsecase) GetSupportedNetworks(c context.Context) ([]domain.NetworkConfig, error) {
+	ret := _m.Called(c)
+
+	var r0 []domain.NetworkConfig
+	if rf, ok := ret.Get(0).(func(context.Context) []domain.NetworkConfig); ok {
+		r0 = rf(c)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.NetworkConfig)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(c)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SwitchNetwork provides a mock function with given fields: c, network
+func (_m *BlockchainUsecase) SwitchNetwork(c context.Context, network string) error {
+	ret := _m.Called(c, network)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(c, network)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewBlockchainUsecase interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewBlockchainUsecase creates a new instance of BlockchainUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewBlockchainUsecase(t mockConstructorTestingTNewBlockchainUsecase) *BlockchainUsecase {
+	mock := &BlockchainUsecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
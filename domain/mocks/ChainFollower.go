@@ -0,0 +1,58 @@
+// Code generated by mockery v2.16.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/littlecheny/go-backend/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ChainFollower is an autogenerated mock type for the ChainFollower type
+type ChainFollower struct {
+	mock.Mock
+}
+
+// HandleHead provides a mock function with given fields: c, head
+func (_m *ChainFollower) HandleHead(c context.Context, head *domain.BlockInfo) error {
+	ret := _m.Called(c, head)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.BlockInfo) error); ok {
+		r0 = rf(c, head)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Run provides a mock function with given fields: ctx
+func (_m *ChainFollower) Run(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewChainFollower interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewChainFollower creates a new instance of ChainFollower. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewChainFollower(t mockConstructorTestingTNewChainFollower) *ChainFollower {
+	mock := &ChainFollower{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,200 @@
+// Code generated by mockery v2.16.0. DO NOT EDIT.
+
+package mocks
+
+import mock "github.com/stretchr/testify/mock"
+
+// CryptoService is an autogenerated mock type for the CryptoService type
+type CryptoService struct {
+	mock.Mock
+}
+
+// Decrypt provides a mock function with given fields: encryptedData, key
+func (_m *CryptoService) Decrypt(encryptedData string, key string) (string, error) {
+	ret := _m.Called(encryptedData, key)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(string, string) string); ok {
+		r0 = rf(encryptedData, key)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(encryptedData, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DecryptWithDataKey provides a mock function with given fields: encryptedData, dataKey
+func (_m *CryptoService) DecryptWithDataKey(encryptedData string, dataKey string) (string, error) {
+	ret := _m.Called(encryptedData, dataKey)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(string, string) string); ok {
+		r0 = rf(encryptedData, dataKey)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(encryptedData, dataKey)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeriveKey provides a mock function with given fields: password, salt
+func (_m *CryptoService) DeriveKey(password string, salt string) (string, error) {
+	ret := _m.Called(password, salt)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(string, string) string); ok {
+		r0 = rf(password, salt)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(password, salt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Encrypt provides a mock function with given fields: data, key
+func (_m *CryptoService) Encrypt(data string, key string) (string, error) {
+	ret := _m.Called(data, key)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(string, string) string); ok {
+		r0 = rf(data, key)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(data, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// EncryptWithDataKey provides a mock function with given fields: data, dataKey
+func (_m *CryptoService) EncryptWithDataKey(data string, dataKey string) (string, error) {
+	ret := _m.Called(data, dataKey)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(string, string) string); ok {
+		r0 = rf(data, dataKey)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(data, dataKey)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GenerateDataKey provides a mock function with given fields:
+func (_m *CryptoService) GenerateDataKey() (string, error) {
+	ret := _m.Called()
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GenerateSalt provides a mock function with given fields:
+func (_m *CryptoService) GenerateSalt() (string, error) {
+	ret := _m.Called()
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Hash provides a mock function with given fields: data
+func (_m *CryptoService) Hash(data string) string {
+	ret := _m.Called(data)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(string) string); ok {
+		r0 = rf(data)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// VerifyHash provides a mock function with given fields: data, hash
+func (_m *CryptoService) VerifyHash(data string, hash string) bool {
+	ret := _m.Called(data, hash)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(string, string) bool); ok {
+		r0 = rf(data, hash)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewCryptoService interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewCryptoService creates a new instance of CryptoService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewCryptoService(t mockConstructorTestingTNewCryptoService) *CryptoService {
+	mock := &CryptoService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
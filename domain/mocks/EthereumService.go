@@ -0,0 +1,542 @@
+// Code generated by mockery v2.16.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/littlecheny/go-backend/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// EthereumService is an autogenerated mock type for the EthereumService type
+type EthereumService struct {
+	mock.Mock
+}
+
+// Connect provides a mock function with given fields: ctx, rpcURL
+func (_m *EthereumService) Connect(ctx context.Context, rpcURL string) error {
+	ret := _m.Called(ctx, rpcURL)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, rpcURL)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateAccount provides a mock function with given fields: passphrase
+func (_m *EthereumService) CreateAccount(passphrase string) (string, string, string, error) {
+	ret := _m.Called(passphrase)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(string) string); ok {
+		r0 = rf(passphrase)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 string
+	if rf, ok := ret.Get(1).(func(string) string); ok {
+		r1 = rf(passphrase)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	var r2 string
+	if rf, ok := ret.Get(2).(func(string) string); ok {
+		r2 = rf(passphrase)
+	} else {
+		r2 = ret.Get(2).(string)
+	}
+
+	var r3 error
+	if rf, ok := ret.Get(3).(func(string) error); ok {
+		r3 = rf(passphrase)
+	} else {
+		r3 = ret.Error(3)
+	}
+
+	return r0, r1, r2, r3
+}
+
+// DeriveAccount provides a mock function with given fields: mnemonic, passphrase, index
+func (_m *EthereumService) DeriveAccount(mnemonic string, passphrase string, index uint32) (string, string, error) {
+	ret := _m.Called(mnemonic, passphrase, index)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(string, string, uint32) string); ok {
+		r0 = rf(mnemonic, passphrase, index)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 string
+	if rf, ok := ret.Get(1).(func(string, string, uint32) string); ok {
+		r1 = rf(mnemonic, passphrase, index)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(string, string, uint32) error); ok {
+		r2 = rf(mnemonic, passphrase, index)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// Disconnect provides a mock function with given fields:
+func (_m *EthereumService) Disconnect() error {
+	ret := _m.Called()
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// EstimateGas provides a mock function with given fields: ctx, from, to, value
+func (_m *EthereumService) EstimateGas(ctx context.Context, from string, to string, value string) (uint64, error) {
+	ret := _m.Called(ctx, from, to, value)
+
+	var r0 uint64
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) uint64); ok {
+		r0 = rf(ctx, from, to, value)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, from, to, value)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetBalance provides a mock function with given fields: ctx, address
+func (_m *EthereumService) GetBalance(ctx context.Context, address string) (string, error) {
+	ret := _m.Called(ctx, address)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(context.Context, string) string); ok {
+		r0 = rf(ctx, address)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, address)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetBlockByNumber provides a mock function with given fields: ctx, number
+func (_m *EthereumService) GetBlockByNumber(ctx context.Context, number uint64) (*domain.BlockInfo, error) {
+	ret := _m.Called(ctx, number)
+
+	var r0 *domain.BlockInfo
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) *domain.BlockInfo); ok {
+		r0 = rf(ctx, number)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.BlockInfo)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uint64) error); ok {
+		r1 = rf(ctx, number)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetBlockTransactions provides a mock function with given fields: ctx, number
+func (_m *EthereumService) GetBlockTransactions(ctx context.Context, number uint64) ([]domain.TransactionResponse, error) {
+	ret := _m.Called(ctx, number)
+
+	var r0 []domain.TransactionResponse
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) []domain.TransactionResponse); ok {
+		r0 = rf(ctx, number)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.TransactionResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uint64) error); ok {
+		r1 = rf(ctx, number)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetGasPrice provides a mock function with given fields: ctx
+func (_m *EthereumService) GetGasPrice(ctx context.Context) (string, error) {
+	ret := _m.Called(ctx)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(context.Context) string); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetLatestBlock provides a mock function with given fields: ctx
+func (_m *EthereumService) GetLatestBlock(ctx context.Context) (*domain.BlockInfo, error) {
+	ret := _m.Called(ctx)
+
+	var r0 *domain.BlockInfo
+	if rf, ok := ret.Get(0).(func(context.Context) *domain.BlockInfo); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.BlockInfo)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetLatestPrice provides a mock function with given fields: ctx, aggregatorAddress
+func (_m *EthereumService) GetLatestPrice(ctx context.Context, aggregatorAddress string) (*domain.PriceRound, error) {
+	ret := _m.Called(ctx, aggregatorAddress)
+
+	var r0 *domain.PriceRound
+	if rf, ok := ret.Get(0).(func(context.Context, string) *domain.PriceRound); ok {
+		r0 = rf(ctx, aggregatorAddress)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.PriceRound)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, aggregatorAddress)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetNetworkID provides a mock function with given fields:
+func (_m *EthereumService) GetNetworkID() (int64, error) {
+	ret := _m.Called()
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func() int64); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetNonce provides a mock function with given fields: ctx, address
+func (_m *EthereumService) GetNonce(ctx context.Context, address string) (uint64, error) {
+	ret := _m.Called(ctx, address)
+
+	var r0 uint64
+	if rf, ok := ret.Get(0).(func(context.Context, string) uint64); ok {
+		r0 = rf(ctx, address)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, address)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTokenBalance provides a mock function with given fields: ctx, contractAddress, address
+func (_m *EthereumService) GetTokenBalance(ctx context.Context, contractAddress string, address string) (string, error) {
+	ret := _m.Called(ctx, contractAddress, address)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) string); ok {
+		r0 = rf(ctx, contractAddress, address)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, contractAddress, address)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTokenTransfers provides a mock function with given fields: ctx, fromBlock, toBlock, contractAddresses, addresses
+func (_m *EthereumService) GetTokenTransfers(ctx context.Context, fromBlock uint64, toBlock uint64, contractAddresses []string, addresses []string) ([]domain.TokenTransfer, error) {
+	ret := _m.Called(ctx, fromBlock, toBlock, contractAddresses, addresses)
+
+	var r0 []domain.TokenTransfer
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64, []string, []string) []domain.TokenTransfer); ok {
+		r0 = rf(ctx, fromBlock, toBlock, contractAddresses, addresses)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.TokenTransfer)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, uint64, []string, []string) error); ok {
+		r1 = rf(ctx, fromBlock, toBlock, contractAddresses, addresses)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTransaction provides a mock function with given fields: ctx, hash
+func (_m *EthereumService) GetTransaction(ctx context.Context, hash string) (*domain.TransactionResponse, error) {
+	ret := _m.Called(ctx, hash)
+
+	var r0 *domain.TransactionResponse
+	if rf, ok := ret.Get(0).(func(context.Context, string) *domain.TransactionResponse); ok {
+		r0 = rf(ctx, hash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.TransactionResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, hash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTransactionReceipt provides a mock function with given fields: ctx, hash
+func (_m *EthereumService) GetTransactionReceipt(ctx context.Context, hash string) (*domain.TransactionReceipt, error) {
+	ret := _m.Called(ctx, hash)
+
+	var r0 *domain.TransactionReceipt
+	if rf, ok := ret.Get(0).(func(context.Context, string) *domain.TransactionReceipt); ok {
+		r0 = rf(ctx, hash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.TransactionReceipt)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, hash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ImportAccount provides a mock function with given fields: mnemonic, passphrase
+func (_m *EthereumService) ImportAccount(mnemonic string, passphrase string) (string, string, error) {
+	ret := _m.Called(mnemonic, passphrase)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(string, string) string); ok {
+		r0 = rf(mnemonic, passphrase)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 string
+	if rf, ok := ret.Get(1).(func(string, string) string); ok {
+		r1 = rf(mnemonic, passphrase)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(string, string) error); ok {
+		r2 = rf(mnemonic, passphrase)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// IsConnected provides a mock function with given fields:
+func (_m *EthereumService) IsConnected() bool {
+	ret := _m.Called()
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// ScanTransactions provides a mock function with given fields: ctx, number, addresses
+func (_m *EthereumService) ScanTransactions(ctx context.Context, number uint64, addresses []string) ([]domain.TransactionResponse, error) {
+	ret := _m.Called(ctx, number, addresses)
+
+	var r0 []domain.TransactionResponse
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, []string) []domain.TransactionResponse); ok {
+		r0 = rf(ctx, number, addresses)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.TransactionResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, []string) error); ok {
+		r1 = rf(ctx, number, addresses)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SendTokenTransfer provides a mock function with given fields: ctx, from, contractAddress, to, privateKey, amount, fees
+func (_m *EthereumService) SendTokenTransfer(ctx context.Context, from string, contractAddress string, to string, privateKey string, amount string, fees *domain.TransactionFees) (*domain.SignedTransaction, error) {
+	ret := _m.Called(ctx, from, contractAddress, to, privateKey, amount, fees)
+
+	var r0 *domain.SignedTransaction
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string, string, *domain.TransactionFees) *domain.SignedTransaction); ok {
+		r0 = rf(ctx, from, contractAddress, to, privateKey, amount, fees)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.SignedTransaction)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, string, string, *domain.TransactionFees) error); ok {
+		r1 = rf(ctx, from, contractAddress, to, privateKey, amount, fees)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SendTransaction provides a mock function with given fields: ctx, from, to, privateKey, value, fees
+func (_m *EthereumService) SendTransaction(ctx context.Context, from string, to string, privateKey string, value string, fees *domain.TransactionFees) (*domain.SignedTransaction, error) {
+	ret := _m.Called(ctx, from, to, privateKey, value, fees)
+
+	var r0 *domain.SignedTransaction
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string, *domain.TransactionFees) *domain.SignedTransaction); ok {
+		r0 = rf(ctx, from, to, privateKey, value, fees)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.SignedTransaction)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, string, *domain.TransactionFees) error); ok {
+		r1 = rf(ctx, from, to, privateKey, value, fees)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SubscribeNewHeads provides a mock function with given fields: ctx
+func (_m *EthereumService) SubscribeNewHeads(ctx context.Context) (<-chan *domain.BlockInfo, error) {
+	ret := _m.Called(ctx)
+
+	var r0 <-chan *domain.BlockInfo
+	if rf, ok := ret.Get(0).(func(context.Context) <-chan *domain.BlockInfo); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan *domain.BlockInfo)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewEthereumService interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewEthereumService creates a new instance of EthereumService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewEthereumService(t mockConstructorTestingTNewEthereumService) *EthereumService {
+	mock := &EthereumService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
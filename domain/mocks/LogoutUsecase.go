@@ -0,0 +1,71 @@
+// Code generated by mockery v2.16.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// LogoutUsecase is an autogenerated mock type for the LogoutUsecase type
+type LogoutUsecase struct {
+	mock.Mock
+}
+
+// RevokeAccessToken provides a mock function with given fields: c, tokenID, expiresAt
+func (_m *LogoutUsecase) RevokeAccessToken(c context.Context, tokenID string, expiresAt int64) error {
+	ret := _m.Called(c, tokenID, expiresAt)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64) error); ok {
+		r0 = rf(c, tokenID, expiresAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RevokeAllTokens provides a mock function with given fields: c, userID, expiry
+func (_m *LogoutUsecase) RevokeAllTokens(c context.Context, userID string, expiry int) error {
+	ret := _m.Called(c, userID, expiry)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) error); ok {
+		r0 = rf(c, userID, expiry)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RevokeRefreshToken provides a mock function with given fields: c, userID, refreshToken, secret
+func (_m *LogoutUsecase) RevokeRefreshToken(c context.Context, userID string, refreshToken string, secret string) error {
+	ret := _m.Called(c, userID, refreshToken, secret)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) error); ok {
+		r0 = rf(c, userID, refreshToken, secret)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewLogoutUsecase interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewLogoutUsecase creates a new instance of LogoutUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewLogoutUsecase(t mockConstructorTestingTNewLogoutUsecase) *LogoutUsecase {
+	mock := &LogoutUsecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
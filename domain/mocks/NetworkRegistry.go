@@ -0,0 +1,104 @@
+// Code generated by mockery v2.16.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	domain "github.com/littlecheny/go-backend/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NetworkRegistry is an autogenerated mock type for the NetworkRegistry type
+type NetworkRegistry struct {
+	mock.Mock
+}
+
+// Close provides a mock function with given fields:
+func (_m *NetworkRegistry) Close() error {
+	ret := _m.Called()
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetClient provides a mock function with given fields: network
+func (_m *NetworkRegistry) GetClient(network string) (domain.EthereumService, error) {
+	ret := _m.Called(network)
+
+	var r0 domain.EthereumService
+	if rf, ok := ret.Get(0).(func(string) domain.EthereumService); ok {
+		r0 = rf(network)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(domain.EthereumService)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(network)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetNetwork provides a mock function with given fields: network
+func (_m *NetworkRegistry) GetNetwork(network string) (*domain.NetworkConfig, error) {
+	ret := _m.Called(network)
+
+	var r0 *domain.NetworkConfig
+	if rf, ok := ret.Get(0).(func(string) *domain.NetworkConfig); ok {
+		r0 = rf(network)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.NetworkConfig)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(network)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetNetworks provides a mock function with given fields:
+func (_m *NetworkRegistry) GetNetworks() []domain.NetworkConfig {
+	ret := _m.Called()
+
+	var r0 []domain.NetworkConfig
+	if rf, ok := ret.Get(0).(func() []domain.NetworkConfig); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.NetworkConfig)
+		}
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewNetworkRegistry interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewNetworkRegistry creates a new instance of NetworkRegistry. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewNetworkRegistry(t mockConstructorTestingTNewNetworkRegistry) *NetworkRegistry {
+	mock := &NetworkRegistry{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
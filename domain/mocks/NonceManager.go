@@ -0,0 +1,108 @@
+// Code generated by mockery v2.16.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NonceManager is an autogenerated mock type for the NonceManager type
+type NonceManager struct {
+	mock.Mock
+}
+
+// Get provides a mock function with given fields: c, network, address
+func (_m *NonceManager) Get(c context.Context, network string, address string) (uint64, bool, error) {
+	ret := _m.Called(c, network, address)
+
+	var r0 uint64
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) uint64); ok {
+		r0 = rf(c, network, address)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	var r1 bool
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) bool); ok {
+		r1 = rf(c, network, address)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, string, string) error); ok {
+		r2 = rf(c, network, address)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// Lock provides a mock function with given fields: c, network, address
+func (_m *NonceManager) Lock(c context.Context, network string, address string) (func(), error) {
+	ret := _m.Called(c, network, address)
+
+	var r0 func()
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) func()); ok {
+		r0 = rf(c, network, address)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(func())
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(c, network, address)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Reset provides a mock function with given fields: c, network, address
+func (_m *NonceManager) Reset(c context.Context, network string, address string) error {
+	ret := _m.Called(c, network, address)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(c, network, address)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Set provides a mock function with given fields: c, network, address, nonce
+func (_m *NonceManager) Set(c context.Context, network string, address string, nonce uint64) error {
+	ret := _m.Called(c, network, address, nonce)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, uint64) error); ok {
+		r0 = rf(c, network, address, nonce)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewNonceManager interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewNonceManager creates a new instance of NonceManager. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewNonceManager(t mockConstructorTestingTNewNonceManager) *NonceManager {
+	mock := &NonceManager{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,50 @@
+// Code generated by mockery v2.16.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// PriceProvider is an autogenerated mock type for the PriceProvider type
+type PriceProvider struct {
+	mock.Mock
+}
+
+// GetPriceUSD provides a mock function with given fields: ctx, symbol
+func (_m *PriceProvider) GetPriceUSD(ctx context.Context, symbol string) (string, error) {
+	ret := _m.Called(ctx, symbol)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(context.Context, string) string); ok {
+		r0 = rf(ctx, symbol)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, symbol)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewPriceProvider interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewPriceProvider creates a new instance of PriceProvider. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewPriceProvider(t mockConstructorTestingTNewPriceProvider) *PriceProvider {
+	mock := &PriceProvider{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,479 @@
+// Code generated by mockery v2.16.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	time "time"
+
+	domain "github.com/littlecheny/go-backend/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// RedisService is an autogenerated mock type for the RedisService type
+type RedisService struct {
+	mock.Mock
+}
+
+// AddToSet provides a mock function with given fields: ctx, key, members
+func (_m *RedisService) AddToSet(ctx context.Context, key string, members ...interface{}) error {
+	var _ca []interface{}
+	_ca = append(_ca, ctx, key)
+	_ca = append(_ca, members...)
+	ret := _m.Called(_ca...)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, ...interface{}) error); ok {
+		r0 = rf(ctx, key, members...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CompareAndDelete provides a mock function with given fields: ctx, key, value
+func (_m *RedisService) CompareAndDelete(ctx context.Context, key string, value interface{}) (bool, error) {
+	ret := _m.Called(ctx, key, value)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, string, interface{}) bool); ok {
+		r0 = rf(ctx, key, value)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, interface{}) error); ok {
+		r1 = rf(ctx, key, value)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CompareAndSwap provides a mock function with given fields: ctx, key, old, value, expiration
+func (_m *RedisService) CompareAndSwap(ctx context.Context, key string, old interface{}, value interface{}, expiration time.Duration) (bool, error) {
+	ret := _m.Called(ctx, key, old, value, expiration)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, string, interface{}, interface{}, time.Duration) bool); ok {
+		r0 = rf(ctx, key, old, value, expiration)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, interface{}, interface{}, time.Duration) error); ok {
+		r1 = rf(ctx, key, old, value, expiration)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Del provides a mock function with given fields: ctx, key
+func (_m *RedisService) Del(ctx context.Context, key string) error {
+	ret := _m.Called(ctx, key)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, key)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteBlock provides a mock function with given fields: ctx, network, number
+func (_m *RedisService) DeleteBlock(ctx context.Context, network string, number uint64) error {
+	ret := _m.Called(ctx, network, number)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, uint64) error); ok {
+		r0 = rf(ctx, network, number)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteTransaction provides a mock function with given fields: ctx, hash
+func (_m *RedisService) DeleteTransaction(ctx context.Context, hash string) error {
+	ret := _m.Called(ctx, hash)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, hash)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Exists provides a mock function with given fields: ctx, key
+func (_m *RedisService) Exists(ctx context.Context, key string) (bool, error) {
+	ret := _m.Called(ctx, key)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = rf(ctx, key)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Get provides a mock function with given fields: ctx, key
+func (_m *RedisService) Get(ctx context.Context, key string) (string, error) {
+	ret := _m.Called(ctx, key)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(context.Context, string) string); ok {
+		r0 = rf(ctx, key)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetBalance provides a mock function with given fields: ctx, address
+func (_m *RedisService) GetBalance(ctx context.Context, address string) (string, error) {
+	ret := _m.Called(ctx, address)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(context.Context, string) string); ok {
+		r0 = rf(ctx, address)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, address)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetBlock provides a mock function with given fields: ctx, network, number
+func (_m *RedisService) GetBlock(ctx context.Context, network string, number uint64) (*domain.BlockInfo, error) {
+	ret := _m.Called(ctx, network, number)
+
+	var r0 *domain.BlockInfo
+	if rf, ok := ret.Get(0).(func(context.Context, string, uint64) *domain.BlockInfo); ok {
+		r0 = rf(ctx, network, number)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.BlockInfo)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, uint64) error); ok {
+		r1 = rf(ctx, network, number)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetGasPrice provides a mock function with given fields: ctx, network
+func (_m *RedisService) GetGasPrice(ctx context.Context, network string) (string, error) {
+	ret := _m.Called(ctx, network)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(context.Context, string) string); ok {
+		r0 = rf(ctx, network)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, network)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetNetworkStatus provides a mock function with given fields: ctx, network
+func (_m *RedisService) GetNetworkStatus(ctx context.Context, network string) (*domain.BlockchainStatus, error) {
+	ret := _m.Called(ctx, network)
+
+	var r0 *domain.BlockchainStatus
+	if rf, ok := ret.Get(0).(func(context.Context, string) *domain.BlockchainStatus); ok {
+		r0 = rf(ctx, network)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.BlockchainStatus)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, network)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPrice provides a mock function with given fields: ctx, symbol
+func (_m *RedisService) GetPrice(ctx context.Context, symbol string) (string, error) {
+	ret := _m.Called(ctx, symbol)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(context.Context, string) string); ok {
+		r0 = rf(ctx, symbol)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, symbol)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSetMembers provides a mock function with given fields: ctx, key
+func (_m *RedisService) GetSetMembers(ctx context.Context, key string) ([]string, error) {
+	ret := _m.Called(ctx, key)
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(context.Context, string) []string); ok {
+		r0 = rf(ctx, key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTransaction provides a mock function with given fields: ctx, hash
+func (_m *RedisService) GetTransaction(ctx context.Context, hash string) (*domain.TransactionResponse, error) {
+	ret := _m.Called(ctx, hash)
+
+	var r0 *domain.TransactionResponse
+	if rf, ok := ret.Get(0).(func(context.Context, string) *domain.TransactionResponse); ok {
+		r0 = rf(ctx, hash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.TransactionResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, hash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RemoveFromSet provides a mock function with given fields: ctx, key, members
+func (_m *RedisService) RemoveFromSet(ctx context.Context, key string, members ...interface{}) error {
+	var _ca []interface{}
+	_ca = append(_ca, ctx, key)
+	_ca = append(_ca, members...)
+	ret := _m.Called(_ca...)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, ...interface{}) error); ok {
+		r0 = rf(ctx, key, members...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Set provides a mock function with given fields: ctx, key, value, expiration
+func (_m *RedisService) Set(ctx context.Context, key string, value interface{}, expiration time.Duration) error {
+	ret := _m.Called(ctx, key, value, expiration)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, interface{}, time.Duration) error); ok {
+		r0 = rf(ctx, key, value, expiration)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetBalance provides a mock function with given fields: ctx, address, balance, expiration
+func (_m *RedisService) SetBalance(ctx context.Context, address string, balance string, expiration time.Duration) error {
+	ret := _m.Called(ctx, address, balance, expiration)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Duration) error); ok {
+		r0 = rf(ctx, address, balance, expiration)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetBlock provides a mock function with given fields: ctx, network, number, block, expiration
+func (_m *RedisService) SetBlock(ctx context.Context, network string, number uint64, block *domain.BlockInfo, expiration time.Duration) error {
+	ret := _m.Called(ctx, network, number, block, expiration)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, uint64, *domain.BlockInfo, time.Duration) error); ok {
+		r0 = rf(ctx, network, number, block, expiration)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetExpiration provides a mock function with given fields: ctx, key, expiration
+func (_m *RedisService) SetExpiration(ctx context.Context, key string, expiration time.Duration) error {
+	ret := _m.Called(ctx, key, expiration)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration) error); ok {
+		r0 = rf(ctx, key, expiration)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetGasPrice provides a mock function with given fields: ctx, network, gasPrice, expiration
+func (_m *RedisService) SetGasPrice(ctx context.Context, network string, gasPrice string, expiration time.Duration) error {
+	ret := _m.Called(ctx, network, gasPrice, expiration)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Duration) error); ok {
+		r0 = rf(ctx, network, gasPrice, expiration)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetNX provides a mock function with given fields: ctx, key, value, expiration
+func (_m *RedisService) SetNX(ctx context.Context, key string, value interface{}, expiration time.Duration) (bool, error) {
+	ret := _m.Called(ctx, key, value, expiration)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, string, interface{}, time.Duration) bool); ok {
+		r0 = rf(ctx, key, value, expiration)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, interface{}, time.Duration) error); ok {
+		r1 = rf(ctx, key, value, expiration)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetNetworkStatus provides a mock function with given fields: ctx, network, status, expiration
+func (_m *RedisService) SetNetworkStatus(ctx context.Context, network string, status *domain.BlockchainStatus, expiration time.Duration) error {
+	ret := _m.Called(ctx, network, status, expiration)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *domain.BlockchainStatus, time.Duration) error); ok {
+		r0 = rf(ctx, network, status, expiration)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetPrice provides a mock function with given fields: ctx, symbol, price, expiration
+func (_m *RedisService) SetPrice(ctx context.Context, symbol string, price string, expiration time.Duration) error {
+	ret := _m.Called(ctx, symbol, price, expiration)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Duration) error); ok {
+		r0 = rf(ctx, symbol, price, expiration)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetTransaction provides a mock function with given fields: ctx, hash, tx, expiration
+func (_m *RedisService) SetTransaction(ctx context.Context, hash string, tx *domain.TransactionResponse, expiration time.Duration) error {
+	ret := _m.Called(ctx, hash, tx, expiration)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *domain.TransactionResponse, time.Duration) error); ok {
+		r0 = rf(ctx, hash, tx, expiration)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewRedisService interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewRedisService creates a new instance of RedisService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewRedisService(t mockConstructorTestingTNewRedisService) *RedisService {
+	mock := &RedisService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,107 @@
+// Code generated by mockery v2.16.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/littlecheny/go-backend/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// RefreshTokenRepository is an autogenerated mock type for the RefreshTokenRepository type
+type RefreshTokenRepository struct {
+	mock.Mock
+}
+
+// GetCurrent provides a mock function with given fields: c, familyID
+func (_m *RefreshTokenRepository) GetCurrent(c context.Context, familyID string) (string, error) {
+	ret := _m.Called(c, familyID)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(context.Context, string) string); ok {
+		r0 = rf(c, familyID)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(c, familyID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RevokeFamily provides a mock function with given fields: c, familyID
+func (_m *RefreshTokenRepository) RevokeFamily(c context.Context, familyID string) error {
+	ret := _m.Called(c, familyID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(c, familyID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RevokeUserFamilies provides a mock function with given fields: c, userID
+func (_m *RefreshTokenRepository) RevokeUserFamilies(c context.Context, userID string) error {
+	ret := _m.Called(c, userID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(c, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RotateCurrent provides a mock function with given fields: c, previousID, claims
+func (_m *RefreshTokenRepository) RotateCurrent(c context.Context, previousID string, claims *domain.JwtCustomRefreshClaims) error {
+	ret := _m.Called(c, previousID, claims)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *domain.JwtCustomRefreshClaims) error); ok {
+		r0 = rf(c, previousID, claims)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SaveCurrent provides a mock function with given fields: c, claims
+func (_m *RefreshTokenRepository) SaveCurrent(c context.Context, claims *domain.JwtCustomRefreshClaims) error {
+	ret := _m.Called(c, claims)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.JwtCustomRefreshClaims) error); ok {
+		r0 = rf(c, claims)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewRefreshTokenRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewRefreshTokenRepository creates a new instance of RefreshTokenRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewRefreshTokenRepository(t mockConstructorTestingTNewRefreshTokenRepository) *RefreshTokenRepository {
+	mock := &RefreshTokenRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -35,20 +35,20 @@ func (_m *RefreshTokenUsecase) CreateAccessToken(user *domain.User, secret strin
 	return r0, r1
 }
 
-// CreateRefreshToken provides a mock function with given fields: user, secret, expiry
-func (_m *RefreshTokenUsecase) CreateRefreshToken(user *domain.User, secret string, expiry int) (string, error) {
-	ret := _m.Called(user, secret, expiry)
+// GetUserByID provides a mock function with given fields: c, id
+func (_m *RefreshTokenUsecase) GetUserByID(c context.Context, id string) (domain.User, error) {
+	ret := _m.Called(c, id)
 
-	var r0 string
-	if rf, ok := ret.Get(0).(func(*domain.User, string, int) string); ok {
-		r0 = rf(user, secret, expiry)
+	var r0 domain.User
+	if rf, ok := ret.Get(0).(func(context.Context, string) domain.User); ok {
+		r0 = rf(c, id)
 	} else {
-		r0 = ret.Get(0).(string)
+		r0 = ret.Get(0).(domain.User)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(*domain.User, string, int) error); ok {
-		r1 = rf(user, secret, expiry)
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(c, id)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -56,20 +56,20 @@ func (_m *RefreshTokenUsecase) CreateRefreshToken(user *domain.User, secret stri
 	return r0, r1
 }
 
-// ExtractIDFromToken provides a mock function with given fields: requestToken, secret
-func (_m *RefreshTokenUsecase) ExtractIDFromToken(requestToken string, secret string) (string, error) {
-	ret := _m.Called(requestToken, secret)
+// RotateRefreshToken provides a mock function with given fields: c, user, claims, secret, expiry
+func (_m *RefreshTokenUsecase) RotateRefreshToken(c context.Context, user *domain.User, claims *domain.JwtCustomRefreshClaims, secret string, expiry int) (string, error) {
+	ret := _m.Called(c, user, claims, secret, expiry)
 
 	var r0 string
-	if rf, ok := ret.Get(0).(func(string, string) string); ok {
-		r0 = rf(requestToken, secret)
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.User, *domain.JwtCustomRefreshClaims, string, int) string); ok {
+		r0 = rf(c, user, claims, secret, expiry)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string, string) error); ok {
-		r1 = rf(requestToken, secret)
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.User, *domain.JwtCustomRefreshClaims, string, int) error); ok {
+		r1 = rf(c, user, claims, secret, expiry)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -77,20 +77,22 @@ func (_m *RefreshTokenUsecase) ExtractIDFromToken(requestToken string, secret st
 	return r0, r1
 }
 
-// GetUserByID provides a mock function with given fields: c, id
-func (_m *RefreshTokenUsecase) GetUserByID(c context.Context, id string) (domain.User, error) {
-	ret := _m.Called(c, id)
+// ValidateRefreshToken provides a mock function with given fields: c, requestToken, secret
+func (_m *RefreshTokenUsecase) ValidateRefreshToken(c context.Context, requestToken string, secret string) (*domain.JwtCustomRefreshClaims, error) {
+	ret := _m.Called(c, requestToken, secret)
 
-	var r0 domain.User
-	if rf, ok := ret.Get(0).(func(context.Context, string) domain.User); ok {
-		r0 = rf(c, id)
+	var r0 *domain.JwtCustomRefreshClaims
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *domain.JwtCustomRefreshClaims); ok {
+		r0 = rf(c, requestToken, secret)
 	} else {
-		r0 = ret.Get(0).(domain.User)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.JwtCustomRefreshClaims)
+		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(c, id)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(c, requestToken, secret)
 	} else {
 		r1 = ret.Error(1)
 	}
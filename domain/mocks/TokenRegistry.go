@@ -0,0 +1,83 @@
+// Code generated by mockery v2.16.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	domain "github.com/littlecheny/go-backend/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// TokenRegistry is an autogenerated mock type for the TokenRegistry type
+type TokenRegistry struct {
+	mock.Mock
+}
+
+// GetAllTokens provides a mock function with given fields:
+func (_m *TokenRegistry) GetAllTokens() []domain.Token {
+	ret := _m.Called()
+
+	var r0 []domain.Token
+	if rf, ok := ret.Get(0).(func() []domain.Token); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Token)
+		}
+	}
+
+	return r0
+}
+
+// GetToken provides a mock function with given fields: network, contractAddress
+func (_m *TokenRegistry) GetToken(network string, contractAddress string) (*domain.Token, error) {
+	ret := _m.Called(network, contractAddress)
+
+	var r0 *domain.Token
+	if rf, ok := ret.Get(0).(func(string, string) *domain.Token); ok {
+		r0 = rf(network, contractAddress)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Token)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(network, contractAddress)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTokens provides a mock function with given fields: network
+func (_m *TokenRegistry) GetTokens(network string) []domain.Token {
+	ret := _m.Called(network)
+
+	var r0 []domain.Token
+	if rf, ok := ret.Get(0).(func(string) []domain.Token); ok {
+		r0 = rf(network)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Token)
+		}
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewTokenRegistry interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewTokenRegistry creates a new instance of TokenRegistry. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewTokenRegistry(t mockConstructorTestingTNewTokenRegistry) *TokenRegistry {
+	mock := &TokenRegistry{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,80 @@
+// Code generated by mockery v2.16.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	time "time"
+
+	domain "github.com/littlecheny/go-backend/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// TokenRevocationRepository is an autogenerated mock type for the TokenRevocationRepository type
+type TokenRevocationRepository struct {
+	mock.Mock
+}
+
+// IsRevoked provides a mock function with given fields: c, claims
+func (_m *TokenRevocationRepository) IsRevoked(c context.Context, claims *domain.JwtCustomClaims) (bool, error) {
+	ret := _m.Called(c, claims)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.JwtCustomClaims) bool); ok {
+		r0 = rf(c, claims)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.JwtCustomClaims) error); ok {
+		r1 = rf(c, claims)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RevokeToken provides a mock function with given fields: c, tokenID, expiresAt
+func (_m *TokenRevocationRepository) RevokeToken(c context.Context, tokenID string, expiresAt int64) error {
+	ret := _m.Called(c, tokenID, expiresAt)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64) error); ok {
+		r0 = rf(c, tokenID, expiresAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RevokeUserTokens provides a mock function with given fields: c, userID, before, expiration
+func (_m *TokenRevocationRepository) RevokeUserTokens(c context.Context, userID string, before int64, expiration time.Duration) error {
+	ret := _m.Called(c, userID, before, expiration)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64, time.Duration) error); ok {
+		r0 = rf(c, userID, before, expiration)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewTokenRevocationRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewTokenRevocationRepository creates a new instance of TokenRevocationRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewTokenRevocationRepository(t mockConstructorTestingTNewTokenRevocationRepository) *TokenRevocationRepository {
+	mock := &TokenRevocationRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,53 @@
+// Code generated by mockery v2.16.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/littlecheny/go-backend/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// TokenUsecase is an autogenerated mock type for the TokenUsecase type
+type TokenUsecase struct {
+	mock.Mock
+}
+
+// GetTokens provides a mock function with given fields: c, network
+func (_m *TokenUsecase) GetTokens(c context.Context, network string) ([]domain.Token, error) {
+	ret := _m.Called(c, network)
+
+	var r0 []domain.Token
+	if rf, ok := ret.Get(0).(func(context.Context, string) []domain.Token); ok {
+		r0 = rf(c, network)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Token)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(c, network)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewTokenUsecase interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewTokenUsecase creates a new instance of TokenUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewTokenUsecase(t mockConstructorTestingTNewTokenUsecase) *TokenUsecase {
+	mock := &TokenUsecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
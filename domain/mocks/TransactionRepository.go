@@ -0,0 +1,271 @@
+// Code generated by mockery v2.16.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/littlecheny/go-backend/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// TransactionRepository is an autogenerated mock type for the TransactionRepository type
+type TransactionRepository struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: c, transaction
+func (_m *TransactionRepository) Create(c context.Context, transaction *domain.Transaction) error {
+	ret := _m.Called(c, transaction)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Transaction) error); ok {
+		r0 = rf(c, transaction)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetByHash provides a mock function with given fields: c, hash
+func (_m *TransactionRepository) GetByHash(c context.Context, hash string) (domain.Transaction, error) {
+	ret := _m.Called(c, hash)
+
+	var r0 domain.Transaction
+	if rf, ok := ret.Get(0).(func(context.Context, string) domain.Transaction); ok {
+		r0 = rf(c, hash)
+	} else {
+		r0 = ret.Get(0).(domain.Transaction)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(c, hash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByHashAndUserID provides a mock function with given fields: c, hash, userID
+func (_m *TransactionRepository) GetByHashAndUserID(c context.Context, hash string, userID string) ([]domain.Transaction, error) {
+	ret := _m.Called(c, hash, userID)
+
+	var r0 []domain.Transaction
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) []domain.Transaction); ok {
+		r0 = rf(c, hash, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Transaction)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(c, hash, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByHashAndWalletID provides a mock function with given fields: c, hash, walletID
+func (_m *TransactionRepository) GetByHashAndWalletID(c context.Context, hash string, walletID string) (domain.Transaction, error) {
+	ret := _m.Called(c, hash, walletID)
+
+	var r0 domain.Transaction
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) domain.Transaction); ok {
+		r0 = rf(c, hash, walletID)
+	} else {
+		r0 = ret.Get(0).(domain.Transaction)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(c, hash, walletID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByID provides a mock function with given fields: c, id
+func (_m *TransactionRepository) GetByID(c context.Context, id string) (domain.Transaction, error) {
+	ret := _m.Called(c, id)
+
+	var r0 domain.Transaction
+	if rf, ok := ret.Get(0).(func(context.Context, string) domain.Transaction); ok {
+		r0 = rf(c, id)
+	} else {
+		r0 = ret.Get(0).(domain.Transaction)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(c, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByNonce provides a mock function with given fields: c, network, from, nonce
+func (_m *TransactionRepository) GetByNonce(c context.Context, network string, from string, nonce uint64) (domain.Transaction, error) {
+	ret := _m.Called(c, network, from, nonce)
+
+	var r0 domain.Transaction
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, uint64) domain.Transaction); ok {
+		r0 = rf(c, network, from, nonce)
+	} else {
+		r0 = ret.Get(0).(domain.Transaction)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, uint64) error); ok {
+		r1 = rf(c, network, from, nonce)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByUserID provides a mock function with given fields: c, userID, limit, offset
+func (_m *TransactionRepository) GetByUserID(c context.Context, userID string, limit int, offset int) ([]domain.Transaction, error) {
+	ret := _m.Called(c, userID, limit, offset)
+
+	var r0 []domain.Transaction
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, int) []domain.Transaction); ok {
+		r0 = rf(c, userID, limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Transaction)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, int, int) error); ok {
+		r1 = rf(c, userID, limit, offset)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByWalletID provides a mock function with given fields: c, walletID, limit, offset
+func (_m *TransactionRepository) GetByWalletID(c context.Context, walletID string, limit int, offset int) ([]domain.Transaction, error) {
+	ret := _m.Called(c, walletID, limit, offset)
+
+	var r0 []domain.Transaction
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, int) []domain.Transaction); ok {
+		r0 = rf(c, walletID, limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Transaction)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, int, int) error); ok {
+		r1 = rf(c, walletID, limit, offset)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetMinedAfter provides a mock function with given fields: c, network, blockNumber
+func (_m *TransactionRepository) GetMinedAfter(c context.Context, network string, blockNumber uint64) ([]domain.Transaction, error) {
+	ret := _m.Called(c, network, blockNumber)
+
+	var r0 []domain.Transaction
+	if rf, ok := ret.Get(0).(func(context.Context, string, uint64) []domain.Transaction); ok {
+		r0 = rf(c, network, blockNumber)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Transaction)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, uint64) error); ok {
+		r1 = rf(c, network, blockNumber)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPendingTransactions provides a mock function with given fields: c
+func (_m *TransactionRepository) GetPendingTransactions(c context.Context) ([]domain.Transaction, error) {
+	ret := _m.Called(c)
+
+	var r0 []domain.Transaction
+	if rf, ok := ret.Get(0).(func(context.Context) []domain.Transaction); ok {
+		r0 = rf(c)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Transaction)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(c)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Update provides a mock function with given fields: c, transaction
+func (_m *TransactionRepository) Update(c context.Context, transaction *domain.Transaction) error {
+	ret := _m.Called(c, transaction)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Transaction) error); ok {
+		r0 = rf(c, transaction)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateStatus provides a mock function with given fields: c, hash, status
+func (_m *TransactionRepository) UpdateStatus(c context.Context, hash string, status domain.TransactionStatus) error {
+	ret := _m.Called(c, hash, status)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, domain.TransactionStatus) error); ok {
+		r0 = rf(c, hash, status)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewTransactionRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewTransactionRepository creates a new instance of TransactionRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewTransactionRepository(t mockConstructorTestingTNewTransactionRepository) *TransactionRepository {
+	mock := &TransactionRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,48 @@
+// Code generated by mockery v2.16.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// TransactionTracker is an autogenerated mock type for the TransactionTracker type
+type TransactionTracker struct {
+	mock.Mock
+}
+
+// CheckPending provides a mock function with given fields: c
+func (_m *TransactionTracker) CheckPending(c context.Context) error {
+	ret := _m.Called(c)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(c)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Run provides a mock function with given fields: ctx
+func (_m *TransactionTracker) Run(ctx context.Context) {
+	_m.Called(ctx)
+}
+
+type mockConstructorTestingTNewTransactionTracker interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewTransactionTracker creates a new instance of TransactionTracker. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewTransactionTracker(t mockConstructorTestingTNewTransactionTracker) *TransactionTracker {
+	mock := &TransactionTracker{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
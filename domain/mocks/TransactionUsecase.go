@@ -0,0 +1,247 @@
+// Code generated by mockery v2.16.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/littlecheny/go-backend/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// TransactionUsecase is an autogenerated mock type for the TransactionUsecase type
+type TransactionUsecase struct {
+	mock.Mock
+}
+
+// CancelTransaction provides a mock function with given fields: c, userID, transactionID, req
+func (_m *TransactionUsecase) CancelTransaction(c context.Context, userID string, transactionID string, req *domain.TransactionReplaceRequest) (*domain.TransactionResponse, error) {
+	ret := _m.Called(c, userID, transactionID, req)
+
+	var r0 *domain.TransactionResponse
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *domain.TransactionReplaceRequest) *domain.TransactionResponse); ok {
+		r0 = rf(c, userID, transactionID, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.TransactionResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, *domain.TransactionReplaceRequest) error); ok {
+		r1 = rf(c, userID, transactionID, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// EstimateGas provides a mock function with given fields: c, network, from, to, value
+func (_m *TransactionUsecase) EstimateGas(c context.Context, network string, from string, to string, value string) (uint64, error) {
+	ret := _m.Called(c, network, from, to, value)
+
+	var r0 uint64
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string) uint64); ok {
+		r0 = rf(c, network, from, to, value)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, string) error); ok {
+		r1 = rf(c, network, from, to, value)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetGasPrice provides a mock function with given fields: c, network
+func (_m *TransactionUsecase) GetGasPrice(c context.Context, network string) (string, error) {
+	ret := _m.Called(c, network)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(context.Context, string) string); ok {
+		r0 = rf(c, network)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(c, network)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTransaction provides a mock function with given fields: c, userID, transactionID
+func (_m *TransactionUsecase) GetTransaction(c context.Context, userID string, transactionID string) (*domain.TransactionResponse, error) {
+	ret := _m.Called(c, userID, transactionID)
+
+	var r0 *domain.TransactionResponse
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *domain.TransactionResponse); ok {
+		r0 = rf(c, userID, transactionID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.TransactionResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(c, userID, transactionID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTransactionByHash provides a mock function with given fields: c, userID, hash
+func (_m *TransactionUsecase) GetTransactionByHash(c context.Context, userID string, hash string) (*domain.TransactionResponse, error) {
+	ret := _m.Called(c, userID, hash)
+
+	var r0 *domain.TransactionResponse
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *domain.TransactionResponse); ok {
+		r0 = rf(c, userID, hash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.TransactionResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(c, userID, hash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTransactions provides a mock function with given fields: c, userID, limit, offset
+func (_m *TransactionUsecase) GetTransactions(c context.Context, userID string, limit int, offset int) ([]domain.TransactionResponse, error) {
+	ret := _m.Called(c, userID, limit, offset)
+
+	var r0 []domain.TransactionResponse
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, int) []domain.TransactionResponse); ok {
+		r0 = rf(c, userID, limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.TransactionResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, int, int) error); ok {
+		r1 = rf(c, userID, limit, offset)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetWalletHistory provides a mock function with given fields: c, userID, walletID, limit, offset
+func (_m *TransactionUsecase) GetWalletHistory(c context.Context, userID string, walletID string, limit int, offset int) ([]domain.TransactionResponse, error) {
+	ret := _m.Called(c, userID, walletID, limit, offset)
+
+	var r0 []domain.TransactionResponse
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int, int) []domain.TransactionResponse); ok {
+		r0 = rf(c, userID, walletID, limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.TransactionResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, int, int) error); ok {
+		r1 = rf(c, userID, walletID, limit, offset)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SendTransaction provides a mock function with given fields: c, userID, req
+func (_m *TransactionUsecase) SendTransaction(c context.Context, userID string, req *domain.TransactionSendRequest) (*domain.TransactionResponse, error) {
+	ret := _m.Called(c, userID, req)
+
+	var r0 *domain.TransactionResponse
+	if rf, ok := ret.Get(0).(func(context.Context, string, *domain.TransactionSendRequest) *domain.TransactionResponse); ok {
+		r0 = rf(c, userID, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.TransactionResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, *domain.TransactionSendRequest) error); ok {
+		r1 = rf(c, userID, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SpeedUpTransaction provides a mock function with given fields: c, userID, transactionID, req
+func (_m *TransactionUsecase) SpeedUpTransaction(c context.Context, userID string, transactionID string, req *domain.TransactionReplaceRequest) (*domain.TransactionResponse, error) {
+	ret := _m.Called(c, userID, transactionID, req)
+
+	var r0 *domain.TransactionResponse
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *domain.TransactionReplaceRequest) *domain.TransactionResponse); ok {
+		r0 = rf(c, userID, transactionID, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.TransactionResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, *domain.TransactionReplaceRequest) error); ok {
+		r1 = rf(c, userID, transactionID, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateTransactionStatus provides a mock function with given fields: c, hash, status
+func (_m *TransactionUsecase) UpdateTransactionStatus(c context.Context, hash string, status domain.TransactionStatus) error {
+	ret := _m.Called(c, hash, status)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, domain.TransactionStatus) error); ok {
+		r0 = rf(c, hash, status)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewTransactionUsecase interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewTransactionUsecase creates a new instance of TransactionUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewTransactionUsecase(t mockConstructorTestingTNewTransactionUsecase) *TransactionUsecase {
+	mock := &TransactionUsecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
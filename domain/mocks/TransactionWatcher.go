@@ -0,0 +1,86 @@
+// Code generated by mockery v2.16.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/littlecheny/go-backend/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// TransactionWatcher is an autogenerated mock type for the TransactionWatcher type
+type TransactionWatcher struct {
+	mock.Mock
+}
+
+// CatchUp provides a mock function with given fields: c, head
+func (_m *TransactionWatcher) CatchUp(c context.Context, head uint64) error {
+	ret := _m.Called(c, head)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) error); ok {
+		r0 = rf(c, head)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// HandleTokenTransfer provides a mock function with given fields: c, transfer
+func (_m *TransactionWatcher) HandleTokenTransfer(c context.Context, transfer *domain.TokenTransfer) error {
+	ret := _m.Called(c, transfer)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.TokenTransfer) error); ok {
+		r0 = rf(c, transfer)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// HandleTransaction provides a mock function with given fields: c, tx
+func (_m *TransactionWatcher) HandleTransaction(c context.Context, tx *domain.TransactionResponse) error {
+	ret := _m.Called(c, tx)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.TransactionResponse) error); ok {
+		r0 = rf(c, tx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Run provides a mock function with given fields: ctx
+func (_m *TransactionWatcher) Run(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewTransactionWatcher interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewTransactionWatcher creates a new instance of TransactionWatcher. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewTransactionWatcher(t mockConstructorTestingTNewTransactionWatcher) *TransactionWatcher {
+	mock := &TransactionWatcher{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
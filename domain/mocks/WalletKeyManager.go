@@ -0,0 +1,137 @@
+// Code generated by mockery v2.16.0. DO NOT EDIT.
+
+package mocks
+
+import mock "github.com/stretchr/testify/mock"
+
+// WalletKeyManager is an autogenerated mock type for the WalletKeyManager type
+type WalletKeyManager struct {
+	mock.Mock
+}
+
+// CurrentVersion provides a mock function with given fields:
+func (_m *WalletKeyManager) CurrentVersion() int {
+	ret := _m.Called()
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func() int); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	return r0
+}
+
+// LegacyKey provides a mock function with given fields: passwordKey
+func (_m *WalletKeyManager) LegacyKey(passwordKey string) (string, error) {
+	ret := _m.Called(passwordKey)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(string) string); ok {
+		r0 = rf(passwordKey)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(passwordKey)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RewrapDataKey provides a mock function with given fields: wrappedKey, version
+func (_m *WalletKeyManager) RewrapDataKey(wrappedKey string, version int) (string, int, error) {
+	ret := _m.Called(wrappedKey, version)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(string, int) string); ok {
+		r0 = rf(wrappedKey, version)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 int
+	if rf, ok := ret.Get(1).(func(string, int) int); ok {
+		r1 = rf(wrappedKey, version)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(string, int) error); ok {
+		r2 = rf(wrappedKey, version)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// UnwrapDataKey provides a mock function with given fields: wrappedKey, version, passwordKey
+func (_m *WalletKeyManager) UnwrapDataKey(wrappedKey string, version int, passwordKey string) (string, error) {
+	ret := _m.Called(wrappedKey, version, passwordKey)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(string, int, string) string); ok {
+		r0 = rf(wrappedKey, version, passwordKey)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, int, string) error); ok {
+		r1 = rf(wrappedKey, version, passwordKey)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WrapDataKey provides a mock function with given fields: dataKey, passwordKey
+func (_m *WalletKeyManager) WrapDataKey(dataKey string, passwordKey string) (string, int, error) {
+	ret := _m.Called(dataKey, passwordKey)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(string, string) string); ok {
+		r0 = rf(dataKey, passwordKey)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 int
+	if rf, ok := ret.Get(1).(func(string, string) int); ok {
+		r1 = rf(dataKey, passwordKey)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(string, string) error); ok {
+		r2 = rf(dataKey, passwordKey)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+type mockConstructorTestingTNewWalletKeyManager interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewWalletKeyManager creates a new instance of WalletKeyManager. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewWalletKeyManager(t mockConstructorTestingTNewWalletKeyManager) *WalletKeyManager {
+	mock := &WalletKeyManager{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,53 @@
+// Code generated by mockery v2.16.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/littlecheny/go-backend/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// WalletRekeyUsecase is an autogenerated mock type for the WalletRekeyUsecase type
+type WalletRekeyUsecase struct {
+	mock.Mock
+}
+
+// RekeyPrivateData provides a mock function with given fields: c, batchSize
+func (_m *WalletRekeyUsecase) RekeyPrivateData(c context.Context, batchSize int) (*domain.WalletRekeyResult, error) {
+	ret := _m.Called(c, batchSize)
+
+	var r0 *domain.WalletRekeyResult
+	if rf, ok := ret.Get(0).(func(context.Context, int) *domain.WalletRekeyResult); ok {
+		r0 = rf(c, batchSize)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.WalletRekeyResult)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(c, batchSize)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewWalletRekeyUsecase interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewWalletRekeyUsecase creates a new instance of WalletRekeyUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewWalletRekeyUsecase(t mockConstructorTestingTNewWalletRekeyUsecase) *WalletRekeyUsecase {
+	mock := &WalletRekeyUsecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
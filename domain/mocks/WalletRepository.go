@@ -0,0 +1,456 @@
+// Code generated by mockery v2.16.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/littlecheny/go-backend/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// WalletRepository is an autogenerated mock type for the WalletRepository type
+type WalletRepository struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: ctx, wallet
+func (_m *WalletRepository) Create(ctx context.Context, wallet *domain.Wallet) error {
+	ret := _m.Called(ctx, wallet)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Wallet) error); ok {
+		r0 = rf(ctx, wallet)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreatePrivateData provides a mock function with given fields: ctx, data
+func (_m *WalletRepository) CreatePrivateData(ctx context.Context, data *domain.WalletPrivateData) error {
+	ret := _m.Called(ctx, data)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.WalletPrivateData) error); ok {
+		r0 = rf(ctx, data)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Delete provides a mock function with given fields: ctx, id
+func (_m *WalletRepository) Delete(ctx context.Context, id string) error {
+	ret := _m.Called(ctx, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeletePrivateData provides a mock function with given fields: ctx, walletID
+func (_m *WalletRepository) DeletePrivateData(ctx context.Context, walletID string) error {
+	ret := _m.Called(ctx, walletID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, walletID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// EnsureIndexes provides a mock function with given fields: ctx
+func (_m *WalletRepository) EnsureIndexes(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetActiveWallets provides a mock function with given fields: ctx, network
+func (_m *WalletRepository) GetActiveWallets(ctx context.Context, network string) ([]domain.Wallet, error) {
+	ret := _m.Called(ctx, network)
+
+	var r0 []domain.Wallet
+	if rf, ok := ret.Get(0).(func(context.Context, string) []domain.Wallet); ok {
+		r0 = rf(ctx, network)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Wallet)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, network)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByAddress provides a mock function with given fields: ctx, address
+func (_m *WalletRepository) GetByAddress(ctx context.Context, address string) (*domain.Wallet, error) {
+	ret := _m.Called(ctx, address)
+
+	var r0 *domain.Wallet
+	if rf, ok := ret.Get(0).(func(context.Context, string) *domain.Wallet); ok {
+		r0 = rf(ctx, address)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Wallet)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, address)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByAddressAndNetwork provides a mock function with given fields: ctx, address, network
+func (_m *WalletRepository) GetByAddressAndNetwork(ctx context.Context, address string, network string) ([]domain.Wallet, error) {
+	ret := _m.Called(ctx, address, network)
+
+	var r0 []domain.Wallet
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) []domain.Wallet); ok {
+		r0 = rf(ctx, address, network)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Wallet)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, address, network)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByID provides a mock function with given fields: ctx, id
+func (_m *WalletRepository) GetByID(ctx context.Context, id string) (*domain.Wallet, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *domain.Wallet
+	if rf, ok := ret.Get(0).(func(context.Context, string) *domain.Wallet); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Wallet)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByUserID provides a mock function with given fields: ctx, userID, page, pageSize
+func (_m *WalletRepository) GetByUserID(ctx context.Context, userID string, page int, pageSize int) ([]domain.Wallet, int, error) {
+	ret := _m.Called(ctx, userID, page, pageSize)
+
+	var r0 []domain.Wallet
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, int) []domain.Wallet); ok {
+		r0 = rf(ctx, userID, page, pageSize)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Wallet)
+		}
+	}
+
+	var r1 int
+	if rf, ok := ret.Get(1).(func(context.Context, string, int, int) int); ok {
+		r1 = rf(ctx, userID, page, pageSize)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, string, int, int) error); ok {
+		r2 = rf(ctx, userID, page, pageSize)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetDefaultWallet provides a mock function with given fields: ctx, userID, network
+func (_m *WalletRepository) GetDefaultWallet(ctx context.Context, userID string, network string) (*domain.Wallet, error) {
+	ret := _m.Called(ctx, userID, network)
+
+	var r0 *domain.Wallet
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *domain.Wallet); ok {
+		r0 = rf(ctx, userID, network)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Wallet)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, userID, network)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetNetworkStats provides a mock function with given fields: ctx, userID
+func (_m *WalletRepository) GetNetworkStats(ctx context.Context, userID string) ([]domain.NetworkStats, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 []domain.NetworkStats
+	if rf, ok := ret.Get(0).(func(context.Context, string) []domain.NetworkStats); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.NetworkStats)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPrivateData provides a mock function with given fields: ctx, walletID
+func (_m *WalletRepository) GetPrivateData(ctx context.Context, walletID string) (*domain.WalletPrivateData, error) {
+	ret := _m.Called(ctx, walletID)
+
+	var r0 *domain.WalletPrivateData
+	if rf, ok := ret.Get(0).(func(context.Context, string) *domain.WalletPrivateData); ok {
+		r0 = rf(ctx, walletID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.WalletPrivateData)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, walletID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetStalePrivateData provides a mock function with given fields: ctx, masterKeyVersion, afterID, limit
+func (_m *WalletRepository) GetStalePrivateData(ctx context.Context, masterKeyVersion int, afterID string, limit int) ([]domain.WalletPrivateData, error) {
+	ret := _m.Called(ctx, masterKeyVersion, afterID, limit)
+
+	var r0 []domain.WalletPrivateData
+	if rf, ok := ret.Get(0).(func(context.Context, int, string, int) []domain.WalletPrivateData); ok {
+		r0 = rf(ctx, masterKeyVersion, afterID, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.WalletPrivateData)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int, string, int) error); ok {
+		r1 = rf(ctx, masterKeyVersion, afterID, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTotalBalance provides a mock function with given fields: ctx, userID, network
+func (_m *WalletRepository) GetTotalBalance(ctx context.Context, userID string, network string) (string, error) {
+	ret := _m.Called(ctx, userID, network)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) string); ok {
+		r0 = rf(ctx, userID, network)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, userID, network)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUserWalletCount provides a mock function with given fields: ctx, userID
+func (_m *WalletRepository) GetUserWalletCount(ctx context.Context, userID string) (int, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context, string) int); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetWalletsByNetwork provides a mock function with given fields: ctx, userID, network
+func (_m *WalletRepository) GetWalletsByNetwork(ctx context.Context, userID string, network string) ([]domain.Wallet, error) {
+	ret := _m.Called(ctx, userID, network)
+
+	var r0 []domain.Wallet
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) []domain.Wallet); ok {
+		r0 = rf(ctx, userID, network)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Wallet)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, userID, network)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetDefaultWallet provides a mock function with given fields: ctx, userID, walletID
+func (_m *WalletRepository) SetDefaultWallet(ctx context.Context, userID string, walletID string) error {
+	ret := _m.Called(ctx, userID, walletID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, userID, walletID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Update provides a mock function with given fields: ctx, wallet
+func (_m *WalletRepository) Update(ctx context.Context, wallet *domain.Wallet) error {
+	ret := _m.Called(ctx, wallet)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Wallet) error); ok {
+		r0 = rf(ctx, wallet)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateBalance provides a mock function with given fields: ctx, walletID, balance, balanceUSD
+func (_m *WalletRepository) UpdateBalance(ctx context.Context, walletID string, balance string, balanceUSD string) error {
+	ret := _m.Called(ctx, walletID, balance, balanceUSD)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) error); ok {
+		r0 = rf(ctx, walletID, balance, balanceUSD)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateDataKey provides a mock function with given fields: ctx, id, fromVersion, encryptedDataKey, toVersion
+func (_m *WalletRepository) UpdateDataKey(ctx context.Context, id string, fromVersion int, encryptedDataKey string, toVersion int) (bool, error) {
+	ret := _m.Called(ctx, id, fromVersion, encryptedDataKey, toVersion)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, string, int) bool); ok {
+		r0 = rf(ctx, id, fromVersion, encryptedDataKey, toVersion)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, int, string, int) error); ok {
+		r1 = rf(ctx, id, fromVersion, encryptedDataKey, toVersion)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdatePrivateData provides a mock function with given fields: ctx, data
+func (_m *WalletRepository) UpdatePrivateData(ctx context.Context, data *domain.WalletPrivateData) error {
+	ret := _m.Called(ctx, data)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.WalletPrivateData) error); ok {
+		r0 = rf(ctx, data)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewWalletRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewWalletRepository creates a new instance of WalletRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewWalletRepository(t mockConstructorTestingTNewWalletRepository) *WalletRepository {
+	mock := &WalletRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
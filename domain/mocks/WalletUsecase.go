@@ -0,0 +1,404 @@
+// Code generated by mockery v2.16.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/littlecheny/go-backend/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// WalletUsecase is an autogenerated mock type for the WalletUsecase type
+type WalletUsecase struct {
+	mock.Mock
+}
+
+// CreateWallet provides a mock function with given fields: ctx, userID, req
+func (_m *WalletUsecase) CreateWallet(ctx context.Context, userID string, req *domain.WalletCreateRequest) (*domain.WalletResponse, error) {
+	ret := _m.Called(ctx, userID, req)
+
+	var r0 *domain.WalletResponse
+	if rf, ok := ret.Get(0).(func(context.Context, string, *domain.WalletCreateRequest) *domain.WalletResponse); ok {
+		r0 = rf(ctx, userID, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.WalletResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, *domain.WalletCreateRequest) error); ok {
+		r1 = rf(ctx, userID, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteWallet provides a mock function with given fields: ctx, userID, walletID
+func (_m *WalletUsecase) DeleteWallet(ctx context.Context, userID string, walletID string) error {
+	ret := _m.Called(ctx, userID, walletID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, userID, walletID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeriveWallet provides a mock function with given fields: ctx, userID, walletID, req
+func (_m *WalletUsecase) DeriveWallet(ctx context.Context, userID string, walletID string, req *domain.WalletDeriveRequest) (*domain.WalletResponse, error) {
+	ret := _m.Called(ctx, userID, walletID, req)
+
+	var r0 *domain.WalletResponse
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *domain.WalletDeriveRequest) *domain.WalletResponse); ok {
+		r0 = rf(ctx, userID, walletID, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.WalletResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, *domain.WalletDeriveRequest) error); ok {
+		r1 = rf(ctx, userID, walletID, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ExportMnemonic provides a mock function with given fields: ctx, userID, walletID, password
+func (_m *WalletUsecase) ExportMnemonic(ctx context.Context, userID string, walletID string, password string) (string, error) {
+	ret := _m.Called(ctx, userID, walletID, password)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) string); ok {
+		r0 = rf(ctx, userID, walletID, password)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, userID, walletID, password)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ExportPrivateKey provides a mock function with given fields: ctx, userID, walletID, password
+func (_m *WalletUsecase) ExportPrivateKey(ctx context.Context, userID string, walletID string, password string) (string, error) {
+	ret := _m.Called(ctx, userID, walletID, password)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) string); ok {
+		r0 = rf(ctx, userID, walletID, password)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, userID, walletID, password)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetBalance provides a mock function with given fields: ctx, userID, walletID
+func (_m *WalletUsecase) GetBalance(ctx context.Context, userID string, walletID string) (*domain.WalletBalanceResponse, error) {
+	ret := _m.Called(ctx, userID, walletID)
+
+	var r0 *domain.WalletBalanceResponse
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *domain.WalletBalanceResponse); ok {
+		r0 = rf(ctx, userID, walletID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.WalletBalanceResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, userID, walletID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetDefaultWallet provides a mock function with given fields: ctx, userID, network
+func (_m *WalletUsecase) GetDefaultWallet(ctx context.Context, userID string, network string) (*domain.WalletResponse, error) {
+	ret := _m.Called(ctx, userID, network)
+
+	var r0 *domain.WalletResponse
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *domain.WalletResponse); ok {
+		r0 = rf(ctx, userID, network)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.WalletResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, userID, network)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTokenBalances provides a mock function with given fields: ctx, userID, walletID
+func (_m *WalletUsecase) GetTokenBalances(ctx context.Context, userID string, walletID string) ([]domain.TokenBalance, error) {
+	ret := _m.Called(ctx, userID, walletID)
+
+	var r0 []domain.TokenBalance
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) []domain.TokenBalance); ok {
+		r0 = rf(ctx, userID, walletID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.TokenBalance)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, userID, walletID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetWallet provides a mock function with given fields: ctx, userID, walletID
+func (_m *WalletUsecase) GetWallet(ctx context.Context, userID string, walletID string) (*domain.WalletResponse, error) {
+	ret := _m.Called(ctx, userID, walletID)
+
+	var r0 *domain.WalletResponse
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *domain.WalletResponse); ok {
+		r0 = rf(ctx, userID, walletID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.WalletResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, userID, walletID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetWalletStats provides a mock function with given fields: ctx, userID
+func (_m *WalletUsecase) GetWalletStats(ctx context.Context, userID string) (*domain.WalletStatsResponse, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 *domain.WalletStatsResponse
+	if rf, ok := ret.Get(0).(func(context.Context, string) *domain.WalletStatsResponse); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.WalletStatsResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetWallets provides a mock function with given fields: ctx, userID, page, pageSize
+func (_m *WalletUsecase) GetWallets(ctx context.Context, userID string, page int, pageSize int) (*domain.WalletListResponse, error) {
+	ret := _m.Called(ctx, userID, page, pageSize)
+
+	var r0 *domain.WalletListResponse
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, int) *domain.WalletListResponse); ok {
+		r0 = rf(ctx, userID, page, pageSize)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.WalletListResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, int, int) error); ok {
+		r1 = rf(ctx, userID, page, pageSize)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetWalletsByNetwork provides a mock function with given fields: ctx, userID, network
+func (_m *WalletUsecase) GetWalletsByNetwork(ctx context.Context, userID string, network string) ([]domain.WalletResponse, error) {
+	ret := _m.Called(ctx, userID, network)
+
+	var r0 []domain.WalletResponse
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) []domain.WalletResponse); ok {
+		r0 = rf(ctx, userID, network)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.WalletResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, userID, network)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ImportWallet provides a mock function with given fields: ctx, userID, req
+func (_m *WalletUsecase) ImportWallet(ctx context.Context, userID string, req *domain.WalletImportRequest) (*domain.WalletResponse, error) {
+	ret := _m.Called(ctx, userID, req)
+
+	var r0 *domain.WalletResponse
+	if rf, ok := ret.Get(0).(func(context.Context, string, *domain.WalletImportRequest) *domain.WalletResponse); ok {
+		r0 = rf(ctx, userID, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.WalletResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, *domain.WalletImportRequest) error); ok {
+		r1 = rf(ctx, userID, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RefreshAllBalances provides a mock function with given fields: ctx, userID
+func (_m *WalletUsecase) RefreshAllBalances(ctx context.Context, userID string) error {
+	ret := _m.Called(ctx, userID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RefreshBalance provides a mock function with given fields: ctx, userID, walletID
+func (_m *WalletUsecase) RefreshBalance(ctx context.Context, userID string, walletID string) (*domain.WalletBalanceResponse, error) {
+	ret := _m.Called(ctx, userID, walletID)
+
+	var r0 *domain.WalletBalanceResponse
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *domain.WalletBalanceResponse); ok {
+		r0 = rf(ctx, userID, walletID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.WalletBalanceResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, userID, walletID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetDefaultWallet provides a mock function with given fields: ctx, userID, walletID
+func (_m *WalletUsecase) SetDefaultWallet(ctx context.Context, userID string, walletID string) error {
+	ret := _m.Called(ctx, userID, walletID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, userID, walletID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SwitchNetwork provides a mock function with given fields: ctx, userID, walletID, network
+func (_m *WalletUsecase) SwitchNetwork(ctx context.Context, userID string, walletID string, network string) error {
+	ret := _m.Called(ctx, userID, walletID, network)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) error); ok {
+		r0 = rf(ctx, userID, walletID, network)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateWallet provides a mock function with given fields: ctx, userID, walletID, name
+func (_m *WalletUsecase) UpdateWallet(ctx context.Context, userID string, walletID string, name string) (*domain.WalletResponse, error) {
+	ret := _m.Called(ctx, userID, walletID, name)
+
+	var r0 *domain.WalletResponse
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) *domain.WalletResponse); ok {
+		r0 = rf(ctx, userID, walletID, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.WalletResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, userID, walletID, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewWalletUsecase interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewWalletUsecase creates a new instance of WalletUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewWalletUsecase(t mockConstructorTestingTNewWalletUsecase) *WalletUsecase {
+	mock := &WalletUsecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
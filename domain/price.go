@@ -0,0 +1,22 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var (
+	ErrPriceNotAvailable = errors.New("price not available")
+)
+
+// PriceRound Chainlink喂价合约的最新一轮报价，Answer已按合约decimals格式化为十进制
+type PriceRound struct {
+	Answer    string
+	UpdatedAt time.Time
+}
+
+// PriceProvider 按代币符号查询USD价格，返回十进制字符串；没有报价的符号返回ErrPriceNotAvailable
+type PriceProvider interface {
+	GetPriceUSD(ctx context.Context, symbol string) (string, error)
+}
@@ -0,0 +1,38 @@
+package domain
+
+import (
+	"context"
+	"errors"
+)
+
+var (
+	// ErrRefreshTokenInvalid 刷新令牌无效、过期或所属家族已被吊销
+	ErrRefreshTokenInvalid = errors.New("invalid refresh token")
+	// ErrRefreshTokenReused 已轮换的刷新令牌被再次使用，整个家族已被吊销
+	ErrRefreshTokenReused = errors.New("refresh token reuse detected")
+)
+
+type RefreshTokenRequest struct {
+	RefreshToken string `form:"refreshToken" json:"refreshToken" binding:"required"`
+}
+
+type RefreshTokenResponse struct {
+	AccessToken  string `json:"accessToken"`
+	RefreshToken string `json:"refreshToken"`
+}
+
+// RefreshTokenRepository 记录每个刷新令牌家族当前有效的jti（Redis存储）
+type RefreshTokenRepository interface {
+	SaveCurrent(c context.Context, claims *JwtCustomRefreshClaims) error
+	GetCurrent(c context.Context, familyID string) (string, error)
+	RotateCurrent(c context.Context, previousID string, claims *JwtCustomRefreshClaims) error
+	RevokeFamily(c context.Context, familyID string) error
+	RevokeUserFamilies(c context.Context, userID string) error
+}
+
+type RefreshTokenUsecase interface {
+	GetUserByID(c context.Context, id string) (User, error)
+	CreateAccessToken(user *User, secret string, expiry int) (accessToken string, err error)
+	ValidateRefreshToken(c context.Context, requestToken string, secret string) (*JwtCustomRefreshClaims, error)
+	RotateRefreshToken(c context.Context, user *User, claims *JwtCustomRefreshClaims, secret string, expiry int) (refreshToken string, err error)
+}
@@ -0,0 +1,5 @@
+package domain
+
+type SuccessResponse struct {
+	Message string `json:"message"`
+}
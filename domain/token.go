@@ -0,0 +1,49 @@
+package domain
+
+import (
+	"context"
+	"errors"
+)
+
+var (
+	ErrTokenNotFound = errors.New("token not found")
+)
+
+// Token ERC-20代币
+type Token struct {
+	Network         string `json:"network"`
+	Symbol          string `json:"symbol"`
+	Name            string `json:"name"`
+	ContractAddress string `json:"contract_address"`
+	Decimals        int    `json:"decimals"`
+}
+
+// TokenBalance 代币余额，Balance按代币精度格式化
+type TokenBalance struct {
+	Token
+	Balance string `json:"balance"`
+}
+
+// TokenTransfer ERC-20 Transfer事件，Amount为代币最小单位；Removed表示日志因链重组被移除
+type TokenTransfer struct {
+	Hash            string
+	ContractAddress string
+	From            string
+	To              string
+	Amount          string
+	BlockNumber     uint64
+	LogIndex        uint
+	Removed         bool
+}
+
+// TokenRegistry 各网络支持的代币，合约地址不区分大小写
+type TokenRegistry interface {
+	GetTokens(network string) []Token
+	GetToken(network, contractAddress string) (*Token, error)
+	GetAllTokens() []Token
+}
+
+// TokenUsecase 代币用例接口
+type TokenUsecase interface {
+	GetTokens(c context.Context, network string) ([]Token, error)
+}
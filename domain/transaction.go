@@ -2,6 +2,7 @@ package domain
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -11,6 +12,16 @@ const (
 	CollectionTransaction = "transactions"
 )
 
+var (
+	ErrTransactionNotFound    = errors.New("transaction not found")
+	ErrInvalidAddress         = errors.New("invalid address")
+	ErrInvalidAmount          = errors.New("invalid amount")
+	ErrReceiptNotFound        = errors.New("transaction receipt not found")
+	ErrWalletBusy             = errors.New("another transaction from this wallet is being sent")
+	ErrNotReplaceable         = errors.New("only pending outgoing transactions can be replaced")
+	ErrReplacementUnderpriced = errors.New("replacement fees must be at least 10% higher than the original")
+)
+
 // TransactionStatus 交易状态
 type TransactionStatus string
 
@@ -18,6 +29,7 @@ const (
 	TransactionStatusPending   TransactionStatus = "pending"
 	TransactionStatusConfirmed TransactionStatus = "confirmed"
 	TransactionStatusFailed    TransactionStatus = "failed"
+	TransactionStatusReplaced  TransactionStatus = "replaced" // 同一nonce的替换交易已上链或正在等待上链
 )
 
 // TransactionType 交易类型
@@ -28,6 +40,14 @@ const (
 	TransactionTypeReceive TransactionType = "receive"
 )
 
+// FeeModel 交易费用模型
+type FeeModel string
+
+const (
+	FeeModelLegacy  FeeModel = "legacy"  // gasPrice
+	FeeModelEIP1559 FeeModel = "eip1559" // maxFeePerGas + maxPriorityFeePerGas (type-2)
+)
+
 // Transaction 交易模型
 type Transaction struct {
 	ID              primitive.ObjectID `bson:"_id" json:"id"`
@@ -36,8 +56,14 @@ type Transaction struct {
 	Hash            string             `bson:"hash" json:"hash"`
 	From            string             `bson:"from" json:"from"`
 	To              string             `bson:"to" json:"to"`
-	Value           string             `bson:"value" json:"value"`           // Wei格式的金额
-	GasPrice        string             `bson:"gas_price" json:"gas_price"`   // Wei格式的Gas价格
+	Value           string             `bson:"value" json:"value"`           // Wei格式的金额，代币转账为代币最小单位
+	ContractAddress string             `bson:"contract_address,omitempty" json:"contract_address,omitempty"` // 代币合约地址，仅ERC-20转账
+	TokenSymbol     string             `bson:"token_symbol,omitempty" json:"token_symbol,omitempty"`
+	TokenDecimals   int                `bson:"token_decimals,omitempty" json:"token_decimals,omitempty"`
+	GasPrice        string             `bson:"gas_price" json:"gas_price"`   // Wei格式的Gas价格，EIP-1559交易为maxFeePerGas
+	FeeModel        FeeModel           `bson:"fee_model" json:"fee_model"`
+	MaxFeePerGas    string             `bson:"max_fee_per_gas,omitempty" json:"max_fee_per_gas,omitempty"`                   // Wei，仅EIP-1559
+	MaxPriorityFeePerGas string        `bson:"max_priority_fee_per_gas,omitempty" json:"max_priority_fee_per_gas,omitempty"` // Wei，仅EIP-1559
 	GasLimit        uint64             `bson:"gas_limit" json:"gas_limit"`
 	GasUsed         uint64             `bson:"gas_used" json:"gas_used"`
 	Nonce           uint64             `bson:"nonce" json:"nonce"`
@@ -50,15 +76,50 @@ type Transaction struct {
 	CreatedAt       time.Time          `bson:"created_at" json:"created_at"`
 	UpdatedAt       time.Time          `bson:"updated_at" json:"updated_at"`
 	ConfirmedAt     *time.Time         `bson:"confirmed_at" json:"confirmed_at,omitempty"`
+	Replaces        string             `bson:"replaces,omitempty" json:"replaces,omitempty"`       // 被本交易替换的交易哈希
+	ReplacedBy      string             `bson:"replaced_by,omitempty" json:"replaced_by,omitempty"` // 替换本交易的交易哈希
 }
 
 // TransactionSendRequest 发送交易请求
 type TransactionSendRequest struct {
 	WalletID string `json:"wallet_id" binding:"required"`
 	To       string `json:"to" binding:"required"`
-	Amount   string `json:"amount" binding:"required"` // ETH格式的金额
+	Amount   string `json:"amount" binding:"required"` // ETH格式的金额，代币转账按代币精度
+	ContractAddress string `json:"contract_address,omitempty"` // 可选，代币合约地址，为空时转账ETH
 	Password string `json:"password" binding:"required"`
-	GasPrice string `json:"gas_price,omitempty"` // 可选，自动估算
+	GasPrice string `json:"gas_price,omitempty"` // 可选，Gwei，自动估算
+	MaxFeePerGas         string `json:"max_fee_per_gas,omitempty"`          // 可选，Gwei，默认由eth_feeHistory估算
+	MaxPriorityFeePerGas string `json:"max_priority_fee_per_gas,omitempty"` // 可选，Gwei，默认由eth_feeHistory估算
+}
+
+// TransactionReplaceRequest 加速或取消pending交易的请求，费用未指定时在原交易基础上提高20%
+type TransactionReplaceRequest struct {
+	Password             string `json:"password" binding:"required"`
+	GasPrice             string `json:"gas_price,omitempty"`                // 可选，Gwei
+	MaxFeePerGas         string `json:"max_fee_per_gas,omitempty"`          // 可选，Gwei
+	MaxPriorityFeePerGas string `json:"max_priority_fee_per_gas,omitempty"` // 可选，Gwei
+}
+
+// TransactionFees 交易费用参数(Wei)，为空的字段由节点数据估算；
+// 支持London的网络发送EIP-1559交易，只给出GasPrice时作为maxFeePerGas和maxPriorityFeePerGas。
+// Nonce为空时使用节点的pending nonce，由NonceManager分配或替换交易时指定
+type TransactionFees struct {
+	GasPrice             string
+	MaxFeePerGas         string
+	MaxPriorityFeePerGas string
+	Nonce                *uint64
+}
+
+// SignedTransaction 已签名并广播的交易
+type SignedTransaction struct {
+	Hash                 string
+	From                 string
+	Nonce                uint64
+	GasLimit             uint64
+	FeeModel             FeeModel
+	GasPrice             string // Wei，EIP-1559交易为maxFeePerGas
+	MaxFeePerGas         string // Wei，仅EIP-1559
+	MaxPriorityFeePerGas string // Wei，仅EIP-1559
 }
 
 // TransactionResponse 交易响应
@@ -67,10 +128,16 @@ type TransactionResponse struct {
 	Hash           string             `json:"hash"`
 	From           string             `json:"from"`
 	To             string             `json:"to"`
-	Value          string             `json:"value"`          // ETH格式的金额
+	Value          string             `json:"value"`          // ETH格式的金额，代币转账按代币精度
+	ContractAddress string            `json:"contract_address,omitempty"`
+	TokenSymbol    string             `json:"token_symbol,omitempty"`
 	GasPrice       string             `json:"gas_price"`      // Gwei格式
+	FeeModel       FeeModel           `json:"fee_model,omitempty"`
+	MaxFeePerGas   string             `json:"max_fee_per_gas,omitempty"`          // Gwei格式，仅EIP-1559
+	MaxPriorityFeePerGas string       `json:"max_priority_fee_per_gas,omitempty"` // Gwei格式，仅EIP-1559
 	GasLimit       uint64             `json:"gas_limit"`
 	GasUsed        uint64             `json:"gas_used"`
+	Nonce          uint64             `json:"nonce"`
 	Status         TransactionStatus  `json:"status"`
 	Type           TransactionType    `json:"type"`
 	Network        string             `json:"network"`
@@ -78,6 +145,9 @@ type TransactionResponse struct {
 	TransactionFee string             `json:"transaction_fee"` // ETH格式
 	CreatedAt      time.Time          `json:"created_at"`
 	ConfirmedAt    *time.Time         `json:"confirmed_at,omitempty"`
+	Replaces       string             `json:"replaces,omitempty"`
+	ReplacedBy     string             `json:"replaced_by,omitempty"`
+	Logs           []TransactionLog   `json:"logs,omitempty"` // 仅从链上解析的已上链交易
 }
 
 // TransactionRepository 交易仓库接口
@@ -85,11 +155,27 @@ type TransactionRepository interface {
 	Create(c context.Context, transaction *Transaction) error
 	GetByID(c context.Context, id string) (Transaction, error)
 	GetByHash(c context.Context, hash string) (Transaction, error)
+	GetByHashAndWalletID(c context.Context, hash, walletID string) (Transaction, error)
+	GetByHashAndUserID(c context.Context, hash, userID string) ([]Transaction, error)
 	GetByUserID(c context.Context, userID string, limit, offset int) ([]Transaction, error)
 	GetByWalletID(c context.Context, walletID string, limit, offset int) ([]Transaction, error)
 	Update(c context.Context, transaction *Transaction) error
+	// UpdateStatus 更新该哈希的所有记录，托管钱包之间转账的send和receive记录状态一致
 	UpdateStatus(c context.Context, hash string, status TransactionStatus) error
 	GetPendingTransactions(c context.Context) ([]Transaction, error)
+	GetMinedAfter(c context.Context, network string, blockNumber uint64) ([]Transaction, error)
+	GetByNonce(c context.Context, network, from string, nonce uint64) (Transaction, error)
+}
+
+// NonceManager 按网络和地址记录下一个可用nonce，发送锁保证同一地址的发送串行执行
+type NonceManager interface {
+	// Lock 获取地址的发送锁，等待超过ctx期限时返回ErrWalletBusy；返回的unlock必须调用
+	Lock(c context.Context, network, address string) (unlock func(), err error)
+	// Get 没有记录时ok为false
+	Get(c context.Context, network, address string) (nonce uint64, ok bool, err error)
+	Set(c context.Context, network, address string, nonce uint64) error
+	// Reset 删除记录，下次发送从节点重新同步
+	Reset(c context.Context, network, address string) error
 }
 
 // TransactionUsecase 交易用例接口
@@ -97,8 +183,30 @@ type TransactionUsecase interface {
 	SendTransaction(c context.Context, userID string, req *TransactionSendRequest) (*TransactionResponse, error)
 	GetTransactions(c context.Context, userID string, limit, offset int) ([]TransactionResponse, error)
 	GetTransaction(c context.Context, userID, transactionID string) (*TransactionResponse, error)
-	GetTransactionByHash(c context.Context, hash string) (*TransactionResponse, error)
+	// GetTransactionByHash 返回用户自己的交易记录，不属于该用户的交易只返回链上数据
+	GetTransactionByHash(c context.Context, userID, hash string) (*TransactionResponse, error)
+	// GetWalletHistory 返回钱包的交易记录，pending记录用链上数据更新状态
+	GetWalletHistory(c context.Context, userID, walletID string, limit, offset int) ([]TransactionResponse, error)
+	// SpeedUpTransaction 以相同nonce和更高的费用重新发送pending交易
+	SpeedUpTransaction(c context.Context, userID, transactionID string, req *TransactionReplaceRequest) (*TransactionResponse, error)
+	// CancelTransaction 以相同nonce和更高的费用向钱包自身发送0金额交易，使原交易无法上链
+	CancelTransaction(c context.Context, userID, transactionID string, req *TransactionReplaceRequest) (*TransactionResponse, error)
 	UpdateTransactionStatus(c context.Context, hash string, status TransactionStatus) error
-	EstimateGas(c context.Context, from, to, value string) (uint64, error)
+	EstimateGas(c context.Context, network, from, to, value string) (uint64, error)
 	GetGasPrice(c context.Context, network string) (string, error)
-}
\ No newline at end of file
+}
+
+// TransactionTracker 跟踪pending交易，达到确认数后更新为confirmed或failed
+type TransactionTracker interface {
+	Run(ctx context.Context)
+	CheckPending(c context.Context) error
+}
+
+// TransactionWatcher 监听托管钱包的链上交易，为转入的ETH和已登记代币自动创建receive记录
+type TransactionWatcher interface {
+	Run(ctx context.Context) error
+	// CatchUp 扫描上次处理的区块之后直到head的区块，断开期间的转入也会被记录
+	CatchUp(c context.Context, head uint64) error
+	HandleTransaction(c context.Context, tx *TransactionResponse) error
+	HandleTokenTransfer(c context.Context, transfer *TokenTransfer) error
+}
@@ -2,6 +2,7 @@ package domain
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -12,6 +13,17 @@ const (
 	CollectionWalletPrivateData = "wallet_private_data"
 )
 
+var (
+	ErrWalletNotFound    = errors.New("wallet not found")
+	ErrWalletExists      = errors.New("wallet already exists")
+	ErrInvalidWalletType = errors.New("invalid wallet type")
+	ErrInvalidPassword   = errors.New("invalid wallet password")
+	ErrWalletIsWatchOnly = errors.New("watch-only wallet has no private key")
+	ErrWalletNoMnemonic  = errors.New("wallet has no mnemonic")
+	ErrMasterKeyNotFound = errors.New("wallet master key version not found")
+	ErrLegacyKeyNotFound = errors.New("legacy wallet encryption key not configured")
+)
+
 // WalletType 钱包类型
 type WalletType string
 
@@ -38,10 +50,11 @@ type Wallet struct {
 	Address     string             `bson:"address" json:"address"`
 	Type        WalletType         `bson:"type" json:"type"`
 	Status      WalletStatus       `bson:"status" json:"status"`
-	Network     string             `bson:"network" json:"network"`     // mainnet, sepolia, goerli
+	Network     string             `bson:"network" json:"network"`     // NetworkRegistry中配置的网络名，如mainnet、sepolia
 	Balance     string             `bson:"balance" json:"balance"`     // 余额 (ETH)
 	BalanceUSD  string             `bson:"balance_usd" json:"balance_usd"` // USD余额
 	IsDefault   bool               `bson:"is_default" json:"is_default"`
+	AccountIndex uint32            `bson:"account_index" json:"account_index"` // HD派生路径中的账户索引
 	CreatedAt   time.Time          `bson:"created_at" json:"created_at"`
 	UpdatedAt   time.Time          `bson:"updated_at" json:"updated_at"`
 }
@@ -50,13 +63,22 @@ type Wallet struct {
 type WalletPrivateData struct {
 	ID              primitive.ObjectID `bson:"_id" json:"id"`
 	WalletID        primitive.ObjectID `bson:"wallet_id" json:"wallet_id"`
-	EncryptedKey    string             `bson:"encrypted_key" json:"-"`       // 加密的私钥
+	EncryptedDataKey string            `bson:"encrypted_data_key" json:"-"`  // 经密码派生密钥和主密钥双重加密的数据密钥
+	MasterKeyVersion int               `bson:"master_key_version" json:"-"`  // 加密数据密钥使用的主密钥版本
+	EncryptedKey    string             `bson:"encrypted_key" json:"-"`       // 数据密钥加密的私钥
 	EncryptedMnemonic string           `bson:"encrypted_mnemonic" json:"-"`  // 加密的助记词
+	EncryptedPassphrase string         `bson:"encrypted_passphrase" json:"-"` // 加密的BIP-39密码短语
 	KeyDerivationPath string           `bson:"key_derivation_path" json:"-"` // HD钱包派生路径
+	Salt            string             `bson:"salt" json:"-"`                // 密码派生密钥使用的盐值
 	CreatedAt       time.Time          `bson:"created_at" json:"created_at"`
 	UpdatedAt       time.Time          `bson:"updated_at" json:"updated_at"`
 }
 
+// IsLegacy 信封加密之前的记录没有数据密钥，私密数据直接用旧密钥加密，需要钱包密码才能迁移
+func (d *WalletPrivateData) IsLegacy() bool {
+	return d.EncryptedDataKey == ""
+}
+
 // WalletCreateRequest 创建钱包请求
 type WalletCreateRequest struct {
 	Name     string     `json:"name" binding:"required"`
@@ -64,6 +86,8 @@ type WalletCreateRequest struct {
 	Network  string     `json:"network" binding:"required"`
 	Password string     `json:"password" binding:"required,min=8"`
 	Mnemonic string     `json:"mnemonic,omitempty"` // 导入钱包时需要
+	Passphrase string   `json:"passphrase,omitempty"` // 可选的BIP-39密码短语
+	Address  string     `json:"address,omitempty"`  // 只读钱包时需要
 }
 
 // WalletImportRequest 导入钱包请求
@@ -71,9 +95,22 @@ type WalletImportRequest struct {
 	Name     string `json:"name" binding:"required"`
 	Network  string `json:"network" binding:"required"`
 	Mnemonic string `json:"mnemonic" binding:"required"`
+	Passphrase string `json:"passphrase,omitempty"` // 可选的BIP-39密码短语
 	Password string `json:"password" binding:"required,min=8"`
 }
 
+// WalletDeriveRequest 从HD钱包派生新账户请求
+type WalletDeriveRequest struct {
+	Name     string `json:"name" binding:"required"`
+	Index    uint32 `json:"index" binding:"required"` // m/44'/60'/0'/0/index，0为原钱包本身
+	Password string `json:"password" binding:"required,min=8"`
+}
+
+// WalletUpdateRequest 重命名钱包请求
+type WalletUpdateRequest struct {
+	Name string `json:"name" binding:"required"`
+}
+
 // WalletResponse 钱包响应
 type WalletResponse struct {
 	ID         primitive.ObjectID `json:"id"`
@@ -85,6 +122,7 @@ type WalletResponse struct {
 	Balance    string             `json:"balance"`
 	BalanceUSD string             `json:"balance_usd"`
 	IsDefault  bool               `json:"is_default"`
+	AccountIndex uint32           `json:"account_index"`
 	CreatedAt  time.Time          `json:"created_at"`
 	UpdatedAt  time.Time          `json:"updated_at"`
 }
@@ -128,25 +166,33 @@ type WalletRepository interface {
 	Create(ctx context.Context, wallet *Wallet) error
 	GetByID(ctx context.Context, id string) (*Wallet, error)
 	GetByAddress(ctx context.Context, address string) (*Wallet, error)
+	GetByAddressAndNetwork(ctx context.Context, address string, network string) ([]Wallet, error)
 	GetByUserID(ctx context.Context, userID string, page, pageSize int) ([]Wallet, int, error)
 	Update(ctx context.Context, wallet *Wallet) error
 	Delete(ctx context.Context, id string) error
+	// EnsureIndexes 创建同一用户在同一网络下地址唯一的索引，重复时Create和Update返回ErrWalletExists
+	EnsureIndexes(ctx context.Context) error
 	
 	// 私有数据操作
 	CreatePrivateData(ctx context.Context, data *WalletPrivateData) error
 	GetPrivateData(ctx context.Context, walletID string) (*WalletPrivateData, error)
 	UpdatePrivateData(ctx context.Context, data *WalletPrivateData) error
 	DeletePrivateData(ctx context.Context, walletID string) error
+	GetStalePrivateData(ctx context.Context, masterKeyVersion int, afterID string, limit int) ([]WalletPrivateData, error)
+	UpdateDataKey(ctx context.Context, id string, fromVersion int, encryptedDataKey string, toVersion int) (bool, error)
 	
 	// 查询操作
 	GetDefaultWallet(ctx context.Context, userID string, network string) (*Wallet, error)
 	SetDefaultWallet(ctx context.Context, userID string, walletID string) error
 	GetWalletsByNetwork(ctx context.Context, userID string, network string) ([]Wallet, error)
+	GetActiveWallets(ctx context.Context, network string) ([]Wallet, error)
 	UpdateBalance(ctx context.Context, walletID string, balance string, balanceUSD string) error
 	
 	// 统计操作
 	GetUserWalletCount(ctx context.Context, userID string) (int, error)
 	GetTotalBalance(ctx context.Context, userID string, network string) (string, error)
+	// GetNetworkStats 按网络聚合启用状态钱包的数量和余额，不含USD价值
+	GetNetworkStats(ctx context.Context, userID string) ([]NetworkStats, error)
 }
 
 // WalletUsecase 钱包用例接口
@@ -158,11 +204,13 @@ type WalletUsecase interface {
 	GetWallets(ctx context.Context, userID string, page, pageSize int) (*WalletListResponse, error)
 	UpdateWallet(ctx context.Context, userID string, walletID string, name string) (*WalletResponse, error)
 	DeleteWallet(ctx context.Context, userID string, walletID string) error
+	DeriveWallet(ctx context.Context, userID string, walletID string, req *WalletDeriveRequest) (*WalletResponse, error)
 	
 	// 余额管理
 	GetBalance(ctx context.Context, userID string, walletID string) (*WalletBalanceResponse, error)
 	RefreshBalance(ctx context.Context, userID string, walletID string) (*WalletBalanceResponse, error)
 	RefreshAllBalances(ctx context.Context, userID string) error
+	GetTokenBalances(ctx context.Context, userID string, walletID string) ([]TokenBalance, error)
 	
 	// 默认钱包
 	SetDefaultWallet(ctx context.Context, userID string, walletID string) error
@@ -190,7 +238,37 @@ type CryptoService interface {
 	DeriveKey(password string, salt string) (string, error)
 	GenerateSalt() (string, error)
 	
+	// 信封加密的数据密钥(base64编码的256位密钥)
+	GenerateDataKey() (string, error)
+	EncryptWithDataKey(data string, dataKey string) (string, error)
+	DecryptWithDataKey(encryptedData string, dataKey string) (string, error)
+	
 	// 哈希
 	Hash(data string) string
 	VerifyHash(data string, hash string) bool
+}
+
+// WalletKeyManager 钱包数据密钥的信封加密：数据密钥先用密码派生密钥加密，再用带版本的服务端主密钥加密，
+// 轮换主密钥时只需重新包裹外层，不需要用户密码，也不需要重新加密私钥
+type WalletKeyManager interface {
+	CurrentVersion() int
+	WrapDataKey(dataKey string, passwordKey string) (wrappedKey string, version int, err error)
+	UnwrapDataKey(wrappedKey string, version int, passwordKey string) (string, error)
+	RewrapDataKey(wrappedKey string, version int) (string, int, error)
+	// LegacyKey 返回信封加密之前直接加密私密数据的密钥，需配合cryptoService.Decrypt使用
+	LegacyKey(passwordKey string) (string, error)
+}
+
+// WalletRekeyResult 主密钥轮换结果
+type WalletRekeyResult struct {
+	Version   int `json:"version"`
+	Rewrapped int `json:"rewrapped"`
+	Skipped   int `json:"skipped"` // 处理期间已被并发更新的记录
+	Legacy    int `json:"legacy"`  // 未迁移到信封加密的记录，需用户下次输入密码时迁移
+	Failed    int `json:"failed"`
+}
+
+// WalletRekeyUsecase 将旧主密钥包裹的数据密钥迁移到当前主密钥
+type WalletRekeyUsecase interface {
+	RekeyPrivateData(c context.Context, batchSize int) (*WalletRekeyResult, error)
 }
\ No newline at end of file
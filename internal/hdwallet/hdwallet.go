@@ -0,0 +1,178 @@
+package hdwallet
+
+import (
+	"crypto/ecdsa"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/tyler-smith/go-bip39"
+)
+
+// HardenedOffset 强化派生的索引起点 (BIP-32)
+const HardenedOffset uint32 = 0x80000000
+
+// EthereumPathFormat 以太坊账户的BIP-44派生路径 m/44'/60'/0'/0/i
+const EthereumPathFormat = "m/44'/60'/0'/0/%d"
+
+var (
+	ErrInvalidMnemonic = errors.New("invalid mnemonic")
+	ErrInvalidPath     = errors.New("invalid derivation path")
+	ErrInvalidKey      = errors.New("derived key is invalid")
+)
+
+// ExtendedKey BIP-32扩展私钥
+type ExtendedKey struct {
+	key       []byte // 32字节私钥
+	chainCode []byte
+}
+
+// EthereumPath 返回第index个以太坊账户的派生路径
+func EthereumPath(index uint32) string {
+	return fmt.Sprintf(EthereumPathFormat, index)
+}
+
+// NewSeed 由BIP-39助记词和可选的密码短语生成种子
+func NewSeed(mnemonic string, passphrase string) ([]byte, error) {
+	seed, err := bip39.NewSeedWithErrorChecking(mnemonic, passphrase)
+	if err != nil {
+		return nil, ErrInvalidMnemonic
+	}
+	return seed, nil
+}
+
+// NewMaster 由种子生成主扩展私钥
+func NewMaster(seed []byte) (*ExtendedKey, error) {
+	mac := hmac.New(sha512.New, []byte("Bitcoin seed"))
+	mac.Write(seed)
+	sum := mac.Sum(nil)
+
+	key := new(big.Int).SetBytes(sum[:32])
+	if key.Sign() == 0 || key.Cmp(crypto.S256().Params().N) >= 0 {
+		return nil, ErrInvalidKey
+	}
+
+	return &ExtendedKey{key: sum[:32], chainCode: sum[32:]}, nil
+}
+
+// Child 派生子私钥，index不小于HardenedOffset时为强化派生
+func (k *ExtendedKey) Child(index uint32) (*ExtendedKey, error) {
+	data := make([]byte, 0, 37)
+	if index >= HardenedOffset {
+		data = append(data, 0x00)
+		data = append(data, k.key...)
+	} else {
+		privateKey, err := crypto.ToECDSA(k.key)
+		if err != nil {
+			return nil, err
+		}
+		data = append(data, crypto.CompressPubkey(&privateKey.PublicKey)...)
+	}
+	data = binary.BigEndian.AppendUint32(data, index)
+
+	mac := hmac.New(sha512.New, k.chainCode)
+	mac.Write(data)
+	sum := mac.Sum(nil)
+
+	n := crypto.S256().Params().N
+	il := new(big.Int).SetBytes(sum[:32])
+	if il.Cmp(n) >= 0 {
+		return nil, ErrInvalidKey
+	}
+
+	child := il.Add(il, new(big.Int).SetBytes(k.key))
+	child.Mod(child, n)
+	if child.Sign() == 0 {
+		return nil, ErrInvalidKey
+	}
+
+	return &ExtendedKey{key: child.FillBytes(make([]byte, 32)), chainCode: sum[32:]}, nil
+}
+
+// Derive 按路径依次派生，例如 m/44'/60'/0'/0/0
+func (k *ExtendedKey) Derive(path string) (*ExtendedKey, error) {
+	indexes, err := ParsePath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	key := k
+	for _, index := range indexes {
+		key, err = key.Child(index)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return key, nil
+}
+
+// PrivateKey 返回扩展私钥对应的ECDSA私钥
+func (k *ExtendedKey) PrivateKey() (*ecdsa.PrivateKey, error) {
+	return crypto.ToECDSA(k.key)
+}
+
+// ChainCode 返回链码
+func (k *ExtendedKey) ChainCode() []byte {
+	return k.chainCode
+}
+
+// ParsePath 解析派生路径，强化索引用 ' 或 H 标记
+func ParsePath(path string) ([]uint32, error) {
+	parts := strings.Split(strings.TrimSpace(path), "/")
+	if len(parts) == 0 || parts[0] != "m" {
+		return nil, ErrInvalidPath
+	}
+
+	indexes := make([]uint32, 0, len(parts)-1)
+	for _, part := range parts[1:] {
+		hardened := strings.HasSuffix(part, "'") || strings.HasSuffix(part, "H")
+		if hardened {
+			part = part[:len(part)-1]
+		}
+
+		index, err := strconv.ParseUint(part, 10, 32)
+		if err != nil || uint32(index) >= HardenedOffset {
+			return nil, ErrInvalidPath
+		}
+		if hardened {
+			index += uint64(HardenedOffset)
+		}
+		indexes = append(indexes, uint32(index))
+	}
+	return indexes, nil
+}
+
+// DeriveEthereumAccount 从助记词派生第index个以太坊账户，返回地址、十六进制私钥和派生路径
+func DeriveEthereumAccount(mnemonic string, passphrase string, index uint32) (address, privateKey, path string, err error) {
+	seed, err := NewSeed(mnemonic, passphrase)
+	if err != nil {
+		return "", "", "", err
+	}
+
+	master, err := NewMaster(seed)
+	if err != nil {
+		return "", "", "", err
+	}
+
+	path = EthereumPath(index)
+	key, err := master.Derive(path)
+	if err != nil {
+		return "", "", "", err
+	}
+
+	privateKeyECDSA, err := key.PrivateKey()
+	if err != nil {
+		return "", "", "", err
+	}
+
+	address = crypto.PubkeyToAddress(privateKeyECDSA.PublicKey).Hex()
+	privateKey = fmt.Sprintf("%x", crypto.FromECDSA(privateKeyECDSA))
+
+	return address, privateKey, path, nil
+}
@@ -0,0 +1,96 @@
+package hdwallet_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/littlecheny/go-backend/internal/hdwallet"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// BIP-32 test vector 1
+func TestDeriveBIP32Vector(t *testing.T) {
+	seed, err := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	require.NoError(t, err)
+
+	master, err := hdwallet.NewMaster(seed)
+	require.NoError(t, err)
+
+	vectors := []struct {
+		path      string
+		chainCode string
+		key       string
+	}{
+		{"m", "873dff81c02f525623fd1fe5167eac3a55a049de3d314bb42ee227ffed37d508", "e8f32e723decf4051aefac8e2c93c9c5b214313817cdb01a1494b917c8436b35"},
+		{"m/0H", "47fdacbd0f1097043b78c63c20c34ef4ed9a111d980047ad16282c7ae6236141", "edb2e14f9ee77d26dd93b4ecede8d16ed408ce149b6cd80b0715a2d911a0afea"},
+		{"m/0H/1", "2a7857631386ba23dacac34180dd1983734e444fdbf774041578e9b6adb37c19", "3c6cb8d0f6a264c91ea8b5030fadaa8e538b020f0a387421a12de9319dc93368"},
+		{"m/0H/1/2H", "04466b9cc8e161e966409ca52986c584f07e9dc81f735db683c3ff6ec7b1503f", "cbce0d719ecf7431d88e6a89fa1483e02e35092af60c042b1df2ff59fa424dca"},
+		{"m/0H/1/2H/2", "cfb71883f01676f587d023cc53a35bc7f88f724b1f8c2892ac1275ac822a3edd", "0f479245fb19a38a1954c5c7c0ebab2f9bdfd96a17563ef28a6a4b1a2a764ef4"},
+		{"m/0H/1/2H/2/1000000000", "c783e67b921d2beb8f6b389cc646d7263b4145701dadd2161548a8b078e65e9e", "471b76e389e528d6de6d816857e012c5455051cad6660850e58372a6c3e6e7c8"},
+	}
+
+	for _, v := range vectors {
+		t.Run(v.path, func(t *testing.T) {
+			key, err := master.Derive(v.path)
+			require.NoError(t, err)
+
+			privateKey, err := key.PrivateKey()
+			require.NoError(t, err)
+
+			assert.Equal(t, v.chainCode, hex.EncodeToString(key.ChainCode()))
+			assert.Equal(t, v.key, hex.EncodeToString(privateKey.D.FillBytes(make([]byte, 32))))
+		})
+	}
+}
+
+// BIP-39 test vector (Trezor)，密码短语为TREZOR
+func TestNewSeed(t *testing.T) {
+	seed, err := hdwallet.NewSeed("abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about", "TREZOR")
+
+	require.NoError(t, err)
+	assert.Equal(t, "c55257c360c07c72029aebc1b53c05ed0362ada38ead3e3e9efa3708e53495531f09a6987599d18264c1e1c92f2cf141630c7a3c4ab7c81b2f001698e7463b04", hex.EncodeToString(seed))
+
+	_, err = hdwallet.NewSeed("abandon abandon abandon", "")
+	assert.ErrorIs(t, err, hdwallet.ErrInvalidMnemonic)
+}
+
+// BIP-44 以太坊账户，与常见钱包(MetaMask、Hardhat)派生结果一致
+func TestDeriveEthereumAccount(t *testing.T) {
+	vectors := []struct {
+		mnemonic   string
+		index      uint32
+		address    string
+		privateKey string
+	}{
+		{"test test test test test test test test test test test junk", 0, "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266", "ac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80"},
+		{"test test test test test test test test test test test junk", 1, "0x70997970C51812dc3A010C7d01b50e0d17dc79C8", "59c6995e998f97a5a0044966f0945389dc9e86dae88c7a8412f4603b6b78690d"},
+		{"abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about", 0, "0x9858EfFD232B4033E47d90003D41EC34EcaEda94", "1ab42cc412b618bdea3a599e3c9bae199ebf030895b039e9db1e30dafb12b727"},
+	}
+
+	for _, v := range vectors {
+		address, privateKey, path, err := hdwallet.DeriveEthereumAccount(v.mnemonic, "", v.index)
+
+		require.NoError(t, err)
+		assert.Equal(t, v.address, address)
+		assert.Equal(t, v.privateKey, privateKey)
+		assert.Equal(t, hdwallet.EthereumPath(v.index), path)
+	}
+
+	// 不同的密码短语派生出不同的账户
+	address, _, _, err := hdwallet.DeriveEthereumAccount("test test test test test test test test test test test junk", "secret", 0)
+	require.NoError(t, err)
+	assert.NotEqual(t, "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266", address)
+}
+
+func TestParsePath(t *testing.T) {
+	indexes, err := hdwallet.ParsePath("m/44'/60'/0'/0/7")
+
+	require.NoError(t, err)
+	assert.Equal(t, []uint32{44 + hdwallet.HardenedOffset, 60 + hdwallet.HardenedOffset, hdwallet.HardenedOffset, 0, 7}, indexes)
+
+	for _, path := range []string{"", "44'/60'", "m/x", "m/2147483648", "m//0"} {
+		_, err = hdwallet.ParsePath(path)
+		assert.ErrorIs(t, err, hdwallet.ErrInvalidPath, path)
+	}
+}
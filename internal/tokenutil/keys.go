@@ -0,0 +1,180 @@
+package tokenutil
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"fmt"
+	"math/big"
+	"os"
+	"sort"
+	"time"
+
+	jwt "github.com/golang-jwt/jwt/v4"
+	"github.com/littlecheny/go-backend/domain"
+)
+
+// signingKey 单个访问令牌签名密钥，轮换下线的旧密钥只保留公钥
+type signingKey struct {
+	id         string
+	method     jwt.SigningMethod
+	privateKey crypto.PrivateKey
+	publicKey  crypto.PublicKey
+	notAfter   time.Time // 零值表示不过期
+}
+
+// PreviousKey 轮换前使用的密钥，PEM文件可以是公钥或私钥
+type PreviousKey struct {
+	ID   string
+	File string
+}
+
+// KeySet 访问令牌签名密钥集合：用当前密钥签名，按kid校验，旧密钥在宽限期内仍可用于校验
+type KeySet struct {
+	active *signingKey
+	keys   map[string]*signingKey
+	// 启用非对称签名之前签发的HS256令牌在宽限期内仍然有效
+	legacyNotAfter time.Time
+}
+
+// LoadKeySet 从PEM文件加载当前签名密钥与轮换前的旧密钥，旧密钥自rotatedAt起grace时长内仍可用于校验
+func LoadKeySet(activeID string, activeFile string, previous []PreviousKey, rotatedAt time.Time, grace time.Duration) (*KeySet, error) {
+	if activeID == "" {
+		return nil, fmt.Errorf("signing key id is required")
+	}
+
+	active, err := loadPrivateKey(activeID, activeFile)
+	if err != nil {
+		return nil, err
+	}
+
+	ks := &KeySet{
+		active:         active,
+		keys:           map[string]*signingKey{activeID: active},
+		legacyNotAfter: rotatedAt.Add(grace),
+	}
+
+	for _, p := range previous {
+		if _, ok := ks.keys[p.ID]; ok {
+			return nil, fmt.Errorf("duplicate signing key id %s", p.ID)
+		}
+		key, err := loadPublicKey(p.ID, p.File)
+		if err != nil {
+			return nil, err
+		}
+		key.notAfter = rotatedAt.Add(grace)
+		ks.keys[p.ID] = key
+	}
+
+	return ks, nil
+}
+
+func loadPrivateKey(id string, file string) (*signingKey, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read signing key %s: %v", id, err)
+	}
+
+	if rsaKey, err := jwt.ParseRSAPrivateKeyFromPEM(data); err == nil {
+		return &signingKey{id: id, method: jwt.SigningMethodRS256, privateKey: rsaKey, publicKey: &rsaKey.PublicKey}, nil
+	}
+
+	edKey, err := jwt.ParseEdPrivateKeyFromPEM(data)
+	if err != nil {
+		return nil, fmt.Errorf("signing key %s is neither an RSA nor an Ed25519 private key", id)
+	}
+	return &signingKey{id: id, method: jwt.SigningMethodEdDSA, privateKey: edKey, publicKey: edKey.(ed25519.PrivateKey).Public()}, nil
+}
+
+func loadPublicKey(id string, file string) (*signingKey, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read signing key %s: %v", id, err)
+	}
+
+	if rsaKey, err := jwt.ParseRSAPublicKeyFromPEM(data); err == nil {
+		return &signingKey{id: id, method: jwt.SigningMethodRS256, publicKey: rsaKey}, nil
+	}
+	if edKey, err := jwt.ParseEdPublicKeyFromPEM(data); err == nil {
+		return &signingKey{id: id, method: jwt.SigningMethodEdDSA, publicKey: edKey}, nil
+	}
+
+	// 也允许直接引用旧的私钥文件，只保留其公钥
+	key, err := loadPrivateKey(id, file)
+	if err != nil {
+		return nil, err
+	}
+	key.privateKey = nil
+	return key, nil
+}
+
+// sign 使用当前密钥签名，并在头部写入kid
+func (ks *KeySet) sign(claims jwt.Claims) (string, error) {
+	token := jwt.NewWithClaims(ks.active.method, claims)
+	token.Header["kid"] = ks.active.id
+	return token.SignedString(ks.active.privateKey)
+}
+
+// keyfunc 按kid查找校验公钥，拒绝算法不匹配或已过宽限期的密钥
+func (ks *KeySet) keyfunc(legacySecret string) jwt.Keyfunc {
+	return func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		if kid == "" {
+			if _, ok := token.Method.(*jwt.SigningMethodHMAC); ok && legacySecret != "" && time.Now().Before(ks.legacyNotAfter) {
+				return []byte(legacySecret), nil
+			}
+			return nil, fmt.Errorf("Missing key id")
+		}
+
+		key, ok := ks.keys[kid]
+		if !ok {
+			return nil, fmt.Errorf("Unknown key id: %s", kid)
+		}
+		if !key.notAfter.IsZero() && time.Now().After(key.notAfter) {
+			return nil, fmt.Errorf("Key %s has been retired", kid)
+		}
+		if token.Method.Alg() != key.method.Alg() {
+			return nil, fmt.Errorf("Unexpected signing method: %v", token.Header["alg"])
+		}
+		return key.publicKey, nil
+	}
+}
+
+// JWKS 返回仍可用于校验的公钥集合
+func (ks *KeySet) JWKS() domain.JSONWebKeySet {
+	set := domain.JSONWebKeySet{Keys: []domain.JSONWebKey{}}
+	now := time.Now()
+
+	ids := make([]string, 0, len(ks.keys))
+	for id := range ks.keys {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	for _, id := range ids {
+		key := ks.keys[id]
+		if !key.notAfter.IsZero() && now.After(key.notAfter) {
+			continue
+		}
+
+		jwk := domain.JSONWebKey{
+			KeyID:     key.id,
+			Use:       "sig",
+			Algorithm: key.method.Alg(),
+		}
+		switch pub := key.publicKey.(type) {
+		case *rsa.PublicKey:
+			jwk.KeyType = "RSA"
+			jwk.N = base64.RawURLEncoding.EncodeToString(pub.N.Bytes())
+			jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes())
+		case ed25519.PublicKey:
+			jwk.KeyType = "OKP"
+			jwk.Curve = "Ed25519"
+			jwk.X = base64.RawURLEncoding.EncodeToString(pub)
+		}
+		set.Keys = append(set.Keys, jwk)
+	}
+
+	return set
+}
@@ -0,0 +1,145 @@
+package tokenutil_test
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	jwt "github.com/golang-jwt/jwt/v4"
+	"github.com/littlecheny/go-backend/domain"
+	"github.com/littlecheny/go-backend/internal/tokenutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func writeRSAKey(t *testing.T, dir string, name string) string {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+
+	path := filepath.Join(dir, name)
+	require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0600))
+	return path
+}
+
+func writeEd25519Key(t *testing.T, dir string, name string) string {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+
+	path := filepath.Join(dir, name)
+	require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0600))
+	return path
+}
+
+func TestAccessTokenKeySet(t *testing.T) {
+	dir := t.TempDir()
+	rsaFile := writeRSAKey(t, dir, "rsa.pem")
+	edFile := writeEd25519Key(t, dir, "ed25519.pem")
+
+	user := &domain.User{
+		ID:   primitive.NewObjectID(),
+		Name: "Test",
+	}
+
+	t.Run("rs256 with kid", func(t *testing.T) {
+		keys, err := tokenutil.LoadKeySet("rsa-1", rsaFile, nil, time.Now(), time.Hour)
+		require.NoError(t, err)
+
+		accessToken, err := tokenutil.CreateAccessToken(user, keys, "unused", 1)
+		require.NoError(t, err)
+
+		token, _, err := new(jwt.Parser).ParseUnverified(accessToken, &domain.JwtCustomClaims{})
+		require.NoError(t, err)
+		assert.Equal(t, "RS256", token.Method.Alg())
+		assert.Equal(t, "rsa-1", token.Header["kid"])
+
+		claims, err := tokenutil.ExtractClaimsFromToken(accessToken, keys, "unused")
+		require.NoError(t, err)
+		assert.Equal(t, user.ID.Hex(), claims.ID)
+	})
+
+	t.Run("rotation grace period", func(t *testing.T) {
+		old, err := tokenutil.LoadKeySet("rsa-1", rsaFile, nil, time.Now(), time.Hour)
+		require.NoError(t, err)
+
+		oldToken, err := tokenutil.CreateAccessToken(user, old, "unused", 1)
+		require.NoError(t, err)
+
+		rotated, err := tokenutil.LoadKeySet("ed-1", edFile, []tokenutil.PreviousKey{{ID: "rsa-1", File: rsaFile}}, time.Now(), time.Hour)
+		require.NoError(t, err)
+
+		newToken, err := tokenutil.CreateAccessToken(user, rotated, "unused", 1)
+		require.NoError(t, err)
+
+		token, _, err := new(jwt.Parser).ParseUnverified(newToken, &domain.JwtCustomClaims{})
+		require.NoError(t, err)
+		assert.Equal(t, "EdDSA", token.Method.Alg())
+
+		_, err = tokenutil.ExtractClaimsFromToken(oldToken, rotated, "unused")
+		assert.NoError(t, err)
+		_, err = tokenutil.ExtractClaimsFromToken(newToken, rotated, "unused")
+		assert.NoError(t, err)
+
+		// 宽限期结束后旧密钥签发的令牌不再有效
+		expired, err := tokenutil.LoadKeySet("ed-1", edFile, []tokenutil.PreviousKey{{ID: "rsa-1", File: rsaFile}}, time.Now().Add(-2*time.Hour), time.Hour)
+		require.NoError(t, err)
+
+		_, err = tokenutil.ExtractClaimsFromToken(oldToken, expired, "unused")
+		assert.Error(t, err)
+		_, err = tokenutil.ExtractClaimsFromToken(newToken, expired, "unused")
+		assert.NoError(t, err)
+	})
+
+	t.Run("legacy hs256 token", func(t *testing.T) {
+		legacyToken, err := tokenutil.CreateAccessToken(user, nil, "shared-secret", 1)
+		require.NoError(t, err)
+
+		keys, err := tokenutil.LoadKeySet("rsa-1", rsaFile, nil, time.Now(), time.Hour)
+		require.NoError(t, err)
+
+		_, err = tokenutil.ExtractClaimsFromToken(legacyToken, keys, "shared-secret")
+		assert.NoError(t, err)
+
+		keys, err = tokenutil.LoadKeySet("rsa-1", rsaFile, nil, time.Now().Add(-2*time.Hour), time.Hour)
+		require.NoError(t, err)
+
+		_, err = tokenutil.ExtractClaimsFromToken(legacyToken, keys, "shared-secret")
+		assert.Error(t, err)
+	})
+
+	t.Run("jwks", func(t *testing.T) {
+		keys, err := tokenutil.LoadKeySet("ed-1", edFile, []tokenutil.PreviousKey{{ID: "rsa-1", File: rsaFile}}, time.Now(), time.Hour)
+		require.NoError(t, err)
+
+		set := keys.JWKS()
+		require.Len(t, set.Keys, 2)
+
+		assert.Equal(t, "ed-1", set.Keys[0].KeyID)
+		assert.Equal(t, "OKP", set.Keys[0].KeyType)
+		assert.Equal(t, "Ed25519", set.Keys[0].Curve)
+		assert.NotEmpty(t, set.Keys[0].X)
+
+		assert.Equal(t, "rsa-1", set.Keys[1].KeyID)
+		assert.Equal(t, "RSA", set.Keys[1].KeyType)
+		assert.Equal(t, "RS256", set.Keys[1].Algorithm)
+		assert.Equal(t, "AQAB", set.Keys[1].E)
+		assert.False(t, strings.ContainsAny(set.Keys[1].N, "+/="))
+
+		retired, err := tokenutil.LoadKeySet("ed-1", edFile, []tokenutil.PreviousKey{{ID: "rsa-1", File: rsaFile}}, time.Now().Add(-2*time.Hour), time.Hour)
+		require.NoError(t, err)
+		assert.Len(t, retired.JWKS().Keys, 1)
+	})
+}
@@ -1,21 +1,34 @@
 package tokenutil
 
 import (
+	"crypto/rand"
+	"encoding/hex"
 	"fmt"
 	"time"
 	"github.com/littlecheny/go-backend/domain"
 	jwt "github.com/golang-jwt/jwt/v4"
 )
 
-func CreateAccessToken(user *domain.User, secret string, expiry int) (accessToken string, err error) {
+// CreateAccessToken keys不为nil时使用非对称密钥签名，否则使用HS256共享密钥
+func CreateAccessToken(user *domain.User, keys *KeySet, secret string, expiry int) (accessToken string, err error) {
+	jti, err := NewTokenID()
+	if err != nil {
+		return "", err
+	}
+
 	exp := time.Now().Add(time.Hour * time.Duration(expiry)).Unix()
 	claims := &domain.JwtCustomClaims{
 		Name: user.Name,
 		ID:   user.ID.Hex(),
 		StandardClaims: jwt.StandardClaims{
+			Id:        jti,
+			IssuedAt:  time.Now().Unix(),
 			ExpiresAt: exp,
 		},
 	}
+	if keys != nil {
+		return keys.sign(claims)
+	}
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 	t, err := token.SignedString([]byte(secret))
 	if err != nil {
@@ -24,32 +37,43 @@ func CreateAccessToken(user *domain.User, secret string, expiry int) (accessToke
 	return t, err
 }
 
-func CreateRefreshToken(user *domain.User, secret string, expiry int) (refreshToken string, err error) {
+// CreateRefreshToken 签发刷新令牌，familyID为空时开启新的令牌家族
+func CreateRefreshToken(user *domain.User, familyID string, secret string, expiry int) (refreshToken string, claims *domain.JwtCustomRefreshClaims, err error) {
+	jti, err := NewTokenID()
+	if err != nil {
+		return "", nil, err
+	}
+	if familyID == "" {
+		familyID, err = NewTokenID()
+		if err != nil {
+			return "", nil, err
+		}
+	}
+
 	claimsRefresh := &domain.JwtCustomRefreshClaims{
-		ID: user.ID.Hex(),
+		ID:       user.ID.Hex(),
+		FamilyID: familyID,
 		StandardClaims: jwt.StandardClaims{
+			Id:        jti,
+			IssuedAt:  time.Now().Unix(),
 			ExpiresAt: time.Now().Add(time.Hour * time.Duration(expiry)).Unix(),
 		},
 	}
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claimsRefresh)
 	rt, err := token.SignedString([]byte(secret))
 	if err != nil {
-		return "", err
+		return "", nil, err
 	}
-	return rt, err
+	return rt, claimsRefresh, err
 }
 
-func IsAuthorized(requestToken string, secret string) (bool, error) {
-	_, err := jwt.Parse(requestToken, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("Unexpected signing method: %v", token.Header["alg"])
-		}
-		return []byte(secret), nil
-	})
-	if err != nil {
-		return false, err
+// NewTokenID 生成随机的令牌标识（jti / family ID）
+func NewTokenID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate token id: %v", err)
 	}
-	return true, nil
+	return hex.EncodeToString(b), nil
 }
 
 func ExtractIDFromToken(requestToken string, secret string) (string, error) {
@@ -71,4 +95,52 @@ func ExtractIDFromToken(requestToken string, secret string) (string, error) {
 	}
 
 	return claims["id"].(string), nil
+}
+
+// ExtractClaimsFromToken 解析访问令牌；keys不为nil时按kid校验，否则使用HS256共享密钥
+func ExtractClaimsFromToken(requestToken string, keys *KeySet, secret string) (*domain.JwtCustomClaims, error) {
+	claims := &domain.JwtCustomClaims{}
+	token, err := jwt.ParseWithClaims(requestToken, claims, accessTokenKeyfunc(keys, secret))
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !token.Valid || claims.ID == "" {
+		return nil, fmt.Errorf("Invalid Token")
+	}
+
+	return claims, nil
+}
+
+func ExtractRefreshClaimsFromToken(requestToken string, secret string) (*domain.JwtCustomRefreshClaims, error) {
+	claims := &domain.JwtCustomRefreshClaims{}
+	token, err := jwt.ParseWithClaims(requestToken, claims, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("Unexpected signing method: %v", token.Header["alg"])
+		}
+		return []byte(secret), nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !token.Valid || claims.ID == "" {
+		return nil, fmt.Errorf("Invalid Token")
+	}
+
+	return claims, nil
+}
+
+func accessTokenKeyfunc(keys *KeySet, secret string) jwt.Keyfunc {
+	if keys != nil {
+		return keys.keyfunc(secret)
+	}
+	return func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("Unexpected signing method: %v", token.Header["alg"])
+		}
+		return []byte(secret), nil
+	}
 }
\ No newline at end of file
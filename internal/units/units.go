@@ -0,0 +1,118 @@
+// Package units 以太坊金额的单位换算，基于big.Int和定点十进制字符串精确计算，不经过浮点数
+package units
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+)
+
+// 各单位相对最小单位的小数位数，代币使用合约的decimals
+const (
+	WeiDecimals   = 0
+	GweiDecimals  = 9
+	EtherDecimals = 18
+	// USDDecimals USD金额保留的小数位数
+	USDDecimals = 2
+)
+
+var ErrInvalidAmount = errors.New("invalid amount")
+
+// Parse 将十进制金额转换为最小单位整数，例如Parse("1.5", EtherDecimals)得到1500000000000000000；
+// 不接受负数、科学计数法，小数位数超过decimals时返回ErrInvalidAmount
+func Parse(amount string, decimals int) (*big.Int, error) {
+	whole, fraction, _ := strings.Cut(strings.TrimSpace(amount), ".")
+	if whole == "" && fraction == "" || len(fraction) > decimals || !isDigits(whole) || !isDigits(fraction) {
+		return nil, ErrInvalidAmount
+	}
+
+	value, ok := new(big.Int).SetString(whole+fraction+strings.Repeat("0", decimals-len(fraction)), 10)
+	if !ok {
+		return nil, ErrInvalidAmount
+	}
+	return value, nil
+}
+
+// Format 将最小单位整数格式化为十进制金额，去掉小数部分末尾的0
+func Format(value *big.Int, decimals int) string {
+	if value.Sign() < 0 {
+		return "-" + Format(new(big.Int).Neg(value), decimals)
+	}
+	if decimals <= 0 {
+		return value.String()
+	}
+
+	unit := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil)
+	whole, fraction := new(big.Int).QuoRem(value, unit, new(big.Int))
+
+	fractionText := fraction.String()
+	fractionText = strings.Repeat("0", decimals-len(fractionText)) + fractionText
+	fractionText = strings.TrimRight(fractionText, "0")
+	if fractionText == "" {
+		return whole.String()
+	}
+	return whole.String() + "." + fractionText
+}
+
+// FormatString 格式化数据库中保存的十进制整数字符串，空字符串返回空，无法解析时原样返回
+func FormatString(value string, decimals int) string {
+	if value == "" {
+		return ""
+	}
+
+	amount, ok := new(big.Int).SetString(value, 10)
+	if !ok {
+		return value
+	}
+	return Format(amount, decimals)
+}
+
+// ParseDecimal 将十进制金额解析为有理数，用于价格换算等需要乘法的场景；
+// 兼容科学计数法(旧数据中big.Float格式的余额)，不接受分数形式
+func ParseDecimal(amount string) (*big.Rat, error) {
+	amount = strings.TrimSpace(amount)
+	if amount == "" || strings.Contains(amount, "/") {
+		return nil, ErrInvalidAmount
+	}
+
+	value, ok := new(big.Rat).SetString(amount)
+	if !ok {
+		return nil, ErrInvalidAmount
+	}
+	return value, nil
+}
+
+// FormatDecimal 四舍五入保留decimals位小数，不去掉末尾的0，例如USD金额格式化为"3000.50"
+func FormatDecimal(value *big.Rat, decimals int) string {
+	return value.FloatString(decimals)
+}
+
+func ParseEther(amount string) (*big.Int, error) {
+	return Parse(amount, EtherDecimals)
+}
+
+func ParseGwei(amount string) (*big.Int, error) {
+	return Parse(amount, GweiDecimals)
+}
+
+// ParseWei 解析wei整数
+func ParseWei(amount string) (*big.Int, error) {
+	return Parse(amount, WeiDecimals)
+}
+
+func FormatEther(wei *big.Int) string {
+	return Format(wei, EtherDecimals)
+}
+
+func FormatGwei(wei *big.Int) string {
+	return Format(wei, GweiDecimals)
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,119 @@
+package units_test
+
+import (
+	"math/big"
+	"math/rand"
+	"strings"
+	"testing"
+	"testing/quick"
+
+	"github.com/littlecheny/go-backend/internal/units"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		amount   string
+		decimals int
+		want     string
+	}{
+		{"1.5", units.EtherDecimals, "1500000000000000000"},
+		{"0.000012", units.EtherDecimals, "12000000000000"},
+		{".5", units.GweiDecimals, "500000000"},
+		{"5.", units.GweiDecimals, "5000000000"},
+		{" 30 ", units.GweiDecimals, "30000000000"},
+		{"0.000000000000000001", units.EtherDecimals, "1"},
+		{"115792089237316195423570985008687907853269984665640564039457.584007913129639935", units.EtherDecimals, "115792089237316195423570985008687907853269984665640564039457584007913129639935"},
+		{"42", units.WeiDecimals, "42"},
+	}
+	for _, tt := range tests {
+		value, err := units.Parse(tt.amount, tt.decimals)
+		require.NoError(t, err, tt.amount)
+		assert.Equal(t, tt.want, value.String(), tt.amount)
+	}
+
+	for _, amount := range []string{"", ".", "-1", "+1", "1e18", "1.2e-05", "0x10", "1.2.3", "1,5", "0.0000000000000000001", "NaN"} {
+		_, err := units.Parse(amount, units.EtherDecimals)
+		assert.ErrorIs(t, err, units.ErrInvalidAmount, amount)
+	}
+
+	_, err := units.ParseWei("1.5")
+	assert.ErrorIs(t, err, units.ErrInvalidAmount)
+}
+
+func TestFormat(t *testing.T) {
+	assert.Equal(t, "0.000012", units.FormatEther(big.NewInt(12000000000000)))
+	assert.Equal(t, "1.5", units.FormatEther(big.NewInt(1500000000000000000)))
+	assert.Equal(t, "0", units.FormatEther(big.NewInt(0)))
+	assert.Equal(t, "30", units.FormatGwei(big.NewInt(30000000000)))
+	assert.Equal(t, "0.000000001", units.FormatGwei(big.NewInt(1)))
+	assert.Equal(t, "-0.5", units.Format(big.NewInt(-5), 1))
+	assert.Equal(t, "", units.FormatString("", units.EtherDecimals))
+	assert.Equal(t, "not-a-number", units.FormatString("not-a-number", units.EtherDecimals))
+	assert.Equal(t, "2", units.FormatString("2000000", 6))
+}
+
+func TestDecimal(t *testing.T) {
+	balance, err := units.ParseDecimal("1.5")
+	require.NoError(t, err)
+	price, err := units.ParseDecimal("2000.12345678")
+	require.NoError(t, err)
+	assert.Equal(t, "3000.19", units.FormatDecimal(new(big.Rat).Mul(balance, price), units.USDDecimals))
+
+	legacy, err := units.ParseDecimal("1.2e-05")
+	require.NoError(t, err)
+	assert.Equal(t, "0.000012", units.FormatDecimal(legacy, 6))
+	assert.Equal(t, "0.00", units.FormatDecimal(new(big.Rat), units.USDDecimals))
+
+	for _, amount := range []string{"", "1/3", "abc"} {
+		_, err := units.ParseDecimal(amount)
+		assert.ErrorIs(t, err, units.ErrInvalidAmount, amount)
+	}
+}
+
+// 任意非负整数格式化后再解析得到原值
+func TestFormatParseRoundTrip(t *testing.T) {
+	property := func(bytes []byte, decimals uint8) bool {
+		value := new(big.Int).SetBytes(bytes)
+		d := int(decimals % 40)
+
+		parsed, err := units.Parse(units.Format(value, d), d)
+		return err == nil && parsed.Cmp(value) == 0
+	}
+	require.NoError(t, quick.Check(property, &quick.Config{MaxCount: 2000}))
+}
+
+// 任意合法的十进制金额解析后再格式化，只去掉多余的0
+func TestParseFormatRoundTrip(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	digits := func(n int) string {
+		var b strings.Builder
+		for i := 0; i < n; i++ {
+			b.WriteByte(byte('0' + random.Intn(10)))
+		}
+		return b.String()
+	}
+
+	for i := 0; i < 2000; i++ {
+		decimals := random.Intn(30)
+		whole := digits(1 + random.Intn(30))
+		fraction := digits(random.Intn(decimals + 1))
+		amount := whole
+		if fraction != "" {
+			amount += "." + fraction
+		}
+
+		value, err := units.Parse(amount, decimals)
+		require.NoError(t, err, amount)
+
+		want := strings.TrimLeft(whole, "0")
+		if want == "" {
+			want = "0"
+		}
+		if trimmed := strings.TrimRight(fraction, "0"); trimmed != "" {
+			want += "." + trimmed
+		}
+		assert.Equal(t, want, units.Format(value, decimals), amount)
+	}
+}
@@ -29,6 +29,7 @@ type Collection interface {
 	Aggregate(context.Context, interface{}) (Cursor, error)
 	UpdateOne(context.Context, interface{}, interface{}, ...*options.UpdateOptions) (*mongo.UpdateResult, error)
 	UpdateMany(context.Context, interface{}, interface{}, ...*options.UpdateOptions) (*mongo.UpdateResult, error)
+	CreateIndex(context.Context, mongo.IndexModel) (string, error)
 }
 
 type SingleResult interface {
@@ -177,6 +178,10 @@ func (mc *mongoCollection) UpdateMany(ctx context.Context, filter interface{}, u
 	return mc.coll.UpdateMany(ctx, filter, update, opts[:]...)
 }
 
+func (mc *mongoCollection) CreateIndex(ctx context.Context, model mongo.IndexModel) (string, error) {
+	return mc.coll.Indexes().CreateOne(ctx, model)
+}
+
 func (mc *mongoCollection) CountDocuments(ctx context.Context, filter interface{}, opts ...*options.CountOptions) (int64, error) {
 	return mc.coll.CountDocuments(ctx, filter, opts...)
 }
@@ -0,0 +1,130 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/littlecheny/go-backend/domain"
+)
+
+type refreshTokenRepository struct {
+	cache domain.RedisService
+}
+
+func NewRefreshTokenRepository(cache domain.RedisService) domain.RefreshTokenRepository {
+	return &refreshTokenRepository{
+		cache: cache,
+	}
+}
+
+func refreshFamilyKey(familyID string) string {
+	return fmt.Sprintf("refresh_family:%s", familyID)
+}
+
+func userRefreshFamiliesKey(userID string) string {
+	return fmt.Sprintf("refresh_families:%s", userID)
+}
+
+// SaveCurrent 将claims中的jti记为家族当前唯一有效的刷新令牌，过期时间与令牌一致
+func (rr *refreshTokenRepository) SaveCurrent(c context.Context, claims *domain.JwtCustomRefreshClaims) error {
+	expiration := time.Until(time.Unix(claims.ExpiresAt, 0))
+	if expiration <= 0 {
+		return fmt.Errorf("refresh token already expired")
+	}
+
+	err := rr.cache.Set(c, refreshFamilyKey(claims.FamilyID), claims.Id, expiration)
+	if err != nil {
+		return err
+	}
+	return rr.trackFamily(c, claims, expiration)
+}
+
+// RotateCurrent 家族当前jti等于previousID时原子地替换为claims中的jti；
+// 已被其他请求替换时返回ErrRefreshTokenReused，家族不存在时返回ErrRefreshTokenInvalid
+func (rr *refreshTokenRepository) RotateCurrent(c context.Context, previousID string, claims *domain.JwtCustomRefreshClaims) error {
+	expiration := time.Until(time.Unix(claims.ExpiresAt, 0))
+	if expiration <= 0 {
+		return fmt.Errorf("refresh token already expired")
+	}
+
+	key := refreshFamilyKey(claims.FamilyID)
+	swapped, err := rr.cache.CompareAndSwap(c, key, previousID, claims.Id, expiration)
+	if err != nil {
+		return err
+	}
+	if !swapped {
+		exists, err := rr.cache.Exists(c, key)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return domain.ErrRefreshTokenInvalid
+		}
+		return domain.ErrRefreshTokenReused
+	}
+	return rr.trackFamily(c, claims, expiration)
+}
+
+// trackFamily 记录用户名下的家族，用于退出所有设备
+func (rr *refreshTokenRepository) trackFamily(c context.Context, claims *domain.JwtCustomRefreshClaims, expiration time.Duration) error {
+	familiesKey := userRefreshFamiliesKey(claims.ID)
+	err := rr.cache.AddToSet(c, familiesKey, claims.FamilyID)
+	if err != nil {
+		return err
+	}
+	return rr.cache.SetExpiration(c, familiesKey, expiration)
+}
+
+// GetCurrent 返回家族当前有效的jti，家族不存在（已吊销或过期）时返回ErrRefreshTokenInvalid
+func (rr *refreshTokenRepository) GetCurrent(c context.Context, familyID string) (string, error) {
+	key := refreshFamilyKey(familyID)
+
+	exists, err := rr.cache.Exists(c, key)
+	if err != nil {
+		return "", err
+	}
+	if !exists {
+		return "", domain.ErrRefreshTokenInvalid
+	}
+
+	val, err := rr.cache.Get(c, key)
+	if err != nil {
+		return "", err
+	}
+
+	var jti string
+	err = json.Unmarshal([]byte(val), &jti)
+	if err != nil {
+		return "", fmt.Errorf("failed to unmarshal refresh token family %s: %v", familyID, err)
+	}
+	return jti, nil
+}
+
+func (rr *refreshTokenRepository) RevokeFamily(c context.Context, familyID string) error {
+	return rr.cache.Del(c, refreshFamilyKey(familyID))
+}
+
+func (rr *refreshTokenRepository) RevokeUserFamilies(c context.Context, userID string) error {
+	familiesKey := userRefreshFamiliesKey(userID)
+
+	members, err := rr.cache.GetSetMembers(c, familiesKey)
+	if err != nil {
+		return err
+	}
+
+	for _, member := range members {
+		var familyID string
+		err = json.Unmarshal([]byte(member), &familyID)
+		if err != nil {
+			return fmt.Errorf("failed to unmarshal refresh token family: %v", err)
+		}
+		err = rr.RevokeFamily(c, familyID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return rr.cache.Del(c, familiesKey)
+}
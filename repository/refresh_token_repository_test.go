@@ -0,0 +1,63 @@
+package repository_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/littlecheny/go-backend/domain"
+	"github.com/littlecheny/go-backend/repository"
+	"github.com/littlecheny/go-backend/services"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRotateCurrent(t *testing.T) {
+	ctx := context.Background()
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { client.Close() })
+
+	rr := repository.NewRefreshTokenRepository(services.NewRedisService(client))
+
+	claims := func(jti string) *domain.JwtCustomRefreshClaims {
+		c := &domain.JwtCustomRefreshClaims{ID: "user-id", FamilyID: "family-id"}
+		c.Id = jti
+		c.ExpiresAt = time.Now().Add(time.Hour).Unix()
+		return c
+	}
+	require.NoError(t, rr.SaveCurrent(ctx, claims("jti-0")))
+
+	// 同一令牌并发刷新只有一个请求成功
+	var wg sync.WaitGroup
+	errs := make([]error, 10)
+	for i := range errs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs[i] = rr.RotateCurrent(ctx, "jti-0", claims("jti-next"))
+		}(i)
+	}
+	wg.Wait()
+
+	succeeded := 0
+	for _, err := range errs {
+		if err == nil {
+			succeeded++
+		} else {
+			assert.ErrorIs(t, err, domain.ErrRefreshTokenReused)
+		}
+	}
+	assert.Equal(t, 1, succeeded)
+
+	current, err := rr.GetCurrent(ctx, "family-id")
+	assert.NoError(t, err)
+	assert.Equal(t, "jti-next", current)
+
+	require.NoError(t, rr.RevokeFamily(ctx, "family-id"))
+	err = rr.RotateCurrent(ctx, "jti-next", claims("jti-2"))
+	assert.ErrorIs(t, err, domain.ErrRefreshTokenInvalid)
+}
@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/littlecheny/go-backend/domain"
+)
+
+type tokenRevocationRepository struct {
+	cache domain.RedisService
+}
+
+func NewTokenRevocationRepository(cache domain.RedisService) domain.TokenRevocationRepository {
+	return &tokenRevocationRepository{
+		cache: cache,
+	}
+}
+
+func revokedTokenKey(tokenID string) string {
+	return fmt.Sprintf("revoked_token:%s", tokenID)
+}
+
+func revokedBeforeKey(userID string) string {
+	return fmt.Sprintf("tokens_revoked_before:%s", userID)
+}
+
+// RevokeToken 吊销单个访问令牌，记录保留到令牌自然过期
+func (tr *tokenRevocationRepository) RevokeToken(c context.Context, tokenID string, expiresAt int64) error {
+	expiration := time.Until(time.Unix(expiresAt, 0))
+	if expiration <= 0 {
+		return nil
+	}
+	return tr.cache.Set(c, revokedTokenKey(tokenID), true, expiration)
+}
+
+// RevokeUserTokens 吊销用户在before（Unix秒）及之前签发的全部访问令牌，expiration应不短于访问令牌有效期
+func (tr *tokenRevocationRepository) RevokeUserTokens(c context.Context, userID string, before int64, expiration time.Duration) error {
+	return tr.cache.Set(c, revokedBeforeKey(userID), before, expiration)
+}
+
+func (tr *tokenRevocationRepository) IsRevoked(c context.Context, claims *domain.JwtCustomClaims) (bool, error) {
+	if claims.Id != "" {
+		revoked, err := tr.cache.Exists(c, revokedTokenKey(claims.Id))
+		if err != nil {
+			return false, err
+		}
+		if revoked {
+			return true, nil
+		}
+	}
+
+	key := revokedBeforeKey(claims.ID)
+	exists, err := tr.cache.Exists(c, key)
+	if err != nil {
+		return false, err
+	}
+	if !exists {
+		return false, nil
+	}
+
+	val, err := tr.cache.Get(c, key)
+	if err != nil {
+		return false, err
+	}
+
+	var before int64
+	err = json.Unmarshal([]byte(val), &before)
+	if err != nil {
+		return false, fmt.Errorf("failed to unmarshal revocation time for user %s: %v", claims.ID, err)
+	}
+
+	// iat只精确到秒，与吊销同一秒签发的令牌无法区分先后，一并视为已吊销
+	return claims.IssuedAt <= before, nil
+}
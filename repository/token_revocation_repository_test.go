@@ -0,0 +1,51 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/littlecheny/go-backend/domain"
+	"github.com/littlecheny/go-backend/repository"
+	"github.com/littlecheny/go-backend/services"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsRevoked(t *testing.T) {
+	ctx := context.Background()
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { client.Close() })
+
+	tr := repository.NewTokenRevocationRepository(services.NewRedisService(client))
+
+	now := time.Now().Unix()
+	claims := func(issuedAt int64) *domain.JwtCustomClaims {
+		c := &domain.JwtCustomClaims{ID: "user-id"}
+		c.IssuedAt = issuedAt
+		return c
+	}
+
+	revoked, err := tr.IsRevoked(ctx, claims(now))
+	assert.NoError(t, err)
+	assert.False(t, revoked)
+
+	require.NoError(t, tr.RevokeUserTokens(ctx, "user-id", now, time.Hour))
+
+	for _, tt := range []struct {
+		issuedAt int64
+		revoked  bool
+	}{
+		{now - 1, true},
+		// 与退出所有设备同一秒签发
+		{now, true},
+		{now + 1, false},
+	} {
+		revoked, err := tr.IsRevoked(ctx, claims(tt.issuedAt))
+		assert.NoError(t, err)
+		assert.Equal(t, tt.revoked, revoked, tt.issuedAt)
+	}
+}
@@ -0,0 +1,218 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/littlecheny/go-backend/domain"
+	"github.com/littlecheny/go-backend/mongo"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	mongodriver "go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type transactionRepository struct {
+	database   mongo.Database
+	collection string
+}
+
+func NewTransactionRepository(db mongo.Database, collection string) domain.TransactionRepository {
+	return &transactionRepository{
+		database:   db,
+		collection: collection,
+	}
+}
+
+func (tr *transactionRepository) Create(c context.Context, transaction *domain.Transaction) error {
+	collection := tr.database.Collection(tr.collection)
+
+	_, err := collection.InsertOne(c, transaction)
+
+	return err
+}
+
+func (tr *transactionRepository) GetByID(c context.Context, id string) (domain.Transaction, error) {
+	var transaction domain.Transaction
+
+	idHex, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return transaction, domain.ErrTransactionNotFound
+	}
+
+	return tr.findOne(c, bson.M{"_id": idHex})
+}
+
+func (tr *transactionRepository) GetByHash(c context.Context, hash string) (domain.Transaction, error) {
+	return tr.findOne(c, bson.M{"hash": hash})
+}
+
+// GetByHashAndWalletID 托管钱包之间转账时同一哈希有send和receive两条记录
+func (tr *transactionRepository) GetByHashAndWalletID(c context.Context, hash, walletID string) (domain.Transaction, error) {
+	idHex, err := primitive.ObjectIDFromHex(walletID)
+	if err != nil {
+		return domain.Transaction{}, domain.ErrTransactionNotFound
+	}
+
+	return tr.findOne(c, bson.M{"hash": hash, "wallet_id": idHex})
+}
+
+// GetByHashAndUserID 用户自己钱包之间转账时返回send和receive两条记录
+func (tr *transactionRepository) GetByHashAndUserID(c context.Context, hash, userID string) ([]domain.Transaction, error) {
+	idHex, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return tr.find(c, bson.M{"hash": hash, "user_id": idHex}, 0, 0)
+}
+
+func (tr *transactionRepository) GetByUserID(c context.Context, userID string, limit, offset int) ([]domain.Transaction, error) {
+	idHex, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return tr.find(c, bson.M{"user_id": idHex}, limit, offset)
+}
+
+func (tr *transactionRepository) GetByWalletID(c context.Context, walletID string, limit, offset int) ([]domain.Transaction, error) {
+	idHex, err := primitive.ObjectIDFromHex(walletID)
+	if err != nil {
+		return nil, err
+	}
+
+	return tr.find(c, bson.M{"wallet_id": idHex}, limit, offset)
+}
+
+func (tr *transactionRepository) Update(c context.Context, transaction *domain.Transaction) error {
+	collection := tr.database.Collection(tr.collection)
+
+	transaction.UpdatedAt = time.Now()
+	update := bson.M{"$set": bson.M{
+		"hash":            transaction.Hash,
+		"gas_price":       transaction.GasPrice,
+		"gas_limit":       transaction.GasLimit,
+		"gas_used":        transaction.GasUsed,
+		"nonce":           transaction.Nonce,
+		"status":          transaction.Status,
+		"block_number":    transaction.BlockNumber,
+		"block_hash":      transaction.BlockHash,
+		"transaction_fee": transaction.TransactionFee,
+		"updated_at":      transaction.UpdatedAt,
+		"confirmed_at":    transaction.ConfirmedAt,
+		"replaced_by":     transaction.ReplacedBy,
+	}}
+
+	_, err := collection.UpdateOne(c, bson.M{"_id": transaction.ID}, update)
+
+	return err
+}
+
+func (tr *transactionRepository) UpdateStatus(c context.Context, hash string, status domain.TransactionStatus) error {
+	collection := tr.database.Collection(tr.collection)
+
+	update := bson.M{"$set": bson.M{
+		"status":     status,
+		"updated_at": time.Now(),
+	}}
+
+	_, err := collection.UpdateMany(c, bson.M{"hash": hash}, update)
+
+	return err
+}
+
+func (tr *transactionRepository) GetPendingTransactions(c context.Context) ([]domain.Transaction, error) {
+	collection := tr.database.Collection(tr.collection)
+
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}})
+	cursor, err := collection.Find(c, bson.M{"status": domain.TransactionStatusPending}, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var transactions []domain.Transaction
+	err = cursor.All(c, &transactions)
+	if transactions == nil {
+		return []domain.Transaction{}, err
+	}
+
+	return transactions, err
+}
+
+// GetMinedAfter 查询指定网络中已记录在blockNumber之后区块中的交易，用于链重组回滚
+func (tr *transactionRepository) GetMinedAfter(c context.Context, network string, blockNumber uint64) ([]domain.Transaction, error) {
+	collection := tr.database.Collection(tr.collection)
+
+	filter := bson.M{
+		"network":      network,
+		"block_number": bson.M{"$gt": blockNumber},
+		"status":       bson.M{"$in": []domain.TransactionStatus{domain.TransactionStatusConfirmed, domain.TransactionStatusFailed}},
+	}
+	cursor, err := collection.Find(c, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	var transactions []domain.Transaction
+	err = cursor.All(c, &transactions)
+	if transactions == nil {
+		return []domain.Transaction{}, err
+	}
+
+	return transactions, err
+}
+
+// GetByNonce 查询地址以指定nonce发出的最新一笔交易
+func (tr *transactionRepository) GetByNonce(c context.Context, network, from string, nonce uint64) (domain.Transaction, error) {
+	filter := bson.M{
+		"network": network,
+		"from":    from,
+		"nonce":   nonce,
+		"type":    domain.TransactionTypeSend,
+	}
+	transactions, err := tr.find(c, filter, 1, 0)
+	if err != nil {
+		return domain.Transaction{}, err
+	}
+	if len(transactions) == 0 {
+		return domain.Transaction{}, domain.ErrTransactionNotFound
+	}
+	return transactions[0], nil
+}
+
+func (tr *transactionRepository) findOne(c context.Context, filter bson.M) (domain.Transaction, error) {
+	collection := tr.database.Collection(tr.collection)
+
+	var transaction domain.Transaction
+	err := collection.FindOne(c, filter).Decode(&transaction)
+	if errors.Is(err, mongodriver.ErrNoDocuments) {
+		return transaction, domain.ErrTransactionNotFound
+	}
+
+	return transaction, err
+}
+
+// find 按创建时间倒序分页查询
+func (tr *transactionRepository) find(c context.Context, filter bson.M, limit, offset int) ([]domain.Transaction, error) {
+	collection := tr.database.Collection(tr.collection)
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: -1}}).
+		SetSkip(int64(offset)).
+		SetLimit(int64(limit))
+
+	cursor, err := collection.Find(c, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var transactions []domain.Transaction
+	err = cursor.All(c, &transactions)
+	if transactions == nil {
+		return []domain.Transaction{}, err
+	}
+
+	return transactions, err
+}
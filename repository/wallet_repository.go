@@ -0,0 +1,545 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/littlecheny/go-backend/domain"
+	"github.com/littlecheny/go-backend/internal/units"
+	"github.com/littlecheny/go-backend/mongo"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	mongodriver "go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type walletRepository struct {
+	database              mongo.Database
+	collection            string
+	privateDataCollection string
+}
+
+func NewWalletRepository(db mongo.Database, collection string, privateDataCollection string) domain.WalletRepository {
+	return &walletRepository{
+		database:              db,
+		collection:            collection,
+		privateDataCollection: privateDataCollection,
+	}
+}
+
+// activeFilter 排除已删除的钱包
+func activeFilter(filter bson.M) bson.M {
+	filter["status"] = bson.M{"$ne": domain.WalletStatusDeleted}
+	return filter
+}
+
+// EnsureIndexes 唯一索引只覆盖未删除的钱包，删除后可以重新导入同一地址；
+// 部分索引的过滤条件不支持$ne，$in需要MongoDB 6.0及以上
+func (wr *walletRepository) EnsureIndexes(ctx context.Context) error {
+	collection := wr.database.Collection(wr.collection)
+
+	_, err := collection.CreateIndex(ctx, mongodriver.IndexModel{
+		Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "address", Value: 1}, {Key: "network", Value: 1}},
+		Options: options.Index().
+			SetName("user_address_network").
+			SetUnique(true).
+			SetPartialFilterExpression(bson.M{"status": bson.M{"$in": bson.A{domain.WalletStatusActive, domain.WalletStatusInactive}}}),
+	})
+
+	return err
+}
+
+// duplicateWalletError 唯一索引冲突说明用户在该网络下已有同一地址的钱包
+func duplicateWalletError(err error) error {
+	if mongodriver.IsDuplicateKeyError(err) {
+		return domain.ErrWalletExists
+	}
+	return err
+}
+
+func (wr *walletRepository) Create(ctx context.Context, wallet *domain.Wallet) error {
+	collection := wr.database.Collection(wr.collection)
+
+	_, err := collection.InsertOne(ctx, wallet)
+
+	return duplicateWalletError(err)
+}
+
+func (wr *walletRepository) GetByID(ctx context.Context, id string) (*domain.Wallet, error) {
+	collection := wr.database.Collection(wr.collection)
+
+	idHex, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, domain.ErrWalletNotFound
+	}
+
+	var wallet domain.Wallet
+	err = collection.FindOne(ctx, activeFilter(bson.M{"_id": idHex})).Decode(&wallet)
+	if err != nil {
+		if errors.Is(err, mongodriver.ErrNoDocuments) {
+			return nil, domain.ErrWalletNotFound
+		}
+		return nil, err
+	}
+
+	return &wallet, nil
+}
+
+func (wr *walletRepository) GetByAddress(ctx context.Context, address string) (*domain.Wallet, error) {
+	collection := wr.database.Collection(wr.collection)
+
+	var wallet domain.Wallet
+	err := collection.FindOne(ctx, activeFilter(bson.M{"address": address})).Decode(&wallet)
+	if err != nil {
+		if errors.Is(err, mongodriver.ErrNoDocuments) {
+			return nil, domain.ErrWalletNotFound
+		}
+		return nil, err
+	}
+
+	return &wallet, nil
+}
+
+// GetByAddressAndNetwork 查询地址在指定网络下的所有钱包，不同用户可以导入同一地址
+func (wr *walletRepository) GetByAddressAndNetwork(ctx context.Context, address string, network string) ([]domain.Wallet, error) {
+	collection := wr.database.Collection(wr.collection)
+
+	cursor, err := collection.Find(ctx, activeFilter(bson.M{"address": address, "network": network}))
+	if err != nil {
+		return nil, err
+	}
+
+	var wallets []domain.Wallet
+	err = cursor.All(ctx, &wallets)
+	if wallets == nil {
+		return []domain.Wallet{}, err
+	}
+
+	return wallets, err
+}
+
+func (wr *walletRepository) GetByUserID(ctx context.Context, userID string, page, pageSize int) ([]domain.Wallet, int, error) {
+	collection := wr.database.Collection(wr.collection)
+
+	idHex, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	filter := activeFilter(bson.M{"user_id": idHex})
+
+	total, err := collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: -1}}).
+		SetSkip(int64((page - 1) * pageSize)).
+		SetLimit(int64(pageSize))
+
+	cursor, err := collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var wallets []domain.Wallet
+	err = cursor.All(ctx, &wallets)
+	if wallets == nil {
+		return []domain.Wallet{}, int(total), err
+	}
+
+	return wallets, int(total), err
+}
+
+func (wr *walletRepository) Update(ctx context.Context, wallet *domain.Wallet) error {
+	collection := wr.database.Collection(wr.collection)
+
+	wallet.UpdatedAt = time.Now()
+	update := bson.M{"$set": bson.M{
+		"name":        wallet.Name,
+		"network":     wallet.Network,
+		"status":      wallet.Status,
+		"balance":     wallet.Balance,
+		"balance_usd": wallet.BalanceUSD,
+		"is_default":  wallet.IsDefault,
+		"updated_at":  wallet.UpdatedAt,
+	}}
+
+	_, err := collection.UpdateOne(ctx, bson.M{"_id": wallet.ID}, update)
+
+	return duplicateWalletError(err)
+}
+
+// Delete 软删除钱包，记录保留用于交易历史关联
+func (wr *walletRepository) Delete(ctx context.Context, id string) error {
+	collection := wr.database.Collection(wr.collection)
+
+	idHex, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return domain.ErrWalletNotFound
+	}
+
+	update := bson.M{"$set": bson.M{
+		"status":     domain.WalletStatusDeleted,
+		"is_default": false,
+		"updated_at": time.Now(),
+	}}
+
+	_, err = collection.UpdateOne(ctx, bson.M{"_id": idHex}, update)
+
+	return err
+}
+
+func (wr *walletRepository) CreatePrivateData(ctx context.Context, data *domain.WalletPrivateData) error {
+	collection := wr.database.Collection(wr.privateDataCollection)
+
+	_, err := collection.InsertOne(ctx, data)
+
+	return err
+}
+
+func (wr *walletRepository) GetPrivateData(ctx context.Context, walletID string) (*domain.WalletPrivateData, error) {
+	collection := wr.database.Collection(wr.privateDataCollection)
+
+	idHex, err := primitive.ObjectIDFromHex(walletID)
+	if err != nil {
+		return nil, domain.ErrWalletNotFound
+	}
+
+	var data domain.WalletPrivateData
+	err = collection.FindOne(ctx, bson.M{"wallet_id": idHex}).Decode(&data)
+	if err != nil {
+		if errors.Is(err, mongodriver.ErrNoDocuments) {
+			return nil, domain.ErrWalletNotFound
+		}
+		return nil, err
+	}
+
+	return &data, nil
+}
+
+func (wr *walletRepository) UpdatePrivateData(ctx context.Context, data *domain.WalletPrivateData) error {
+	collection := wr.database.Collection(wr.privateDataCollection)
+
+	data.UpdatedAt = time.Now()
+	update := bson.M{"$set": bson.M{
+		"encrypted_data_key":   data.EncryptedDataKey,
+		"master_key_version":   data.MasterKeyVersion,
+		"encrypted_key":        data.EncryptedKey,
+		"encrypted_mnemonic":   data.EncryptedMnemonic,
+		"encrypted_passphrase": data.EncryptedPassphrase,
+		"key_derivation_path":  data.KeyDerivationPath,
+		"salt":                 data.Salt,
+		"updated_at":           data.UpdatedAt,
+	}}
+
+	_, err := collection.UpdateOne(ctx, bson.M{"_id": data.ID}, update)
+
+	return err
+}
+
+func (wr *walletRepository) DeletePrivateData(ctx context.Context, walletID string) error {
+	collection := wr.database.Collection(wr.privateDataCollection)
+
+	idHex, err := primitive.ObjectIDFromHex(walletID)
+	if err != nil {
+		return domain.ErrWalletNotFound
+	}
+
+	_, err = collection.DeleteOne(ctx, bson.M{"wallet_id": idHex})
+
+	return err
+}
+
+// GetStalePrivateData 按_id顺序分批获取主密钥版本不是masterKeyVersion的私有数据，afterID为上一批最后一条
+func (wr *walletRepository) GetStalePrivateData(ctx context.Context, masterKeyVersion int, afterID string, limit int) ([]domain.WalletPrivateData, error) {
+	collection := wr.database.Collection(wr.privateDataCollection)
+
+	filter := bson.M{"master_key_version": bson.M{"$ne": masterKeyVersion}}
+	if afterID != "" {
+		idHex, err := primitive.ObjectIDFromHex(afterID)
+		if err != nil {
+			return nil, err
+		}
+		filter["_id"] = bson.M{"$gt": idHex}
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "_id", Value: 1}}).
+		SetLimit(int64(limit))
+
+	cursor, err := collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var data []domain.WalletPrivateData
+	err = cursor.All(ctx, &data)
+	if data == nil {
+		return []domain.WalletPrivateData{}, err
+	}
+
+	return data, err
+}
+
+// UpdateDataKey 仅在记录仍为fromVersion时替换包裹后的数据密钥，避免覆盖并发写入；记录已被修改时返回false
+func (wr *walletRepository) UpdateDataKey(ctx context.Context, id string, fromVersion int, encryptedDataKey string, toVersion int) (bool, error) {
+	collection := wr.database.Collection(wr.privateDataCollection)
+
+	idHex, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return false, domain.ErrWalletNotFound
+	}
+
+	update := bson.M{"$set": bson.M{
+		"encrypted_data_key": encryptedDataKey,
+		"master_key_version": toVersion,
+		"updated_at":         time.Now(),
+	}}
+
+	result, err := collection.UpdateOne(ctx, bson.M{"_id": idHex, "master_key_version": fromVersion}, update)
+	if err != nil {
+		return false, err
+	}
+
+	return result.MatchedCount > 0, nil
+}
+
+func (wr *walletRepository) GetDefaultWallet(ctx context.Context, userID string, network string) (*domain.Wallet, error) {
+	collection := wr.database.Collection(wr.collection)
+
+	idHex, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var wallet domain.Wallet
+	err = collection.FindOne(ctx, activeFilter(bson.M{"user_id": idHex, "network": network, "is_default": true})).Decode(&wallet)
+	if err != nil {
+		if errors.Is(err, mongodriver.ErrNoDocuments) {
+			return nil, domain.ErrWalletNotFound
+		}
+		return nil, err
+	}
+
+	return &wallet, nil
+}
+
+// SetDefaultWallet 将钱包设为其所在网络的默认钱包，同网络的其他钱包取消默认
+func (wr *walletRepository) SetDefaultWallet(ctx context.Context, userID string, walletID string) error {
+	collection := wr.database.Collection(wr.collection)
+
+	userIDHex, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return err
+	}
+
+	wallet, err := wr.GetByID(ctx, walletID)
+	if err != nil {
+		return err
+	}
+	if wallet.UserID != userIDHex {
+		return domain.ErrWalletNotFound
+	}
+
+	now := time.Now()
+	_, err = collection.UpdateMany(ctx,
+		bson.M{"user_id": userIDHex, "network": wallet.Network, "_id": bson.M{"$ne": wallet.ID}},
+		bson.M{"$set": bson.M{"is_default": false, "updated_at": now}},
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = collection.UpdateOne(ctx,
+		bson.M{"_id": wallet.ID},
+		bson.M{"$set": bson.M{"is_default": true, "updated_at": now}},
+	)
+
+	return err
+}
+
+func (wr *walletRepository) GetWalletsByNetwork(ctx context.Context, userID string, network string) ([]domain.Wallet, error) {
+	collection := wr.database.Collection(wr.collection)
+
+	idHex, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
+	cursor, err := collection.Find(ctx, activeFilter(bson.M{"user_id": idHex, "network": network}), opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var wallets []domain.Wallet
+	err = cursor.All(ctx, &wallets)
+	if wallets == nil {
+		return []domain.Wallet{}, err
+	}
+
+	return wallets, err
+}
+
+// GetActiveWallets 查询指定网络中所有未删除的钱包，用于监听链上交易
+func (wr *walletRepository) GetActiveWallets(ctx context.Context, network string) ([]domain.Wallet, error) {
+	collection := wr.database.Collection(wr.collection)
+
+	cursor, err := collection.Find(ctx, activeFilter(bson.M{"network": network}))
+	if err != nil {
+		return nil, err
+	}
+
+	var wallets []domain.Wallet
+	err = cursor.All(ctx, &wallets)
+	if wallets == nil {
+		return []domain.Wallet{}, err
+	}
+
+	return wallets, err
+}
+
+func (wr *walletRepository) UpdateBalance(ctx context.Context, walletID string, balance string, balanceUSD string) error {
+	collection := wr.database.Collection(wr.collection)
+
+	idHex, err := primitive.ObjectIDFromHex(walletID)
+	if err != nil {
+		return domain.ErrWalletNotFound
+	}
+
+	update := bson.M{"$set": bson.M{
+		"balance":     balance,
+		"balance_usd": balanceUSD,
+		"updated_at":  time.Now(),
+	}}
+
+	_, err = collection.UpdateOne(ctx, bson.M{"_id": idHex}, update)
+
+	return err
+}
+
+func (wr *walletRepository) GetUserWalletCount(ctx context.Context, userID string) (int, error) {
+	collection := wr.database.Collection(wr.collection)
+
+	idHex, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return 0, err
+	}
+
+	count, err := collection.CountDocuments(ctx, activeFilter(bson.M{"user_id": idHex}))
+
+	return int(count), err
+}
+
+// GetTotalBalance 汇总用户启用状态钱包的余额(ETH)，network为空时统计全部网络
+func (wr *walletRepository) GetTotalBalance(ctx context.Context, userID string, network string) (string, error) {
+	collection := wr.database.Collection(wr.collection)
+
+	idHex, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return "", err
+	}
+
+	filter := bson.M{"user_id": idHex, "status": domain.WalletStatusActive}
+	if network != "" {
+		filter["network"] = network
+	}
+
+	pipeline := bson.A{
+		bson.M{"$match": filter},
+		bson.M{"$group": bson.M{"_id": nil, "total_balance": balanceSum}},
+	}
+
+	cursor, err := collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return "", err
+	}
+
+	var groups []networkGroup
+	err = cursor.All(ctx, &groups)
+	if err != nil {
+		return "", err
+	}
+	if len(groups) == 0 {
+		return "0", nil
+	}
+
+	return formatBalance(groups[0].TotalBalance)
+}
+
+// balanceSum 余额以字符串保存，在Mongo中转为Decimal128精确累加；
+// 空字符串和缺失的余额排序在所有非空字符串之前，按0计算
+var balanceSum = bson.M{"$sum": bson.M{"$toDecimal": bson.M{
+	"$cond": bson.A{bson.M{"$gt": bson.A{"$balance", ""}}, "$balance", "0"},
+}}}
+
+// networkGroup 按网络分组的聚合结果
+type networkGroup struct {
+	Network      string               `bson:"_id"`
+	WalletCount  int                  `bson:"wallet_count"`
+	TotalBalance primitive.Decimal128 `bson:"total_balance"`
+}
+
+// GetNetworkStats 只聚合用户启用状态的钱包，停用和已删除的钱包不计入，按网络名排序
+func (wr *walletRepository) GetNetworkStats(ctx context.Context, userID string) ([]domain.NetworkStats, error) {
+	collection := wr.database.Collection(wr.collection)
+
+	idHex, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	pipeline := bson.A{
+		bson.M{"$match": bson.M{"user_id": idHex, "status": domain.WalletStatusActive}},
+		bson.M{"$group": bson.M{
+			"_id":           "$network",
+			"wallet_count":  bson.M{"$sum": 1},
+			"total_balance": balanceSum,
+		}},
+		bson.M{"$sort": bson.M{"_id": 1}},
+	}
+
+	cursor, err := collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+
+	var groups []networkGroup
+	err = cursor.All(ctx, &groups)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := make([]domain.NetworkStats, 0, len(groups))
+	for _, group := range groups {
+		total, err := formatBalance(group.TotalBalance)
+		if err != nil {
+			return nil, fmt.Errorf("network %s: %w", group.Network, err)
+		}
+
+		stats = append(stats, domain.NetworkStats{
+			Network:      group.Network,
+			WalletCount:  group.WalletCount,
+			TotalBalance: total,
+		})
+	}
+	return stats, nil
+}
+
+// formatBalance Decimal128可能是科学计数法，统一为与钱包余额相同的ETH格式
+func formatBalance(total primitive.Decimal128) (string, error) {
+	amount, err := units.ParseDecimal(total.String())
+	if err != nil {
+		return "", err
+	}
+
+	wei, err := units.ParseEther(units.FormatDecimal(amount, units.EtherDecimals))
+	if err != nil {
+		return "", err
+	}
+	return units.FormatEther(wei), nil
+}
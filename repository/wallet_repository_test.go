@@ -0,0 +1,147 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/littlecheny/go-backend/domain"
+	"github.com/littlecheny/go-backend/mongo/mocks"
+	"github.com/littlecheny/go-backend/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	mongodriver "go.mongodb.org/mongo-driver/mongo"
+)
+
+// aggregateResult 模拟cursor.All，把聚合结果解码到传入的切片
+func aggregateResult(t *testing.T, documents bson.A) func(args mock.Arguments) {
+	return func(args mock.Arguments) {
+		kind, data, err := bson.MarshalValue(documents)
+		require.NoError(t, err)
+		require.NoError(t, bson.UnmarshalValue(kind, data, args.Get(1)))
+	}
+}
+
+func decimal(t *testing.T, value string) primitive.Decimal128 {
+	d, err := primitive.ParseDecimal128(value)
+	require.NoError(t, err)
+	return d
+}
+
+func TestCreateWallet(t *testing.T) {
+	t.Run("duplicate address", func(t *testing.T) {
+		databaseHelper := &mocks.Database{}
+		collectionHelper := &mocks.Collection{}
+
+		databaseHelper.On("Collection", domain.CollectionWallet).Return(collectionHelper)
+		collectionHelper.On("InsertOne", mock.Anything, mock.AnythingOfType("*domain.Wallet")).Return(nil, mongodriver.WriteException{
+			WriteErrors: mongodriver.WriteErrors{{Code: 11000, Message: "E11000 duplicate key error"}},
+		}).Once()
+
+		wr := repository.NewWalletRepository(databaseHelper, domain.CollectionWallet, domain.CollectionWalletPrivateData)
+
+		err := wr.Create(context.Background(), &domain.Wallet{ID: primitive.NewObjectID()})
+
+		assert.ErrorIs(t, err, domain.ErrWalletExists)
+
+		collectionHelper.AssertExpectations(t)
+	})
+}
+
+func TestEnsureIndexes(t *testing.T) {
+	databaseHelper := &mocks.Database{}
+	collectionHelper := &mocks.Collection{}
+
+	databaseHelper.On("Collection", domain.CollectionWallet).Return(collectionHelper)
+	collectionHelper.On("CreateIndex", mock.Anything, mock.MatchedBy(func(model mongodriver.IndexModel) bool {
+		return *model.Options.Unique && model.Options.PartialFilterExpression != nil
+	})).Return("user_address_network", nil).Once()
+
+	wr := repository.NewWalletRepository(databaseHelper, domain.CollectionWallet, domain.CollectionWalletPrivateData)
+
+	err := wr.EnsureIndexes(context.Background())
+
+	assert.NoError(t, err)
+
+	collectionHelper.AssertExpectations(t)
+}
+
+func TestGetNetworkStats(t *testing.T) {
+	userID := primitive.NewObjectID()
+
+	databaseHelper := &mocks.Database{}
+	collectionHelper := &mocks.Collection{}
+	cursorHelper := &mocks.Cursor{}
+
+	databaseHelper.On("Collection", domain.CollectionWallet).Return(collectionHelper)
+	collectionHelper.On("Aggregate", mock.Anything, mock.MatchedBy(func(pipeline bson.A) bool {
+		match := pipeline[0].(bson.M)["$match"].(bson.M)
+		group := pipeline[1].(bson.M)["$group"].(bson.M)
+		return len(pipeline) == 3 && match["user_id"] == userID && match["status"] == domain.WalletStatusActive && group["total_balance"] != nil
+	})).Return(cursorHelper, nil).Once()
+	cursorHelper.On("All", mock.Anything, mock.Anything).Run(aggregateResult(t, bson.A{
+		bson.M{"_id": "mainnet", "wallet_count": 3, "total_balance": decimal(t, "0.3")},
+		bson.M{"_id": "sepolia", "wallet_count": 1, "total_balance": decimal(t, "1.2E-5")},
+	})).Return(nil).Once()
+
+	wr := repository.NewWalletRepository(databaseHelper, domain.CollectionWallet, domain.CollectionWalletPrivateData)
+
+	stats, err := wr.GetNetworkStats(context.Background(), userID.Hex())
+
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.NetworkStats{
+		{Network: "mainnet", WalletCount: 3, TotalBalance: "0.3"},
+		{Network: "sepolia", WalletCount: 1, TotalBalance: "0.000012"},
+	}, stats)
+
+	collectionHelper.AssertExpectations(t)
+	cursorHelper.AssertExpectations(t)
+}
+
+func TestGetTotalBalance(t *testing.T) {
+	userID := primitive.NewObjectID()
+
+	t.Run("network", func(t *testing.T) {
+		databaseHelper := &mocks.Database{}
+		collectionHelper := &mocks.Collection{}
+		cursorHelper := &mocks.Cursor{}
+
+		databaseHelper.On("Collection", domain.CollectionWallet).Return(collectionHelper)
+		collectionHelper.On("Aggregate", mock.Anything, mock.MatchedBy(func(pipeline bson.A) bool {
+			match := pipeline[0].(bson.M)["$match"].(bson.M)
+			return match["user_id"] == userID && match["network"] == "mainnet" && match["status"] == domain.WalletStatusActive
+		})).Return(cursorHelper, nil).Once()
+		cursorHelper.On("All", mock.Anything, mock.Anything).Run(aggregateResult(t, bson.A{
+			bson.M{"_id": nil, "total_balance": decimal(t, "1.500000000000000000")},
+		})).Return(nil).Once()
+
+		wr := repository.NewWalletRepository(databaseHelper, domain.CollectionWallet, domain.CollectionWalletPrivateData)
+
+		total, err := wr.GetTotalBalance(context.Background(), userID.Hex(), "mainnet")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "1.5", total)
+
+		collectionHelper.AssertExpectations(t)
+		cursorHelper.AssertExpectations(t)
+	})
+
+	t.Run("no wallets", func(t *testing.T) {
+		databaseHelper := &mocks.Database{}
+		collectionHelper := &mocks.Collection{}
+		cursorHelper := &mocks.Cursor{}
+
+		databaseHelper.On("Collection", domain.CollectionWallet).Return(collectionHelper)
+		collectionHelper.On("Aggregate", mock.Anything, mock.Anything).Return(cursorHelper, nil).Once()
+		cursorHelper.On("All", mock.Anything, mock.Anything).Run(aggregateResult(t, bson.A{})).Return(nil).Once()
+
+		wr := repository.NewWalletRepository(databaseHelper, domain.CollectionWallet, domain.CollectionWalletPrivateData)
+
+		total, err := wr.GetTotalBalance(context.Background(), userID.Hex(), "")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "0", total)
+	})
+}
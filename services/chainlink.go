@@ -0,0 +1,9 @@
+package services
+
+// aggregatorV3ABI 只包含用到的Chainlink AggregatorV3Interface方法
+const aggregatorV3ABI = `[
+	{"inputs":[],"name":"decimals","outputs":[{"name":"","type":"uint8"}],"stateMutability":"view","type":"function"},
+	{"inputs":[],"name":"latestRoundData","outputs":[{"name":"roundId","type":"uint80"},{"name":"answer","type":"int256"},{"name":"startedAt","type":"uint256"},{"name":"updatedAt","type":"uint256"},{"name":"answeredInRound","type":"uint80"}],"stateMutability":"view","type":"function"}
+]`
+
+var aggregatorV3 = mustParseABI(aggregatorV3ABI)
@@ -32,28 +32,12 @@ func NewCryptoService() domain.CryptoService {
 func (c *cryptoService) Encrypt(data string, key string) (string, error) {
 	// 将密钥转换为32字节
 	keyBytes := c.deriveKeyFromString(key)
-	
-	// 创建AES cipher
-	block, err := aes.NewCipher(keyBytes)
-	if err != nil {
-		return "", fmt.Errorf("failed to create cipher: %v", err)
-	}
 
-	// 创建GCM
-	gcm, err := cipher.NewGCM(block)
+	ciphertext, err := sealAESGCM(keyBytes, []byte(data))
 	if err != nil {
-		return "", fmt.Errorf("failed to create GCM: %v", err)
+		return "", err
 	}
 
-	// 生成随机nonce
-	nonce := make([]byte, gcm.NonceSize())
-	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
-		return "", fmt.Errorf("failed to generate nonce: %v", err)
-	}
-
-	// 加密数据
-	ciphertext := gcm.Seal(nonce, nonce, []byte(data), nil)
-
 	// 返回base64编码的结果
 	return base64.StdEncoding.EncodeToString(ciphertext), nil
 }
@@ -69,31 +53,49 @@ func (c *cryptoService) Decrypt(encryptedData string, key string) (string, error
 	// 将密钥转换为32字节
 	keyBytes := c.deriveKeyFromString(key)
 
-	// 创建AES cipher
-	block, err := aes.NewCipher(keyBytes)
+	plaintext, err := openAESGCM(keyBytes, ciphertext)
 	if err != nil {
-		return "", fmt.Errorf("failed to create cipher: %v", err)
+		return "", err
 	}
 
-	// 创建GCM
-	gcm, err := cipher.NewGCM(block)
+	return string(plaintext), nil
+}
+
+// GenerateDataKey 生成随机的256位数据密钥(base64)
+func (c *cryptoService) GenerateDataKey() (string, error) {
+	return c.GenerateRandomKey(c.defaultKeySize)
+}
+
+// EncryptWithDataKey 直接使用base64编码的256位密钥进行AES-GCM加密，不做哈希派生
+func (c *cryptoService) EncryptWithDataKey(data string, dataKey string) (string, error) {
+	keyBytes, err := decodeAESKey(dataKey)
 	if err != nil {
-		return "", fmt.Errorf("failed to create GCM: %v", err)
+		return "", err
 	}
 
-	// 检查密文长度
-	nonceSize := gcm.NonceSize()
-	if len(ciphertext) < nonceSize {
-		return "", fmt.Errorf("ciphertext too short")
+	ciphertext, err := sealAESGCM(keyBytes, []byte(data))
+	if err != nil {
+		return "", err
 	}
 
-	// 分离nonce和密文
-	nonce, ciphertext := ciphertext[:nonceSize], ciphertext[nonceSize:]
+	return base64.StdEncoding.EncodeToString(ciphertext), nil
+}
 
-	// 解密
-	plaintext, err := gcm.Open(nil, nonce, ciphertext, nil)
+// DecryptWithDataKey 使用base64编码的256位密钥进行AES-GCM解密
+func (c *cryptoService) DecryptWithDataKey(encryptedData string, dataKey string) (string, error) {
+	keyBytes, err := decodeAESKey(dataKey)
 	if err != nil {
-		return "", fmt.Errorf("failed to decrypt: %v", err)
+		return "", err
+	}
+
+	ciphertext, err := base64.StdEncoding.DecodeString(encryptedData)
+	if err != nil {
+		return "", fmt.Errorf("failed to decode base64: %v", err)
+	}
+
+	plaintext, err := openAESGCM(keyBytes, ciphertext)
+	if err != nil {
+		return "", err
 	}
 
 	return string(plaintext), nil
@@ -150,6 +152,73 @@ func (c *cryptoService) deriveKeyFromString(key string) []byte {
 	return hash[:]
 }
 
+// decodeAESKey 解码base64编码的256位密钥
+func decodeAESKey(key string) ([]byte, error) {
+	keyBytes, err := base64.StdEncoding.DecodeString(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode key: %v", err)
+	}
+	if len(keyBytes) != 32 {
+		return nil, fmt.Errorf("invalid key size %d, want 32 bytes", len(keyBytes))
+	}
+	return keyBytes, nil
+}
+
+// sealAESGCM 加密并在密文前附加随机nonce
+func sealAESGCM(key []byte, plaintext []byte) ([]byte, error) {
+	// 创建AES cipher
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %v", err)
+	}
+
+	// 创建GCM
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create GCM: %v", err)
+	}
+
+	// 生成随机nonce
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %v", err)
+	}
+
+	return gcm.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+// openAESGCM 解密sealAESGCM生成的密文
+func openAESGCM(key []byte, ciphertext []byte) ([]byte, error) {
+	// 创建AES cipher
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %v", err)
+	}
+
+	// 创建GCM
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create GCM: %v", err)
+	}
+
+	// 检查密文长度
+	nonceSize := gcm.NonceSize()
+	if len(ciphertext) < nonceSize {
+		return nil, fmt.Errorf("ciphertext too short")
+	}
+
+	// 分离nonce和密文
+	nonce, ciphertext := ciphertext[:nonceSize], ciphertext[nonceSize:]
+
+	// 解密
+	plaintext, err := gcm.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt: %v", err)
+	}
+
+	return plaintext, nil
+}
+
 // EncryptWithPassword 使用密码加密（包含盐值派生）
 func (c *cryptoService) EncryptWithPassword(data string, password string) (string, string, error) {
 	// 生成盐值
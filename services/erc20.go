@@ -0,0 +1,50 @@
+package services
+
+import (
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/littlecheny/go-backend/domain"
+)
+
+// erc20ABI 只包含用到的ERC-20方法和事件
+const erc20ABI = `[
+	{"constant":true,"inputs":[{"name":"owner","type":"address"}],"name":"balanceOf","outputs":[{"name":"","type":"uint256"}],"type":"function"},
+	{"constant":false,"inputs":[{"name":"to","type":"address"},{"name":"value","type":"uint256"}],"name":"transfer","outputs":[{"name":"","type":"bool"}],"type":"function"},
+	{"anonymous":false,"inputs":[{"indexed":true,"name":"from","type":"address"},{"indexed":true,"name":"to","type":"address"},{"indexed":false,"name":"value","type":"uint256"}],"name":"Transfer","type":"event"}
+]`
+
+var erc20 = mustParseABI(erc20ABI)
+
+// transferEventTopic Transfer(address,address,uint256)事件签名
+var transferEventTopic = erc20.Events["Transfer"].ID
+
+func mustParseABI(definition string) abi.ABI {
+	parsed, err := abi.JSON(strings.NewReader(definition))
+	if err != nil {
+		panic(err)
+	}
+	return parsed
+}
+
+// parseTransferLog 解析Transfer事件日志，不是标准ERC-20 Transfer事件时返回false
+func parseTransferLog(log types.Log) (*domain.TokenTransfer, bool) {
+	// ERC-721的Transfer事件tokenId也是indexed，topics为4个且data为空
+	if len(log.Topics) != 3 || log.Topics[0] != transferEventTopic || len(log.Data) != 32 {
+		return nil, false
+	}
+
+	return &domain.TokenTransfer{
+		Hash:            log.TxHash.Hex(),
+		ContractAddress: log.Address.Hex(),
+		From:            common.BytesToAddress(log.Topics[1].Bytes()).Hex(),
+		To:              common.BytesToAddress(log.Topics[2].Bytes()).Hex(),
+		Amount:          new(big.Int).SetBytes(log.Data).String(),
+		BlockNumber:     log.BlockNumber,
+		LogIndex:        log.Index,
+		Removed:         log.Removed,
+	}, true
+}
@@ -2,65 +2,109 @@ package services
 
 import (
 	"context"
-	"crypto/ecdsa"
+	"errors"
 	"fmt"
+	"log"
 	"math/big"
-	"string"
+	"sort"
+	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/ethereum/go-ethereum"
-	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/ethclient"
-	"github.com/ethereum/go-ethereum/params"
 	"github.com/littlecheny/go-backend/domain"
+	"github.com/littlecheny/go-backend/internal/hdwallet"
+	"github.com/littlecheny/go-backend/internal/units"
 	"github.com/tyler-smith/go-bip39"
 )
 
-type ethereumService struct {
-	client    *ethclient.Client
+// feeHistoryBlocks 估算EIP-1559小费时参考的区块数
+const feeHistoryBlocks = 10
+
+// ethClient ethereumService用到的节点接口，由*ethclient.Client和模拟链客户端实现
+type ethClient interface {
+	ethereum.ChainReader
+	ethereum.ChainStateReader
+	ethereum.ContractCaller
+	ethereum.GasEstimator
+	ethereum.GasPricer
+	ethereum.GasPricer1559
+	ethereum.FeeHistoryReader
+	ethereum.LogFilterer
+	ethereum.PendingStateReader
+	ethereum.TransactionReader
+	ethereum.TransactionSender
+}
+
+// ethConnection 一次连接的节点客户端，Connect和Disconnect整体替换，方法开始时取出使用，
+// 期间并发断开只会让后续调用返回节点错误
+type ethConnection struct {
+	client    ethClient
+	close     func()
 	networkID int64
 	rpcURL    string
 }
 
+type ethereumService struct {
+	conn atomic.Pointer[ethConnection]
+}
+
 func NewEthereumService() domain.EthereumService {
 	return &ethereumService{}
 }
 
-func (e *ethereumService) Connect(rpcURL string) error {
-	client, err := ethclient.Dial(rpcURL)
+func (e *ethereumService) Connect(ctx context.Context, rpcURL string) error {
+	client, err := ethclient.DialContext(ctx, rpcURL)
 	if err != nil {
 		return fmt.Errorf("failed to connect to Ethereum client: %v", err)
 	}
 
-	e.client = client
-	e.rpcURL = rpcURL
-
-	// 获取网络ID
-	networkID, err := client.NetworkID(context.Background())
+	// 获取网络ID，失败时不保留连接，IsConnected保持false
+	networkID, err := client.NetworkID(ctx)
 	if err != nil {
+		client.Close()
 		return fmt.Errorf("failed to get network ID: %v", err)
 	}
-	e.networkID = networkID.Int64()
+
+	previous := e.conn.Swap(&ethConnection{
+		client:    client,
+		close:     client.Close,
+		networkID: networkID.Int64(),
+		rpcURL:    rpcURL,
+	})
+	if previous != nil {
+		previous.close()
+	}
 
 	return nil
 }
 
 func (e *ethereumService) Disconnect() error {
-	if e.client != nil {
-		e.client.Close()
-		e.client = nil
+	conn := e.conn.Swap(nil)
+	if conn != nil {
+		conn.close()
 	}
 	return nil
 }
 
 func (e *ethereumService) IsConnected() bool {
-	return e.client != nil
+	return e.conn.Load() != nil
 }
 
-func (e *ethereumService) CreateAccount() (address, privateKey, mnemonic string, err error) {
+// connection 返回当前连接，未连接时返回错误
+func (e *ethereumService) connection() (*ethConnection, error) {
+	conn := e.conn.Load()
+	if conn == nil {
+		return nil, fmt.Errorf("ethereum client not connected")
+	}
+	return conn, nil
+}
+
+func (e *ethereumService) CreateAccount(passphrase string) (address, privateKey, mnemonic string, err error) {
 	// 生成助记词
 	entropy, err := bip39.NewEntropy(128)
 	if err != nil {
@@ -72,222 +116,469 @@ func (e *ethereumService) CreateAccount() (address, privateKey, mnemonic string,
 		return "", "", "", fmt.Errorf("failed to generate mnemonic: %v", err)
 	}
 
-	// 从助记词生成种子
-	seed := bip39.NewSeed(mnemonic, "")
+	// 按 m/44'/60'/0'/0/0 派生第一个账户，保证可由助记词恢复
+	address, privateKey, err = e.DeriveAccount(mnemonic, passphrase, 0)
+	if err != nil {
+		return "", "", "", err
+	}
+
+	return address, privateKey, mnemonic, nil
+}
 
-	// 生成私钥
-	privateKeyECDSA, err := crypto.GenerateKey()
+func (e *ethereumService) ImportAccount(mnemonic, passphrase string) (address, privateKey string, err error) {
+	return e.DeriveAccount(mnemonic, passphrase, 0)
+}
+
+// DeriveAccount 按BIP-44路径 m/44'/60'/0'/0/index 从助记词派生账户
+func (e *ethereumService) DeriveAccount(mnemonic, passphrase string, index uint32) (address, privateKey string, err error) {
+	address, privateKey, _, err = hdwallet.DeriveEthereumAccount(mnemonic, passphrase, index)
 	if err != nil {
-		return "", "", "", fmt.Errorf("failed to generate private key: %v", err)
+		return "", "", fmt.Errorf("failed to derive account: %w", err)
 	}
 
-	// 获取私钥的十六进制表示
-	privateKeyBytes := crypto.FromECDSA(privateKeyECDSA)
-	privateKey = fmt.Sprintf("%x", privateKeyBytes)
+	return address, privateKey, nil
+}
 
-	// 获取公钥和地址
-	publicKey := privateKeyECDSA.Public()
-	publicKeyECDSA, ok := publicKey.(*ecdsa.PublicKey)
-	if !ok {
-		return "", "", "", fmt.Errorf("failed to cast public key to ECDSA")
+func (e *ethereumService) GetBalance(ctx context.Context, address string) (string, error) {
+	conn, err := e.connection()
+	if err != nil {
+		return "", err
 	}
 
-	address = crypto.PubkeyToAddress(*publicKeyECDSA).Hex()
+	account := common.HexToAddress(address)
+	balance, err := conn.client.BalanceAt(ctx, account, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to get balance: %v", err)
+	}
 
-	return address, privateKey, mnemonic, nil
+	return units.FormatEther(balance), nil
 }
 
-func (e *ethereumService) ImportAccount(mnemonic string) (address, privateKey string, err error) {
-	// 验证助记词
-	if !bip39.IsMnemonicValid(mnemonic) {
-		return "", "", fmt.Errorf("invalid mnemonic")
+func (e *ethereumService) SendTransaction(ctx context.Context, from, to, privateKey, value string, fees *domain.TransactionFees) (*domain.SignedTransaction, error) {
+	conn, err := e.connection()
+	if err != nil {
+		return nil, err
 	}
 
-	// 从助记词生成种子
-	seed := bip39.NewSeed(mnemonic, "")
+	valueWei, err := units.ParseWei(value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse value: %w", err)
+	}
 
-	// 这里简化处理，实际应该使用HD钱包派生
-	// 为了演示，我们生成一个新的私钥
-	privateKeyECDSA, err := crypto.GenerateKey()
+	return conn.sendTransaction(ctx, from, common.HexToAddress(to), privateKey, valueWei, nil, fees)
+}
+
+// SendTokenTransfer 调用代币合约的transfer(to, amount)，amount为代币最小单位
+func (e *ethereumService) SendTokenTransfer(ctx context.Context, from, contractAddress, to, privateKey, amount string, fees *domain.TransactionFees) (*domain.SignedTransaction, error) {
+	conn, err := e.connection()
 	if err != nil {
-		return "", "", fmt.Errorf("failed to generate private key: %v", err)
+		return nil, err
 	}
 
-	privateKeyBytes := crypto.FromECDSA(privateKeyECDSA)
-	privateKey = fmt.Sprintf("%x", privateKeyBytes)
+	amountUnits, err := units.ParseWei(amount)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse amount: %w", err)
+	}
 
-	publicKey := privateKeyECDSA.Public()
-	publicKeyECDSA, ok := publicKey.(*ecdsa.PublicKey)
-	if !ok {
-		return "", "", fmt.Errorf("failed to cast public key to ECDSA")
+	data, err := erc20.Pack("transfer", common.HexToAddress(to), amountUnits)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pack transfer call: %v", err)
 	}
 
-	address = crypto.PubkeyToAddress(*publicKeyECDSA).Hex()
+	return conn.sendTransaction(ctx, from, common.HexToAddress(contractAddress), privateKey, big.NewInt(0), data, fees)
+}
 
-	return address, privateKey, nil
+// GetTokenBalance 通过balanceOf查询代币余额，返回代币最小单位
+func (e *ethereumService) GetTokenBalance(ctx context.Context, contractAddress, address string) (string, error) {
+	conn, err := e.connection()
+	if err != nil {
+		return "", err
+	}
+
+	data, err := erc20.Pack("balanceOf", common.HexToAddress(address))
+	if err != nil {
+		return "", fmt.Errorf("failed to pack balanceOf call: %v", err)
+	}
+
+	contract := common.HexToAddress(contractAddress)
+	output, err := conn.client.CallContract(ctx, ethereum.CallMsg{To: &contract, Data: data}, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to get token balance: %v", err)
+	}
+
+	values, err := erc20.Unpack("balanceOf", output)
+	if err != nil {
+		return "", fmt.Errorf("failed to unpack token balance: %v", err)
+	}
+
+	balance, ok := values[0].(*big.Int)
+	if !ok {
+		return "", fmt.Errorf("unexpected token balance type %T", values[0])
+	}
+	return balance.String(), nil
 }
 
-func (e *ethereumService) GetBalance(address string) (string, error) {
-	if e.client == nil {
-		return "", fmt.Errorf("ethereum client not connected")
+// GetLatestPrice 调用喂价合约的decimals和latestRoundData，报价不为正数时返回ErrPriceNotAvailable
+func (e *ethereumService) GetLatestPrice(ctx context.Context, aggregatorAddress string) (*domain.PriceRound, error) {
+	conn, err := e.connection()
+	if err != nil {
+		return nil, err
 	}
 
-	account := common.HexToAddress(address)
-	balance, err := e.client.BalanceAt(context.Background(), account, nil)
+	contract := common.HexToAddress(aggregatorAddress)
+	values, err := conn.callAggregator(ctx, contract, "decimals")
 	if err != nil {
-		return "", fmt.Errorf("failed to get balance: %v", err)
+		return nil, err
+	}
+	decimals, ok := values[0].(uint8)
+	if !ok {
+		return nil, fmt.Errorf("unexpected decimals type %T", values[0])
 	}
 
-	// 转换为ETH
-	ethBalance := new(big.Float)
-	ethBalance.SetString(balance.String())
-	ethBalance = ethBalance.Quo(ethBalance, big.NewFloat(params.Ether))
+	values, err = conn.callAggregator(ctx, contract, "latestRoundData")
+	if err != nil {
+		return nil, err
+	}
+	answer, ok := values[1].(*big.Int)
+	if !ok {
+		return nil, fmt.Errorf("unexpected answer type %T", values[1])
+	}
+	updatedAt, ok := values[3].(*big.Int)
+	if !ok {
+		return nil, fmt.Errorf("unexpected updatedAt type %T", values[3])
+	}
+	if answer.Sign() <= 0 {
+		return nil, fmt.Errorf("%w: aggregator %s answered %s", domain.ErrPriceNotAvailable, contract.Hex(), answer)
+	}
 
-	return ethBalance.String(), nil
+	return &domain.PriceRound{
+		Answer:    units.Format(answer, int(decimals)),
+		UpdatedAt: time.Unix(updatedAt.Int64(), 0),
+	}, nil
 }
 
-func (e *ethereumService) SendTransaction(from, to, privateKey, value string, gasPrice *string) (string, error) {
-	if e.client == nil {
-		return "", fmt.Errorf("ethereum client not connected")
+func (conn *ethConnection) callAggregator(ctx context.Context, contract common.Address, method string) ([]interface{}, error) {
+	data, err := aggregatorV3.Pack(method)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pack %s call: %v", method, err)
+	}
+
+	output, err := conn.client.CallContract(ctx, ethereum.CallMsg{To: &contract, Data: data}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call %s on aggregator %s: %v", method, contract.Hex(), err)
 	}
 
+	values, err := aggregatorV3.Unpack(method, output)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unpack %s: %v", method, err)
+	}
+	return values, nil
+}
+
+// sendTransaction 签名并广播交易，data不为空时为合约调用
+func (conn *ethConnection) sendTransaction(ctx context.Context, from string, toAddress common.Address, privateKey string, valueWei *big.Int, data []byte, fees *domain.TransactionFees) (*domain.SignedTransaction, error) {
 	// 解析私钥
 	privateKeyECDSA, err := crypto.HexToECDSA(privateKey)
 	if err != nil {
-		return "", fmt.Errorf("failed to parse private key: %v", err)
+		return nil, fmt.Errorf("failed to parse private key: %v", err)
 	}
 
 	// 获取发送方地址
 	fromAddress := crypto.PubkeyToAddress(privateKeyECDSA.PublicKey)
-
-	// 获取nonce
-	nonce, err := e.client.PendingNonceAt(context.Background(), fromAddress)
-	if err != nil {
-		return "", fmt.Errorf("failed to get nonce: %v", err)
+	if from != "" && common.HexToAddress(from) != fromAddress {
+		return nil, fmt.Errorf("private key does not match sender %s", from)
 	}
 
-	// 解析金额
-	valueWei, ok := new(big.Int).SetString(value, 10)
-	if !ok {
-		return "", fmt.Errorf("failed to parse value")
+	if fees == nil {
+		fees = &domain.TransactionFees{}
 	}
 
-	// 获取gas价格
-	var gasPriceWei *big.Int
-	if gasPrice != nil {
-		gasPriceWei, ok = new(big.Int).SetString(*gasPrice, 10)
-		if !ok {
-			return "", fmt.Errorf("failed to parse gas price")
-		}
+	// 获取nonce，指定了nonce时直接使用
+	var nonce uint64
+	if fees.Nonce != nil {
+		nonce = *fees.Nonce
 	} else {
-		gasPriceWei, err = e.client.SuggestGasPrice(context.Background())
+		nonce, err = conn.client.PendingNonceAt(ctx, fromAddress)
 		if err != nil {
-			return "", fmt.Errorf("failed to suggest gas price: %v", err)
+			return nil, fmt.Errorf("failed to get nonce: %v", err)
 		}
 	}
 
 	// 估算gas限制
-	toAddress := common.HexToAddress(to)
-	gasLimit, err := e.client.EstimateGas(context.Background(), ethereum.CallMsg{
+	gasLimit, err := conn.client.EstimateGas(ctx, ethereum.CallMsg{
 		From:  fromAddress,
 		To:    &toAddress,
 		Value: valueWei,
+		Data:  data,
 	})
 	if err != nil {
+		// 合约调用估算失败通常意味着执行会revert，不能使用默认值
+		if len(data) > 0 {
+			return nil, fmt.Errorf("failed to estimate gas: %v", err)
+		}
 		gasLimit = 21000 // 默认gas限制
 	}
 
-	// 创建交易
-	tx := types.NewTransaction(nonce, toAddress, valueWei, gasLimit, gasPriceWei, nil)
+	// 最新区块带baseFee说明网络已启用London，发送EIP-1559交易
+	header, err := conn.client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest block: %v", err)
+	}
+
+	chainID := big.NewInt(conn.networkID)
+	var tx *types.Transaction
+	signed := &domain.SignedTransaction{
+		From:     fromAddress.Hex(),
+		Nonce:    nonce,
+		GasLimit: gasLimit,
+	}
+
+	if header.BaseFee != nil {
+		maxFee, tipCap, err := conn.dynamicFees(ctx, header, fees)
+		if err != nil {
+			return nil, err
+		}
+
+		tx = types.NewTx(&types.DynamicFeeTx{
+			ChainID:   chainID,
+			Nonce:     nonce,
+			GasTipCap: tipCap,
+			GasFeeCap: maxFee,
+			Gas:       gasLimit,
+			To:        &toAddress,
+			Value:     valueWei,
+			Data:      data,
+		})
+		signed.FeeModel = domain.FeeModelEIP1559
+		signed.GasPrice = maxFee.String()
+		signed.MaxFeePerGas = maxFee.String()
+		signed.MaxPriorityFeePerGas = tipCap.String()
+	} else {
+		if fees.MaxFeePerGas != "" || fees.MaxPriorityFeePerGas != "" {
+			return nil, fmt.Errorf("network does not support EIP-1559 fees")
+		}
+
+		gasPriceWei, err := conn.legacyGasPrice(ctx, fees.GasPrice)
+		if err != nil {
+			return nil, err
+		}
 
-	// 签名交易
-	chainID := big.NewInt(e.networkID)
-	signedTx, err := types.SignTx(tx, types.NewEIP155Signer(chainID), privateKeyECDSA)
+		tx = types.NewTransaction(nonce, toAddress, valueWei, gasLimit, gasPriceWei, data)
+		signed.FeeModel = domain.FeeModelLegacy
+		signed.GasPrice = gasPriceWei.String()
+	}
+
+	// 签名交易，LatestSignerForChainID同时支持EIP-155和EIP-1559交易
+	signedTx, err := types.SignTx(tx, types.LatestSignerForChainID(chainID), privateKeyECDSA)
 	if err != nil {
-		return "", fmt.Errorf("failed to sign transaction: %v", err)
+		return nil, fmt.Errorf("failed to sign transaction: %v", err)
 	}
 
 	// 发送交易
-	err = e.client.SendTransaction(context.Background(), signedTx)
+	err = conn.client.SendTransaction(ctx, signedTx)
 	if err != nil {
-		return "", fmt.Errorf("failed to send transaction: %v", err)
+		return nil, fmt.Errorf("failed to send transaction: %v", err)
 	}
 
-	return signedTx.Hash().Hex(), nil
+	signed.Hash = signedTx.Hash().Hex()
+	return signed, nil
 }
 
-func (e *ethereumService) GetTransaction(hash string) (*domain.TransactionResponse, error) {
-	if e.client == nil {
-		return nil, fmt.Errorf("ethereum client not connected")
+// dynamicFees 计算EIP-1559费用，未指定的字段由eth_feeHistory估算：
+// maxPriorityFeePerGas取近期区块小费的中位数，maxFeePerGas为下一区块baseFee的两倍加小费
+func (conn *ethConnection) dynamicFees(ctx context.Context, header *types.Header, fees *domain.TransactionFees) (maxFee, tipCap *big.Int, err error) {
+	maxFee, err = parseWei(fees.MaxFeePerGas, "max fee per gas")
+	if err != nil {
+		return nil, nil, err
+	}
+	tipCap, err = parseWei(fees.MaxPriorityFeePerGas, "max priority fee per gas")
+	if err != nil {
+		return nil, nil, err
 	}
 
-	txHash := common.HexToHash(hash)
-	tx, isPending, err := e.client.TransactionByHash(context.Background(), txHash)
+	// 只给出gasPrice时按legacy的出价方式处理
+	if maxFee == nil && tipCap == nil && fees.GasPrice != "" {
+		gasPrice, err := parseWei(fees.GasPrice, "gas price")
+		if err != nil {
+			return nil, nil, err
+		}
+		return gasPrice, new(big.Int).Set(gasPrice), nil
+	}
+
+	if tipCap == nil || maxFee == nil {
+		baseFee, suggestedTip, err := conn.feeHistory(ctx, header)
+		if err != nil {
+			return nil, nil, err
+		}
+		if tipCap == nil {
+			tipCap = suggestedTip
+		}
+		if maxFee == nil {
+			maxFee = new(big.Int).Add(new(big.Int).Mul(baseFee, big.NewInt(2)), tipCap)
+		}
+	}
+
+	if maxFee.Cmp(tipCap) < 0 {
+		return nil, nil, fmt.Errorf("max fee per gas %s is lower than max priority fee per gas %s", maxFee, tipCap)
+	}
+
+	return maxFee, tipCap, nil
+}
+
+// feeHistory 返回下一区块的baseFee和近期区块小费的中位数
+func (conn *ethConnection) feeHistory(ctx context.Context, header *types.Header) (baseFee, tipCap *big.Int, err error) {
+	history, err := conn.client.FeeHistory(ctx, feeHistoryBlocks, nil, []float64{50})
 	if err != nil {
-		return nil, fmt.Errorf("failed to get transaction: %v", err)
+		return nil, nil, fmt.Errorf("failed to get fee history: %v", err)
 	}
 
-	var status domain.TransactionStatus
-	var blockNumber uint64
-	var gasUsed uint64
+	baseFee = header.BaseFee
+	if len(history.BaseFee) > 0 {
+		baseFee = history.BaseFee[len(history.BaseFee)-1]
+	}
 
-	if isPending {
-		status = domain.TransactionStatusPending
-	} else {
-		// 获取交易收据
-		receipt, err := e.client.TransactionReceipt(context.Background(), txHash)
+	// 空区块的小费为0，计入会压低中位数，导致交易因小费过低被拒绝
+	var tips []*big.Int
+	for i, reward := range history.Reward {
+		if i < len(history.GasUsedRatio) && history.GasUsedRatio[i] == 0 {
+			continue
+		}
+		if len(reward) > 0 && reward[0] != nil {
+			tips = append(tips, reward[0])
+		}
+	}
+	if len(tips) == 0 {
+		tipCap, err = conn.client.SuggestGasTipCap(ctx)
 		if err != nil {
-			return nil, fmt.Errorf("failed to get transaction receipt: %v", err)
+			return nil, nil, fmt.Errorf("failed to suggest gas tip cap: %v", err)
 		}
+		return baseFee, tipCap, nil
+	}
+
+	sort.Slice(tips, func(i, j int) bool { return tips[i].Cmp(tips[j]) < 0 })
+	return baseFee, new(big.Int).Set(tips[len(tips)/2]), nil
+}
 
-		if receipt.Status == 1 {
-			status = domain.TransactionStatusConfirmed
-		} else {
-			status = domain.TransactionStatusFailed
+func (conn *ethConnection) legacyGasPrice(ctx context.Context, gasPrice string) (*big.Int, error) {
+	gasPriceWei, err := parseWei(gasPrice, "gas price")
+	if err != nil {
+		return nil, err
+	}
+	if gasPriceWei != nil {
+		return gasPriceWei, nil
+	}
+
+	gasPriceWei, err = conn.client.SuggestGasPrice(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to suggest gas price: %v", err)
+	}
+	return gasPriceWei, nil
+}
+
+// isIndexingInProgress geth在交易索引未完成时不返回NotFound，而是返回这个错误，
+// 查询结果同样是暂时没有收据
+func isIndexingInProgress(err error) bool {
+	return err != nil && strings.Contains(err.Error(), "transaction indexing is in progress")
+}
+
+// parseWei 解析wei整数，空字符串返回nil
+func parseWei(value string, name string) (*big.Int, error) {
+	if value == "" {
+		return nil, nil
+	}
+	wei, err := units.ParseWei(value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", name, err)
+	}
+	return wei, nil
+}
+
+// GetTransaction 交易池中的交易返回pending状态，已上链的交易附带收据中的状态、费用和日志；
+// 节点明确查不到时返回ErrTransactionNotFound
+func (e *ethereumService) GetTransaction(ctx context.Context, hash string) (*domain.TransactionResponse, error) {
+	conn, err := e.connection()
+	if err != nil {
+		return nil, err
+	}
+
+	txHash := common.HexToHash(hash)
+	tx, isPending, err := conn.client.TransactionByHash(ctx, txHash)
+	if errors.Is(err, ethereum.NotFound) {
+		return nil, domain.ErrTransactionNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transaction: %v", err)
+	}
+
+	if isPending {
+		return decodeTransaction(tx, nil, time.Now())
+	}
+
+	receipt, err := conn.client.TransactionReceipt(ctx, txHash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transaction receipt: %v", err)
+	}
+
+	header, err := conn.client.HeaderByHash(ctx, receipt.BlockHash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get block header: %v", err)
+	}
+
+	return decodeTransaction(tx, receipt, time.Unix(int64(header.Time), 0))
+}
+
+// GetTransactionReceipt 获取已上链交易的收据，未上链时返回ErrReceiptNotFound
+func (e *ethereumService) GetTransactionReceipt(ctx context.Context, hash string) (*domain.TransactionReceipt, error) {
+	conn, err := e.connection()
+	if err != nil {
+		return nil, err
+	}
+
+	txHash := common.HexToHash(hash)
+	receipt, err := conn.client.TransactionReceipt(ctx, txHash)
+	if errors.Is(err, ethereum.NotFound) || isIndexingInProgress(err) {
+		return nil, domain.ErrReceiptNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transaction receipt: %v", err)
+	}
+
+	// 部分节点的收据不返回effectiveGasPrice，此时使用交易本身的gasPrice
+	effectiveGasPrice := receipt.EffectiveGasPrice
+	if effectiveGasPrice == nil {
+		tx, _, err := conn.client.TransactionByHash(ctx, txHash)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get transaction: %v", err)
 		}
+		effectiveGasPrice = tx.GasPrice()
+	}
 
-		blockNumber = receipt.BlockNumber.Uint64()
-		gasUsed = receipt.GasUsed
-	}
-
-	// 转换金额为ETH
-	ethValue := new(big.Float)
-	ethValue.SetString(tx.Value().String())
-	ethValue = ethValue.Quo(ethValue, big.NewFloat(params.Ether))
-
-	// 转换gas价格为Gwei
-	gweiGasPrice := new(big.Float)
-	gweiGasPrice.SetString(tx.GasPrice().String())
-	gweiGasPrice = gweiGasPrice.Quo(gweiGasPrice, big.NewFloat(params.GWei))
-
-	return &domain.TransactionResponse{
-		Hash:        tx.Hash().Hex(),
-		From:        tx.To().Hex(), // 注意：这里需要从交易中获取正确的from地址
-		To:          tx.To().Hex(),
-		Value:       ethValue.String(),
-		GasPrice:    gweiGasPrice.String(),
-		GasLimit:    tx.Gas(),
-		GasUsed:     gasUsed,
-		Status:      status,
-		BlockNumber: blockNumber,
-		CreatedAt:   time.Now(),
+	return &domain.TransactionReceipt{
+		Hash:              receipt.TxHash.Hex(),
+		Status:            receiptStatus(receipt),
+		BlockNumber:       receipt.BlockNumber.Uint64(),
+		BlockHash:         receipt.BlockHash.Hex(),
+		GasUsed:           receipt.GasUsed,
+		EffectiveGasPrice: effectiveGasPrice.String(),
+		Logs:              decodeLogs(receipt.Logs),
 	}, nil
 }
 
-func (e *ethereumService) EstimateGas(from, to, value string) (uint64, error) {
-	if e.client == nil {
-		return 0, fmt.Errorf("ethereum client not connected")
+func (e *ethereumService) EstimateGas(ctx context.Context, from, to, value string) (uint64, error) {
+	conn, err := e.connection()
+	if err != nil {
+		return 0, err
 	}
 
 	fromAddress := common.HexToAddress(from)
 	toAddress := common.HexToAddress(to)
 
-	valueWei, ok := new(big.Int).SetString(value, 10)
-	if !ok {
-		return 0, fmt.Errorf("failed to parse value")
+	valueWei, err := units.ParseWei(value)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse value: %w", err)
 	}
 
-	gasLimit, err := e.client.EstimateGas(context.Background(), ethereum.CallMsg{
+	gasLimit, err := conn.client.EstimateGas(ctx, ethereum.CallMsg{
 		From:  fromAddress,
 		To:    &toAddress,
 		Value: valueWei,
@@ -299,31 +590,28 @@ func (e *ethereumService) EstimateGas(from, to, value string) (uint64, error) {
 	return gasLimit, nil
 }
 
-func (e *ethereumService) GetGasPrice() (string, error) {
-	if e.client == nil {
-		return "", fmt.Errorf("ethereum client not connected")
+func (e *ethereumService) GetGasPrice(ctx context.Context) (string, error) {
+	conn, err := e.connection()
+	if err != nil {
+		return "", err
 	}
 
-	gasPrice, err := e.client.SuggestGasPrice(context.Background())
+	gasPrice, err := conn.client.SuggestGasPrice(ctx)
 	if err != nil {
 		return "", fmt.Errorf("failed to get gas price: %v", err)
 	}
 
-	// 转换为Gwei
-	gweiGasPrice := new(big.Float)
-	gweiGasPrice.SetString(gasPrice.String())
-	gweiGasPrice = gweiGasPrice.Quo(gweiGasPrice, big.NewFloat(params.GWei))
-
-	return gweiGasPrice.String(), nil
+	return units.FormatGwei(gasPrice), nil
 }
 
-func (e *ethereumService) GetNonce(address string) (uint64, error) {
-	if e.client == nil {
-		return 0, fmt.Errorf("ethereum client not connected")
+func (e *ethereumService) GetNonce(ctx context.Context, address string) (uint64, error) {
+	conn, err := e.connection()
+	if err != nil {
+		return 0, err
 	}
 
 	account := common.HexToAddress(address)
-	nonce, err := e.client.PendingNonceAt(context.Background(), account)
+	nonce, err := conn.client.PendingNonceAt(ctx, account)
 	if err != nil {
 		return 0, fmt.Errorf("failed to get nonce: %v", err)
 	}
@@ -331,17 +619,18 @@ func (e *ethereumService) GetNonce(address string) (uint64, error) {
 	return nonce, nil
 }
 
-func (e *ethereumService) GetLatestBlock() (*domain.BlockInfo, error) {
-	if e.client == nil {
-		return nil, fmt.Errorf("ethereum client not connected")
+func (e *ethereumService) GetLatestBlock(ctx context.Context) (*domain.BlockInfo, error) {
+	conn, err := e.connection()
+	if err != nil {
+		return nil, err
 	}
 
-	header, err := e.client.HeaderByNumber(context.Background(), nil)
+	header, err := conn.client.HeaderByNumber(ctx, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get latest block: %v", err)
 	}
 
-	block, err := e.client.BlockByNumber(context.Background(), header.Number)
+	block, err := conn.client.BlockByNumber(ctx, header.Number)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get block details: %v", err)
 	}
@@ -357,12 +646,16 @@ func (e *ethereumService) GetLatestBlock() (*domain.BlockInfo, error) {
 	}, nil
 }
 
-func (e *ethereumService) GetBlockByNumber(number uint64) (*domain.BlockInfo, error) {
-	if e.client == nil {
-		return nil, fmt.Errorf("ethereum client not connected")
+func (e *ethereumService) GetBlockByNumber(ctx context.Context, number uint64) (*domain.BlockInfo, error) {
+	conn, err := e.connection()
+	if err != nil {
+		return nil, err
 	}
 
-	block, err := e.client.BlockByNumber(context.Background(), big.NewInt(int64(number)))
+	block, err := conn.client.BlockByNumber(ctx, new(big.Int).SetUint64(number))
+	if errors.Is(err, ethereum.NotFound) {
+		return nil, domain.ErrBlockNotFound
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to get block: %v", err)
 	}
@@ -379,20 +672,22 @@ func (e *ethereumService) GetBlockByNumber(number uint64) (*domain.BlockInfo, er
 }
 
 func (e *ethereumService) GetNetworkID() (int64, error) {
-	if e.client == nil {
-		return 0, fmt.Errorf("ethereum client not connected")
+	conn, err := e.connection()
+	if err != nil {
+		return 0, err
 	}
 
-	return e.networkID, nil
+	return conn.networkID, nil
 }
 
 func (e *ethereumService) SubscribeNewHeads(ctx context.Context) (<-chan *domain.BlockInfo, error) {
-	if e.client == nil {
-		return nil, fmt.Errorf("ethereum client not connected")
+	conn, err := e.connection()
+	if err != nil {
+		return nil, err
 	}
 
 	headers := make(chan *types.Header)
-	sub, err := e.client.SubscribeNewHead(ctx, headers)
+	sub, err := conn.client.SubscribeNewHead(ctx, headers)
 	if err != nil {
 		return nil, fmt.Errorf("failed to subscribe to new heads: %v", err)
 	}
@@ -406,12 +701,12 @@ func (e *ethereumService) SubscribeNewHeads(ctx context.Context) (<-chan *domain
 		for {
 			select {
 			case err := <-sub.Err():
-				fmt.Printf("Subscription error: %v\n", err)
+				log.Printf("subscription error: %v", err)
 				return
 			case header := <-headers:
-				block, err := e.client.BlockByHash(ctx, header.Hash())
+				block, err := conn.client.BlockByHash(ctx, header.Hash())
 				if err != nil {
-					fmt.Printf("Failed to get block: %v\n", err)
+					log.Printf("failed to get block %d: %v", header.Number.Uint64(), err)
 					continue
 				}
 
@@ -439,16 +734,95 @@ func (e *ethereumService) SubscribeNewHeads(ctx context.Context) (<-chan *domain
 	return blockInfoChan, nil
 }
 
-func (e *ethereumService) WatchTransactions(ctx context.Context, addresses []string) (<-chan *domain.TransactionResponse, error) {
-	// 这是一个简化的实现，实际应用中需要更复杂的逻辑
-	txChan := make(chan *domain.TransactionResponse)
+// ScanTransactions 扫描区块中from或to属于addresses的交易；from匹配的交易Type为send，否则为receive。
+// 无法恢复发送方的交易跳过并记录日志
+func (e *ethereumService) ScanTransactions(ctx context.Context, number uint64, addresses []string) ([]domain.TransactionResponse, error) {
+	conn, err := e.connection()
+	if err != nil {
+		return nil, err
+	}
 
-	go func() {
-		defer close(txChan)
-		// 这里应该实现监听指定地址的交易逻辑
-		// 由于复杂性，这里只是一个占位符
-		<-ctx.Done()
-	}()
+	watched := make(map[common.Address]bool, len(addresses))
+	for _, address := range addresses {
+		watched[common.HexToAddress(address)] = true
+	}
+
+	block, err := conn.client.BlockByNumber(ctx, new(big.Int).SetUint64(number))
+	if errors.Is(err, ethereum.NotFound) {
+		return nil, domain.ErrBlockNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get block: %v", err)
+	}
+
+	var transactions []domain.TransactionResponse
+	for _, tx := range block.Transactions() {
+		from, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+		if err != nil {
+			log.Printf("failed to recover sender of %s: %v", tx.Hash().Hex(), err)
+			continue
+		}
+
+		var txType domain.TransactionType
+		switch {
+		case watched[from]:
+			txType = domain.TransactionTypeSend
+		case tx.To() != nil && watched[*tx.To()]:
+			txType = domain.TransactionTypeReceive
+		default:
+			continue
+		}
+
+		receipt, err := conn.client.TransactionReceipt(ctx, tx.Hash())
+		if err != nil {
+			return nil, fmt.Errorf("failed to get transaction receipt: %v", err)
+		}
+
+		response, err := decodeTransaction(tx, receipt, time.Unix(int64(block.Time()), 0))
+		if err != nil {
+			return nil, err
+		}
+		response.Type = txType
+		transactions = append(transactions, *response)
+	}
+	return transactions, nil
+}
+
+// GetTokenTransfers 查询[fromBlock, toBlock]区间内contractAddresses中转入addresses的Transfer事件
+func (e *ethereumService) GetTokenTransfers(ctx context.Context, fromBlock, toBlock uint64, contractAddresses []string, addresses []string) ([]domain.TokenTransfer, error) {
+	conn, err := e.connection()
+	if err != nil {
+		return nil, err
+	}
+
+	// 过滤条件为空时节点会返回所有合约的日志
+	if len(contractAddresses) == 0 || len(addresses) == 0 {
+		return nil, nil
+	}
+
+	query := ethereum.FilterQuery{
+		FromBlock: new(big.Int).SetUint64(fromBlock),
+		ToBlock:   new(big.Int).SetUint64(toBlock),
+		Topics:    [][]common.Hash{{transferEventTopic}, nil, {}},
+	}
+	for _, contractAddress := range contractAddresses {
+		query.Addresses = append(query.Addresses, common.HexToAddress(contractAddress))
+	}
+	for _, address := range addresses {
+		query.Topics[2] = append(query.Topics[2], common.BytesToHash(common.HexToAddress(address).Bytes()))
+	}
+
+	logs, err := conn.client.FilterLogs(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to filter transfer logs: %v", err)
+	}
 
-	return txChan, nil
-}
\ No newline at end of file
+	var transfers []domain.TokenTransfer
+	for _, log := range logs {
+		transfer, ok := parseTransferLog(log)
+		if ok {
+			transfers = append(transfers, *transfer)
+		}
+	}
+	return transfers, nil
+}
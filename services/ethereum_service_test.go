@@ -0,0 +1,317 @@
+package services_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient/simulated"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/littlecheny/go-backend/domain"
+	"github.com/littlecheny/go-backend/internal/units"
+	"github.com/littlecheny/go-backend/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const recipient = "0x2222222222222222222222222222222222222222"
+
+// newSimulatedService 创建预置100 ETH账户的模拟链，需要手动Commit出块
+func newSimulatedService(t *testing.T) (domain.EthereumService, *simulated.Backend, *ecdsa.PrivateKey) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	balance := new(big.Int).Mul(big.NewInt(100), big.NewInt(params.Ether))
+	alloc := types.GenesisAlloc{crypto.PubkeyToAddress(key.PublicKey): {Balance: balance}}
+
+	service, backend, err := services.NewSimulatedEthereumService(alloc, 0)
+	require.NoError(t, err)
+	t.Cleanup(func() { service.Disconnect() })
+
+	return service, backend, key
+}
+
+func privateKeyHex(key *ecdsa.PrivateKey) string {
+	return hex.EncodeToString(crypto.FromECDSA(key))
+}
+
+func TestSimulatedSendTransaction(t *testing.T) {
+	ctx := context.Background()
+	service, backend, key := newSimulatedService(t)
+	from := crypto.PubkeyToAddress(key.PublicKey).Hex()
+
+	networkID, err := service.GetNetworkID()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(services.SimulatedChainID), networkID)
+
+	gas, err := service.EstimateGas(ctx, from, recipient, "1000000000000000000")
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(21000), gas)
+
+	signed, err := service.SendTransaction(ctx, from, recipient, privateKeyHex(key), "1500000000000000000", nil)
+	require.NoError(t, err)
+	assert.Equal(t, from, signed.From)
+	assert.Equal(t, uint64(0), signed.Nonce)
+	assert.Equal(t, uint64(21000), signed.GasLimit)
+	assert.Equal(t, domain.FeeModelEIP1559, signed.FeeModel)
+
+	// 出块之前没有收据
+	_, err = service.GetTransactionReceipt(ctx, signed.Hash)
+	assert.ErrorIs(t, err, domain.ErrReceiptNotFound)
+
+	nonce, err := service.GetNonce(ctx, from)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1), nonce)
+
+	blockHash := backend.Commit()
+
+	receipt, err := service.GetTransactionReceipt(ctx, signed.Hash)
+	require.NoError(t, err)
+	assert.Equal(t, domain.TransactionStatusConfirmed, receipt.Status)
+	assert.Equal(t, uint64(1), receipt.BlockNumber)
+	assert.Equal(t, blockHash.Hex(), receipt.BlockHash)
+	assert.Equal(t, uint64(21000), receipt.GasUsed)
+	assert.NotEmpty(t, receipt.EffectiveGasPrice)
+
+	balance, err := service.GetBalance(ctx, recipient)
+	assert.NoError(t, err)
+	assert.Equal(t, "1.5", balance)
+
+	tx, err := service.GetTransaction(ctx, signed.Hash)
+	require.NoError(t, err)
+	assert.Equal(t, domain.TransactionStatusConfirmed, tx.Status)
+	assert.Equal(t, uint64(1), tx.BlockNumber)
+	assert.Equal(t, from, tx.From)
+	assert.Equal(t, recipient, tx.To)
+	assert.Equal(t, "1.5", tx.Value)
+	assert.NotEmpty(t, tx.TransactionFee)
+}
+
+func TestSimulatedSendTransactionWrongSender(t *testing.T) {
+	ctx := context.Background()
+	service, _, key := newSimulatedService(t)
+
+	_, err := service.SendTransaction(ctx, recipient, recipient, privateKeyHex(key), "1", nil)
+	assert.Error(t, err)
+}
+
+func TestSimulatedExactUnits(t *testing.T) {
+	ctx := context.Background()
+	service, backend, key := newSimulatedService(t)
+	from := crypto.PubkeyToAddress(key.PublicKey).Hex()
+
+	// big.Float格式化会得到1.2e-05
+	_, err := service.SendTransaction(ctx, from, recipient, privateKeyHex(key), "12000000000000", nil)
+	require.NoError(t, err)
+	backend.Commit()
+
+	balance, err := service.GetBalance(ctx, recipient)
+	assert.NoError(t, err)
+	assert.Equal(t, "0.000012", balance)
+
+	gasPrice, err := service.GetGasPrice(ctx)
+	assert.NoError(t, err)
+	assert.NotContains(t, gasPrice, "e")
+
+	for _, value := range []string{"-1", "1.5", "0x10"} {
+		_, err = service.SendTransaction(ctx, from, recipient, privateKeyHex(key), value, nil)
+		assert.ErrorIs(t, err, units.ErrInvalidAmount, value)
+	}
+}
+
+func TestSimulatedBlocks(t *testing.T) {
+	ctx := context.Background()
+	service, backend, _ := newSimulatedService(t)
+
+	backend.Commit()
+	second := backend.Commit()
+
+	latest, err := service.GetLatestBlock(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), latest.Number)
+	assert.Equal(t, second.Hex(), latest.Hash)
+
+	parent, err := service.GetBlockByNumber(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, parent.Hash, latest.ParentHash)
+	assert.Equal(t, 0, parent.Transactions)
+
+	_, err = service.GetBlockByNumber(ctx, 100)
+	assert.ErrorIs(t, err, domain.ErrBlockNotFound)
+}
+
+func TestSimulatedReplaceTransaction(t *testing.T) {
+	ctx := context.Background()
+	service, backend, key := newSimulatedService(t)
+	from := crypto.PubkeyToAddress(key.PublicKey).Hex()
+	nonce := uint64(0)
+
+	stuck, err := service.SendTransaction(ctx, from, recipient, privateKeyHex(key), "1", &domain.TransactionFees{
+		MaxFeePerGas:         "2000000000",
+		MaxPriorityFeePerGas: "1000000000",
+		Nonce:                &nonce,
+	})
+	require.NoError(t, err)
+
+	// 相同nonce、费用提高超过10%才能替换
+	replacement, err := service.SendTransaction(ctx, from, from, privateKeyHex(key), "0", &domain.TransactionFees{
+		MaxFeePerGas:         "4000000000",
+		MaxPriorityFeePerGas: "2000000000",
+		Nonce:                &nonce,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, nonce, replacement.Nonce)
+	assert.NotEqual(t, stuck.Hash, replacement.Hash)
+
+	backend.Commit()
+
+	_, err = service.GetTransactionReceipt(ctx, stuck.Hash)
+	assert.ErrorIs(t, err, domain.ErrReceiptNotFound)
+
+	receipt, err := service.GetTransactionReceipt(ctx, replacement.Hash)
+	require.NoError(t, err)
+	assert.Equal(t, domain.TransactionStatusConfirmed, receipt.Status)
+}
+
+func TestSimulatedBlockTransactions(t *testing.T) {
+	ctx := context.Background()
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	from := crypto.PubkeyToAddress(key.PublicKey)
+
+	// 合约代码: LOG1(offset=0, size=0, topic=42)
+	emitter := common.HexToAddress("0x3333333333333333333333333333333333333333")
+	alloc := types.GenesisAlloc{
+		from:    {Balance: new(big.Int).Mul(big.NewInt(100), big.NewInt(params.Ether))},
+		emitter: {Code: common.FromHex("602a60006000a100")},
+	}
+
+	service, backend, err := services.NewSimulatedEthereumService(alloc, 0)
+	require.NoError(t, err)
+	t.Cleanup(func() { service.Disconnect() })
+
+	signed, err := service.SendTransaction(ctx, from.Hex(), emitter.Hex(), privateKeyHex(key), "0", nil)
+	require.NoError(t, err)
+
+	pending, err := service.GetTransaction(ctx, signed.Hash)
+	require.NoError(t, err)
+	assert.Equal(t, domain.TransactionStatusPending, pending.Status)
+	assert.Equal(t, from.Hex(), pending.From)
+
+	backend.Commit()
+
+	transactions, err := service.GetBlockTransactions(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, transactions, 1)
+	assert.Equal(t, signed.Hash, transactions[0].Hash)
+	assert.Equal(t, from.Hex(), transactions[0].From)
+	assert.Equal(t, emitter.Hex(), transactions[0].To)
+	assert.Equal(t, domain.FeeModelEIP1559, transactions[0].FeeModel)
+	require.Len(t, transactions[0].Logs, 1)
+	assert.Equal(t, emitter.Hex(), transactions[0].Logs[0].Address)
+	assert.Equal(t, []string{common.BigToHash(big.NewInt(42)).Hex()}, transactions[0].Logs[0].Topics)
+	assert.Equal(t, "0x", transactions[0].Logs[0].Data)
+
+	receipt, err := service.GetTransactionReceipt(ctx, signed.Hash)
+	require.NoError(t, err)
+	assert.Equal(t, transactions[0].Logs, receipt.Logs)
+
+	_, err = service.GetBlockTransactions(ctx, 100)
+	assert.ErrorIs(t, err, domain.ErrBlockNotFound)
+}
+
+func TestSimulatedScanTransactions(t *testing.T) {
+	ctx := context.Background()
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	from := crypto.PubkeyToAddress(key.PublicKey)
+
+	// 合约代码: MSTORE(0, 5); LOG3(0, 32, Transfer, from, recipient)，模拟代币转入recipient
+	token := common.HexToAddress("0x4444444444444444444444444444444444444444")
+	transferTopic := crypto.Keccak256Hash([]byte("Transfer(address,address,uint256)"))
+	code := "6005600052" +
+		"7f" + common.Bytes2Hex(common.LeftPadBytes(common.HexToAddress(recipient).Bytes(), 32)) +
+		"7f" + common.Bytes2Hex(common.LeftPadBytes(from.Bytes(), 32)) +
+		"7f" + common.Bytes2Hex(transferTopic.Bytes()) +
+		"60206000a300"
+	alloc := types.GenesisAlloc{
+		from:  {Balance: new(big.Int).Mul(big.NewInt(100), big.NewInt(params.Ether))},
+		token: {Code: common.FromHex(code)},
+	}
+
+	service, backend, err := services.NewSimulatedEthereumService(alloc, 0)
+	require.NoError(t, err)
+	t.Cleanup(func() { service.Disconnect() })
+
+	sent, err := service.SendTransaction(ctx, from.Hex(), recipient, privateKeyHex(key), "1000000000000000000", nil)
+	require.NoError(t, err)
+	backend.Commit()
+
+	_, err = service.SendTransaction(ctx, from.Hex(), token.Hex(), privateKeyHex(key), "0", nil)
+	require.NoError(t, err)
+	backend.Commit()
+
+	received, err := service.ScanTransactions(ctx, 1, []string{recipient})
+	require.NoError(t, err)
+	require.Len(t, received, 1)
+	assert.Equal(t, sent.Hash, received[0].Hash)
+	assert.Equal(t, domain.TransactionTypeReceive, received[0].Type)
+	assert.Equal(t, "1", received[0].Value)
+
+	sentByWatched, err := service.ScanTransactions(ctx, 1, []string{from.Hex()})
+	require.NoError(t, err)
+	require.Len(t, sentByWatched, 1)
+	assert.Equal(t, domain.TransactionTypeSend, sentByWatched[0].Type)
+
+	unrelated, err := service.ScanTransactions(ctx, 1, []string{"0x5555555555555555555555555555555555555555"})
+	require.NoError(t, err)
+	assert.Empty(t, unrelated)
+
+	_, err = service.ScanTransactions(ctx, 100, []string{recipient})
+	assert.ErrorIs(t, err, domain.ErrBlockNotFound)
+
+	transfers, err := service.GetTokenTransfers(ctx, 1, 2, []string{token.Hex()}, []string{recipient})
+	require.NoError(t, err)
+	require.Len(t, transfers, 1)
+	assert.Equal(t, token.Hex(), transfers[0].ContractAddress)
+	assert.Equal(t, from.Hex(), transfers[0].From)
+	assert.Equal(t, common.HexToAddress(recipient).Hex(), transfers[0].To)
+	assert.Equal(t, "5", transfers[0].Amount)
+	assert.Equal(t, uint64(2), transfers[0].BlockNumber)
+
+	transfers, err = service.GetTokenTransfers(ctx, 1, 1, []string{token.Hex()}, []string{recipient})
+	require.NoError(t, err)
+	assert.Empty(t, transfers)
+}
+
+func TestSimulatedConcurrentDisconnect(t *testing.T) {
+	ctx := context.Background()
+	service, _, key := newSimulatedService(t)
+	from := crypto.PubkeyToAddress(key.PublicKey).Hex()
+
+	// 断开与正在进行的调用并发时，调用要么成功要么返回错误，不会访问已置空的客户端
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 50; i++ {
+			if !service.IsConnected() {
+				_, err := service.GetBalance(ctx, from)
+				assert.Error(t, err)
+				return
+			}
+			service.GetBalance(ctx, from)
+		}
+	}()
+
+	service.Disconnect()
+	<-done
+
+	assert.False(t, service.IsConnected())
+	_, err := service.GetLatestBlock(ctx)
+	assert.Error(t, err)
+}
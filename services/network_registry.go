@@ -0,0 +1,149 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/littlecheny/go-backend/domain"
+)
+
+const (
+	// reconnectInterval 断开的网络两次重连之间的最小间隔
+	reconnectInterval = 10 * time.Second
+	// connectTimeout 单次连接(含链ID校验)的超时时间
+	connectTimeout = 10 * time.Second
+)
+
+// networkClient mu只保护重连状态，连接本身在锁外进行
+type networkClient struct {
+	config  domain.NetworkConfig
+	service domain.EthereumService
+
+	mu          sync.Mutex
+	connecting  bool
+	lastAttempt time.Time
+}
+
+// networkRegistry clients在创建后只读，查询不需要加锁
+type networkRegistry struct {
+	clients  map[string]*networkClient
+	networks []domain.NetworkConfig
+}
+
+// NewNetworkRegistry 为每个网络建立连接，连接失败只记录日志，使用时再重连；
+// connected中已经连接的服务(例如模拟链)直接使用，只校验链ID
+func NewNetworkRegistry(configs []domain.NetworkConfig, connected map[string]domain.EthereumService) domain.NetworkRegistry {
+	registry := &networkRegistry{clients: make(map[string]*networkClient)}
+	for _, config := range configs {
+		client := &networkClient{config: config, service: connected[config.Name]}
+		registry.clients[config.Name] = client
+		registry.networks = append(registry.networks, config)
+
+		var err error
+		if client.service != nil && client.service.IsConnected() {
+			err = registry.verifyChainID(client)
+		} else {
+			if client.service == nil {
+				client.service = NewEthereumService()
+			}
+			client.lastAttempt = time.Now()
+			err = registry.connect(client)
+		}
+		if err != nil {
+			log.Printf("failed to connect to network %s: %v", config.Name, err)
+		}
+	}
+	return registry
+}
+
+// GetClient 网络已配置时总是返回对应的EthereumService，即使当前未连接；
+// 未连接的服务仍可用于创建、派生账户等离线操作。每个网络同时只有一个调用者重连，
+// 其他调用者不等待，直接拿到未连接的服务
+func (nr *networkRegistry) GetClient(network string) (domain.EthereumService, error) {
+	client, ok := nr.clients[network]
+	if !ok {
+		return nil, domain.ErrNetworkNotSupported
+	}
+
+	if !client.service.IsConnected() && client.startReconnect() {
+		err := nr.connect(client)
+		if err != nil {
+			log.Printf("failed to reconnect to network %s: %v", network, err)
+		}
+		client.finishReconnect()
+	}
+	return client.service, nil
+}
+
+// startReconnect 没有进行中的重连且距上次尝试超过reconnectInterval时返回true
+func (client *networkClient) startReconnect() bool {
+	client.mu.Lock()
+	defer client.mu.Unlock()
+
+	if client.connecting || time.Since(client.lastAttempt) < reconnectInterval {
+		return false
+	}
+	client.connecting = true
+	client.lastAttempt = time.Now()
+	return true
+}
+
+func (client *networkClient) finishReconnect() {
+	client.mu.Lock()
+	defer client.mu.Unlock()
+
+	client.connecting = false
+}
+
+func (nr *networkRegistry) GetNetwork(network string) (*domain.NetworkConfig, error) {
+	client, ok := nr.clients[network]
+	if !ok {
+		return nil, domain.ErrNetworkNotSupported
+	}
+
+	config := client.config
+	return &config, nil
+}
+
+// GetNetworks 按配置顺序返回
+func (nr *networkRegistry) GetNetworks() []domain.NetworkConfig {
+	networks := make([]domain.NetworkConfig, len(nr.networks))
+	copy(networks, nr.networks)
+	return networks
+}
+
+func (nr *networkRegistry) Close() error {
+	for _, client := range nr.clients {
+		client.service.Disconnect()
+	}
+	return nil
+}
+
+// connect 连接后校验链ID，防止RPC配置错误导致交易签到错误的链
+func (nr *networkRegistry) connect(client *networkClient) error {
+	ctx, cancel := context.WithTimeout(context.Background(), connectTimeout)
+	defer cancel()
+
+	err := client.service.Connect(ctx, client.config.RPC)
+	if err != nil {
+		return err
+	}
+	return nr.verifyChainID(client)
+}
+
+// verifyChainID 链ID不一致时断开连接
+func (nr *networkRegistry) verifyChainID(client *networkClient) error {
+	networkID, err := client.service.GetNetworkID()
+	if err != nil {
+		client.service.Disconnect()
+		return err
+	}
+	if client.config.ChainID != 0 && networkID != client.config.ChainID {
+		client.service.Disconnect()
+		return fmt.Errorf("network %s expects chain ID %d, RPC returned %d", client.config.Name, client.config.ChainID, networkID)
+	}
+	return nil
+}
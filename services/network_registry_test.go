@@ -0,0 +1,94 @@
+package services_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/littlecheny/go-backend/domain"
+	"github.com/littlecheny/go-backend/domain/mocks"
+	"github.com/littlecheny/go-backend/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestNetworkRegistry(t *testing.T) {
+	registry := services.NewNetworkRegistry([]domain.NetworkConfig{
+		{Name: "sepolia", ChainID: 11155111, RPC: "http://127.0.0.1:1"},
+		{Name: "mainnet", ChainID: 1, RPC: "http://127.0.0.1:1"},
+	}, nil)
+	defer registry.Close()
+
+	// 节点不可达时仍返回客户端，只是未连接
+	client, err := registry.GetClient("sepolia")
+	assert.NoError(t, err)
+	assert.False(t, client.IsConnected())
+
+	_, err = registry.GetClient("polygon")
+	assert.ErrorIs(t, err, domain.ErrNetworkNotSupported)
+
+	network, err := registry.GetNetwork("mainnet")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), network.ChainID)
+
+	_, err = registry.GetNetwork("polygon")
+	assert.ErrorIs(t, err, domain.ErrNetworkNotSupported)
+
+	networks := registry.GetNetworks()
+	assert.Len(t, networks, 2)
+	assert.Equal(t, "sepolia", networks[0].Name)
+}
+
+func TestNetworkRegistryReconnect(t *testing.T) {
+	dialing := make(chan struct{})
+	release := make(chan struct{})
+
+	sepolia := new(mocks.EthereumService)
+	sepolia.On("IsConnected").Return(true).Once()
+	sepolia.On("GetNetworkID").Return(int64(11155111), nil).Once()
+	sepolia.On("IsConnected").Return(false)
+	sepolia.On("Connect", mock.Anything, "http://sepolia").Run(func(mock.Arguments) {
+		close(dialing)
+		<-release
+	}).Return(errors.New("connection refused")).Once()
+
+	mainnet := new(mocks.EthereumService)
+	mainnet.On("IsConnected").Return(true)
+	mainnet.On("GetNetworkID").Return(int64(1), nil).Once()
+
+	registry := services.NewNetworkRegistry([]domain.NetworkConfig{
+		{Name: "sepolia", ChainID: 11155111, RPC: "http://sepolia"},
+		{Name: "mainnet", ChainID: 1, RPC: "http://mainnet"},
+	}, map[string]domain.EthereumService{"sepolia": sepolia, "mainnet": mainnet})
+
+	reconnected := make(chan struct{})
+	go func() {
+		defer close(reconnected)
+		registry.GetClient("sepolia")
+	}()
+	<-dialing
+
+	// 重连进行中时，其他网络和同一网络的查询都不等待
+	lookups := make(chan struct{})
+	go func() {
+		defer close(lookups)
+		client, err := registry.GetClient("mainnet")
+		assert.NoError(t, err)
+		assert.Equal(t, mainnet, client)
+
+		client, err = registry.GetClient("sepolia")
+		assert.NoError(t, err)
+		assert.False(t, client.IsConnected())
+	}()
+
+	select {
+	case <-lookups:
+	case <-time.After(time.Second):
+		t.Fatal("GetClient blocked while another network was reconnecting")
+	}
+
+	close(release)
+	<-reconnected
+
+	sepolia.AssertExpectations(t)
+}
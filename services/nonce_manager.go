@@ -0,0 +1,144 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/littlecheny/go-backend/domain"
+)
+
+const (
+	// nonceLockTTL 发送锁的过期时间，防止进程崩溃后地址被永久锁住；持有期间每nonceLockRenew续期一次
+	nonceLockTTL   = 30 * time.Second
+	nonceLockRenew = nonceLockTTL / 3
+	// nonceLockRetry 等待发送锁时的轮询间隔
+	nonceLockRetry = 50 * time.Millisecond
+	// nonceTTL 长时间没有发送的地址删除记录，下次从节点重新同步
+	nonceTTL = 24 * time.Hour
+)
+
+type nonceManager struct {
+	cache domain.RedisService
+}
+
+func NewNonceManager(cache domain.RedisService) domain.NonceManager {
+	return &nonceManager{cache: cache}
+}
+
+// Lock 锁的值为随机令牌，只有持有者能续期和释放；节点响应慢时持续续期，直到unlock
+func (nm *nonceManager) Lock(ctx context.Context, network, address string) (func(), error) {
+	key := nonceLockKey(network, address)
+
+	token := make([]byte, 16)
+	_, err := rand.Read(token)
+	if err != nil {
+		return nil, err
+	}
+	value := hex.EncodeToString(token)
+
+	for {
+		ok, err := nm.cache.SetNX(ctx, key, value, nonceLockTTL)
+		if err != nil && ctx.Err() == nil {
+			return nil, fmt.Errorf("failed to lock %s: %v", key, err)
+		}
+		if ok {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, domain.ErrWalletBusy
+		case <-time.After(nonceLockRetry):
+		}
+	}
+
+	stop := make(chan struct{})
+	stopped := make(chan struct{})
+	go nm.renew(key, value, stop, stopped)
+
+	unlock := func() {
+		close(stop)
+		<-stopped
+
+		// 请求的ctx可能已经超时，释放锁使用独立的ctx
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+
+		_, err := nm.cache.CompareAndDelete(ctx, key, value)
+		if err != nil {
+			log.Printf("failed to unlock %s: %v", key, err)
+		}
+	}
+	return unlock, nil
+}
+
+// renew 锁仍由value持有时重设过期时间，锁已丢失时停止续期
+func (nm *nonceManager) renew(key, value string, stop <-chan struct{}, stopped chan<- struct{}) {
+	defer close(stopped)
+
+	ticker := time.NewTicker(nonceLockRenew)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			held, err := nm.cache.CompareAndSwap(ctx, key, value, value, nonceLockTTL)
+			cancel()
+			if err != nil {
+				log.Printf("failed to renew lock %s: %v", key, err)
+				continue
+			}
+			if !held {
+				log.Printf("lock %s expired before it was released", key)
+				return
+			}
+		}
+	}
+}
+
+func (nm *nonceManager) Get(ctx context.Context, network, address string) (uint64, bool, error) {
+	key := nonceKey(network, address)
+
+	exists, err := nm.cache.Exists(ctx, key)
+	if err != nil || !exists {
+		return 0, false, err
+	}
+
+	value, err := nm.cache.Get(ctx, key)
+	if err != nil {
+		return 0, false, err
+	}
+
+	var nonce uint64
+	err = json.Unmarshal([]byte(value), &nonce)
+	if err != nil {
+		return 0, false, fmt.Errorf("invalid nonce for key %s: %v", key, err)
+	}
+	return nonce, true, nil
+}
+
+func (nm *nonceManager) Set(ctx context.Context, network, address string, nonce uint64) error {
+	return nm.cache.Set(ctx, nonceKey(network, address), nonce, nonceTTL)
+}
+
+func (nm *nonceManager) Reset(ctx context.Context, network, address string) error {
+	return nm.cache.Del(ctx, nonceKey(network, address))
+}
+
+// nonceKey 地址统一为校验和格式，大小写不同的地址使用同一个计数
+func nonceKey(network, address string) string {
+	return fmt.Sprintf("nonce:%s:%s", network, common.HexToAddress(address).Hex())
+}
+
+func nonceLockKey(network, address string) string {
+	return fmt.Sprintf("nonce_lock:%s:%s", network, common.HexToAddress(address).Hex())
+}
@@ -0,0 +1,103 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/littlecheny/go-backend/domain"
+	"github.com/littlecheny/go-backend/services"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const nonceTestAddress = "0x1111111111111111111111111111111111111111"
+
+func newNonceManager(t *testing.T) (domain.NonceManager, *miniredis.Miniredis) {
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { client.Close() })
+
+	return services.NewNonceManager(services.NewRedisService(client)), server
+}
+
+func TestNonceManagerLock(t *testing.T) {
+	ctx := context.Background()
+	lockKey := "nonce_lock:sepolia:" + nonceTestAddress
+
+	t.Run("exclusive until unlocked", func(t *testing.T) {
+		nm, server := newNonceManager(t)
+
+		unlock, err := nm.Lock(ctx, "sepolia", nonceTestAddress)
+		require.NoError(t, err)
+		assert.True(t, server.Exists(lockKey))
+
+		waitCtx, cancel := context.WithTimeout(ctx, 200*time.Millisecond)
+		defer cancel()
+		_, err = nm.Lock(waitCtx, "sepolia", nonceTestAddress)
+		assert.ErrorIs(t, err, domain.ErrWalletBusy)
+
+		// 其他网络上的同一地址不受影响
+		unlockOther, err := nm.Lock(ctx, "mainnet", nonceTestAddress)
+		require.NoError(t, err)
+		unlockOther()
+
+		unlock()
+		assert.False(t, server.Exists(lockKey))
+
+		unlock, err = nm.Lock(ctx, "sepolia", nonceTestAddress)
+		require.NoError(t, err)
+		unlock()
+	})
+
+	t.Run("expired lock is not released by its previous holder", func(t *testing.T) {
+		nm, server := newNonceManager(t)
+
+		unlockFirst, err := nm.Lock(ctx, "sepolia", nonceTestAddress)
+		require.NoError(t, err)
+
+		server.FastForward(time.Minute)
+		assert.False(t, server.Exists(lockKey))
+
+		unlockSecond, err := nm.Lock(ctx, "sepolia", nonceTestAddress)
+		require.NoError(t, err)
+		held, err := server.Get(lockKey)
+		require.NoError(t, err)
+
+		unlockFirst()
+		current, err := server.Get(lockKey)
+		require.NoError(t, err)
+		assert.Equal(t, held, current)
+
+		unlockSecond()
+		assert.False(t, server.Exists(lockKey))
+	})
+}
+
+func TestNonceManagerGetSetReset(t *testing.T) {
+	ctx := context.Background()
+	nm, server := newNonceManager(t)
+
+	_, ok, err := nm.Get(ctx, "sepolia", nonceTestAddress)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	require.NoError(t, nm.Set(ctx, "sepolia", nonceTestAddress, 9))
+	assert.Equal(t, 24*time.Hour, server.TTL("nonce:sepolia:"+nonceTestAddress))
+
+	nonce, ok, err := nm.Get(ctx, "sepolia", nonceTestAddress)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, uint64(9), nonce)
+
+	_, ok, err = nm.Get(ctx, "mainnet", nonceTestAddress)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	require.NoError(t, nm.Reset(ctx, "sepolia", nonceTestAddress))
+	_, ok, err = nm.Get(ctx, "sepolia", nonceTestAddress)
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
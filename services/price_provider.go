@@ -0,0 +1,102 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/littlecheny/go-backend/domain"
+)
+
+// chainlinkMaxPriceAge 超过该时间未更新的报价视为不可用，Chainlink ETH/USD主网喂价的心跳为1小时
+const chainlinkMaxPriceAge = 2 * time.Hour
+
+type chainlinkPriceProvider struct {
+	networks domain.NetworkRegistry
+	network  string
+	feeds    map[string]string
+}
+
+// NewChainlinkPriceProvider 通过network上的Chainlink喂价合约查询价格，feeds为代币符号到USD喂价合约地址的映射
+func NewChainlinkPriceProvider(networks domain.NetworkRegistry, network string, feeds map[string]string) domain.PriceProvider {
+	provider := &chainlinkPriceProvider{networks: networks, network: network, feeds: make(map[string]string)}
+	for symbol, feed := range feeds {
+		provider.feeds[strings.ToUpper(symbol)] = feed
+	}
+	return provider
+}
+
+func (cp *chainlinkPriceProvider) GetPriceUSD(ctx context.Context, symbol string) (string, error) {
+	feed, ok := cp.feeds[strings.ToUpper(symbol)]
+	if !ok {
+		return "", domain.ErrPriceNotAvailable
+	}
+
+	ethereumService, err := cp.networks.GetClient(cp.network)
+	if err != nil {
+		return "", err
+	}
+
+	round, err := ethereumService.GetLatestPrice(ctx, feed)
+	if err != nil {
+		return "", err
+	}
+	if time.Since(round.UpdatedAt) > chainlinkMaxPriceAge {
+		return "", fmt.Errorf("%w: %s price was last updated at %s", domain.ErrPriceNotAvailable, symbol, round.UpdatedAt.Format(time.RFC3339))
+	}
+	return round.Answer, nil
+}
+
+type staticPriceProvider struct {
+	prices map[string]string
+}
+
+// NewStaticPriceProvider 使用固定价格，用于没有节点的离线环境；符号不区分大小写
+func NewStaticPriceProvider(prices map[string]string) domain.PriceProvider {
+	provider := &staticPriceProvider{prices: make(map[string]string)}
+	for symbol, price := range prices {
+		provider.prices[strings.ToUpper(symbol)] = price
+	}
+	return provider
+}
+
+func (sp *staticPriceProvider) GetPriceUSD(ctx context.Context, symbol string) (string, error) {
+	price, ok := sp.prices[strings.ToUpper(symbol)]
+	if !ok {
+		return "", domain.ErrPriceNotAvailable
+	}
+	return price, nil
+}
+
+type cachedPriceProvider struct {
+	provider domain.PriceProvider
+	cache    domain.RedisService
+	ttl      time.Duration
+}
+
+// NewCachedPriceProvider 查询结果在Redis中缓存ttl时间，多个实例共享；查询失败不缓存
+func NewCachedPriceProvider(provider domain.PriceProvider, cache domain.RedisService, ttl time.Duration) domain.PriceProvider {
+	return &cachedPriceProvider{provider: provider, cache: cache, ttl: ttl}
+}
+
+func (cp *cachedPriceProvider) GetPriceUSD(ctx context.Context, symbol string) (string, error) {
+	symbol = strings.ToUpper(symbol)
+
+	price, err := cp.cache.GetPrice(ctx, symbol)
+	if err == nil {
+		return price, nil
+	}
+
+	price, err = cp.provider.GetPriceUSD(ctx, symbol)
+	if err != nil {
+		return "", err
+	}
+
+	err = cp.cache.SetPrice(ctx, symbol, price, cp.ttl)
+	if err != nil {
+		log.Printf("failed to cache %s price: %v", symbol, err)
+	}
+	return price, nil
+}
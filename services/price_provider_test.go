@@ -0,0 +1,98 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/littlecheny/go-backend/domain"
+	"github.com/littlecheny/go-backend/domain/mocks"
+	"github.com/littlecheny/go-backend/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// aggregatorCode 模拟喂价合约: decimals()返回8，latestRoundData()返回报价300012345678，updatedAt为当前区块时间
+const aggregatorCode = "60003560e01c8063313ce56714601d5763feaf968c14602857600080fd5b600860005260206000f35b60016000526445da21194e6020524260405242606052600160805260a06000f3"
+
+func TestChainlinkPriceProvider(t *testing.T) {
+	ctx := context.Background()
+	aggregator := common.HexToAddress("0x4444444444444444444444444444444444444444")
+
+	service, backend, err := services.NewSimulatedEthereumService(types.GenesisAlloc{aggregator: {Code: common.FromHex(aggregatorCode)}}, 0)
+	require.NoError(t, err)
+	backend.Commit()
+
+	registry := services.NewNetworkRegistry([]domain.NetworkConfig{{Name: "mainnet", ChainID: services.SimulatedChainID}}, map[string]domain.EthereumService{"mainnet": service})
+	t.Cleanup(func() { registry.Close() })
+
+	round, err := service.GetLatestPrice(ctx, aggregator.Hex())
+	require.NoError(t, err)
+	assert.Equal(t, "3000.12345678", round.Answer)
+	assert.WithinDuration(t, time.Now(), round.UpdatedAt, time.Minute)
+
+	provider := services.NewChainlinkPriceProvider(registry, "mainnet", map[string]string{"eth": aggregator.Hex()})
+
+	price, err := provider.GetPriceUSD(ctx, "ETH")
+	assert.NoError(t, err)
+	assert.Equal(t, "3000.12345678", price)
+
+	_, err = provider.GetPriceUSD(ctx, "BTC")
+	assert.ErrorIs(t, err, domain.ErrPriceNotAvailable)
+
+	// 没有部署合约的地址调用返回空数据
+	_, err = service.GetLatestPrice(ctx, recipient)
+	assert.Error(t, err)
+}
+
+func TestStaticPriceProvider(t *testing.T) {
+	provider := services.NewStaticPriceProvider(map[string]string{"eth": "2500.5"})
+
+	price, err := provider.GetPriceUSD(context.Background(), "ETH")
+	assert.NoError(t, err)
+	assert.Equal(t, "2500.5", price)
+
+	_, err = provider.GetPriceUSD(context.Background(), "DAI")
+	assert.ErrorIs(t, err, domain.ErrPriceNotAvailable)
+}
+
+func TestCachedPriceProvider(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("hit", func(t *testing.T) {
+		cache := new(mocks.RedisService)
+		cache.On("GetPrice", mock.Anything, "ETH").Return("2500", nil).Once()
+
+		provider := services.NewCachedPriceProvider(services.NewStaticPriceProvider(nil), cache, time.Minute)
+		price, err := provider.GetPriceUSD(ctx, "eth")
+		assert.NoError(t, err)
+		assert.Equal(t, "2500", price)
+		cache.AssertExpectations(t)
+	})
+
+	t.Run("miss", func(t *testing.T) {
+		cache := new(mocks.RedisService)
+		cache.On("GetPrice", mock.Anything, "ETH").Return("", errors.New("redis: nil")).Once()
+		cache.On("SetPrice", mock.Anything, "ETH", "2600", time.Minute).Return(nil).Once()
+
+		provider := services.NewCachedPriceProvider(services.NewStaticPriceProvider(map[string]string{"ETH": "2600"}), cache, time.Minute)
+		price, err := provider.GetPriceUSD(ctx, "ETH")
+		assert.NoError(t, err)
+		assert.Equal(t, "2600", price)
+		cache.AssertExpectations(t)
+	})
+
+	t.Run("unavailable", func(t *testing.T) {
+		cache := new(mocks.RedisService)
+		cache.On("GetPrice", mock.Anything, "BTC").Return("", errors.New("redis: nil")).Once()
+
+		provider := services.NewCachedPriceProvider(services.NewStaticPriceProvider(nil), cache, time.Minute)
+		_, err := provider.GetPriceUSD(ctx, "BTC")
+		assert.ErrorIs(t, err, domain.ErrPriceNotAvailable)
+		cache.AssertNotCalled(t, "SetPrice", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
@@ -10,6 +10,23 @@ import (
 	"github.com/littlecheny/go-backend/domain"
 )
 
+// compareAndSwapScript 值等于ARGV[1]时替换为ARGV[2]，ARGV[3]为过期毫秒数
+var compareAndSwapScript = redis.NewScript(`
+if redis.call("get", KEYS[1]) == ARGV[1] then
+	redis.call("set", KEYS[1], ARGV[2], "PX", ARGV[3])
+	return 1
+end
+return 0
+`)
+
+// compareAndDeleteScript 值等于ARGV[1]时删除
+var compareAndDeleteScript = redis.NewScript(`
+if redis.call("get", KEYS[1]) == ARGV[1] then
+	return redis.call("del", KEYS[1])
+end
+return 0
+`)
+
 type redisService struct {
 	client *redis.Client
 }
@@ -20,9 +37,7 @@ func NewRedisService(client *redis.Client) domain.RedisService {
 	}
 }
 
-func (r *redisService) Set(key string, value interface{}, expiration time.Duration) error {
-	ctx := context.Background()
-	
+func (r *redisService) Set(ctx context.Context, key string, value interface{}, expiration time.Duration) error {
 	// 将值序列化为JSON
 	jsonValue, err := json.Marshal(value)
 	if err != nil {
@@ -37,9 +52,7 @@ func (r *redisService) Set(key string, value interface{}, expiration time.Durati
 	return nil
 }
 
-func (r *redisService) Get(key string) (string, error) {
-	ctx := context.Background()
-	
+func (r *redisService) Get(ctx context.Context, key string) (string, error) {
 	val, err := r.client.Get(ctx, key).Result()
 	if err != nil {
 		if err == redis.Nil {
@@ -51,9 +64,7 @@ func (r *redisService) Get(key string) (string, error) {
 	return val, nil
 }
 
-func (r *redisService) Del(key string) error {
-	ctx := context.Background()
-	
+func (r *redisService) Del(ctx context.Context, key string) error {
 	err := r.client.Del(ctx, key).Err()
 	if err != nil {
 		return fmt.Errorf("failed to delete key %s: %v", key, err)
@@ -62,9 +73,58 @@ func (r *redisService) Del(key string) error {
 	return nil
 }
 
+// CompareAndSwap 比较和替换在同一个Lua脚本中执行，并发调用只有一个能成功
+func (r *redisService) CompareAndSwap(ctx context.Context, key string, old, value interface{}, expiration time.Duration) (bool, error) {
+	if expiration <= 0 {
+		return false, fmt.Errorf("invalid expiration %s for key %s", expiration, key)
+	}
+
+	oldValue, err := json.Marshal(old)
+	if err != nil {
+		return false, fmt.Errorf("failed to marshal value: %v", err)
+	}
+	newValue, err := json.Marshal(value)
+	if err != nil {
+		return false, fmt.Errorf("failed to marshal value: %v", err)
+	}
+
+	swapped, err := compareAndSwapScript.Run(ctx, r.client, []string{key}, oldValue, newValue, expiration.Milliseconds()).Int()
+	if err != nil {
+		return false, fmt.Errorf("failed to swap key %s: %v", key, err)
+	}
+	return swapped == 1, nil
+}
+
+func (r *redisService) SetNX(ctx context.Context, key string, value interface{}, expiration time.Duration) (bool, error) {
+	jsonValue, err := json.Marshal(value)
+	if err != nil {
+		return false, fmt.Errorf("failed to marshal value: %v", err)
+	}
+
+	ok, err := r.client.SetNX(ctx, key, jsonValue, expiration).Result()
+	if err != nil {
+		return false, fmt.Errorf("failed to set key %s: %v", key, err)
+	}
+	return ok, nil
+}
+
+// CompareAndDelete 比较和删除在同一个Lua脚本中执行，值已被他人替换时不会误删
+func (r *redisService) CompareAndDelete(ctx context.Context, key string, value interface{}) (bool, error) {
+	jsonValue, err := json.Marshal(value)
+	if err != nil {
+		return false, fmt.Errorf("failed to marshal value: %v", err)
+	}
+
+	deleted, err := compareAndDeleteScript.Run(ctx, r.client, []string{key}, jsonValue).Int()
+	if err != nil {
+		return false, fmt.Errorf("failed to delete key %s: %v", key, err)
+	}
+	return deleted == 1, nil
+}
+
 // 辅助方法：获取并反序列化JSON
-func (r *redisService) GetJSON(key string, dest interface{}) error {
-	val, err := r.Get(key)
+func (r *redisService) GetJSON(ctx context.Context, key string, dest interface{}) error {
+	val, err := r.Get(ctx, key)
 	if err != nil {
 		return err
 	}
@@ -78,9 +138,7 @@ func (r *redisService) GetJSON(key string, dest interface{}) error {
 	return nil
 }
 
-func (r *redisService) Exists(key string) (bool, error) {
-	ctx := context.Background()
-	
+func (r *redisService) Exists(ctx context.Context, key string) (bool, error) {
 	exists, err := r.client.Exists(ctx, key).Result()
 	if err != nil {
 		return false, fmt.Errorf("failed to check existence of key %s: %v", key, err)
@@ -89,9 +147,7 @@ func (r *redisService) Exists(key string) (bool, error) {
 	return exists > 0, nil
 }
 
-func (r *redisService) SetExpiration(key string, expiration time.Duration) error {
-	ctx := context.Background()
-	
+func (r *redisService) SetExpiration(ctx context.Context, key string, expiration time.Duration) error {
 	err := r.client.Expire(ctx, key, expiration).Err()
 	if err != nil {
 		return fmt.Errorf("failed to set expiration for key %s: %v", key, err)
@@ -100,9 +156,7 @@ func (r *redisService) SetExpiration(key string, expiration time.Duration) error
 	return nil
 }
 
-func (r *redisService) GetTTL(key string) (time.Duration, error) {
-	ctx := context.Background()
-	
+func (r *redisService) GetTTL(ctx context.Context, key string) (time.Duration, error) {
 	ttl, err := r.client.TTL(ctx, key).Result()
 	if err != nil {
 		return 0, fmt.Errorf("failed to get TTL for key %s: %v", key, err)
@@ -111,9 +165,7 @@ func (r *redisService) GetTTL(key string) (time.Duration, error) {
 	return ttl, nil
 }
 
-func (r *redisService) SetHash(key, field string, value interface{}) error {
-	ctx := context.Background()
-	
+func (r *redisService) SetHash(ctx context.Context, key, field string, value interface{}) error {
 	jsonValue, err := json.Marshal(value)
 	if err != nil {
 		return fmt.Errorf("failed to marshal value: %v", err)
@@ -127,9 +179,7 @@ func (r *redisService) SetHash(key, field string, value interface{}) error {
 	return nil
 }
 
-func (r *redisService) GetHash(key, field string, dest interface{}) error {
-	ctx := context.Background()
-	
+func (r *redisService) GetHash(ctx context.Context, key, field string, dest interface{}) error {
 	val, err := r.client.HGet(ctx, key, field).Result()
 	if err != nil {
 		if err == redis.Nil {
@@ -146,9 +196,7 @@ func (r *redisService) GetHash(key, field string, dest interface{}) error {
 	return nil
 }
 
-func (r *redisService) GetAllHash(key string) (map[string]string, error) {
-	ctx := context.Background()
-	
+func (r *redisService) GetAllHash(ctx context.Context, key string) (map[string]string, error) {
 	result, err := r.client.HGetAll(ctx, key).Result()
 	if err != nil {
 		return nil, fmt.Errorf("failed to get all hash fields for key %s: %v", key, err)
@@ -157,9 +205,7 @@ func (r *redisService) GetAllHash(key string) (map[string]string, error) {
 	return result, nil
 }
 
-func (r *redisService) DeleteHash(key, field string) error {
-	ctx := context.Background()
-	
+func (r *redisService) DeleteHash(ctx context.Context, key, field string) error {
 	err := r.client.HDel(ctx, key, field).Err()
 	if err != nil {
 		return fmt.Errorf("failed to delete hash field %s:%s: %v", key, field, err)
@@ -168,9 +214,7 @@ func (r *redisService) DeleteHash(key, field string) error {
 	return nil
 }
 
-func (r *redisService) IncrementCounter(key string) (int64, error) {
-	ctx := context.Background()
-	
+func (r *redisService) IncrementCounter(ctx context.Context, key string) (int64, error) {
 	val, err := r.client.Incr(ctx, key).Result()
 	if err != nil {
 		return 0, fmt.Errorf("failed to increment counter %s: %v", key, err)
@@ -179,9 +223,7 @@ func (r *redisService) IncrementCounter(key string) (int64, error) {
 	return val, nil
 }
 
-func (r *redisService) DecrementCounter(key string) (int64, error) {
-	ctx := context.Background()
-	
+func (r *redisService) DecrementCounter(ctx context.Context, key string) (int64, error) {
 	val, err := r.client.Decr(ctx, key).Result()
 	if err != nil {
 		return 0, fmt.Errorf("failed to decrement counter %s: %v", key, err)
@@ -190,9 +232,7 @@ func (r *redisService) DecrementCounter(key string) (int64, error) {
 	return val, nil
 }
 
-func (r *redisService) AddToList(key string, values ...interface{}) error {
-	ctx := context.Background()
-	
+func (r *redisService) AddToList(ctx context.Context, key string, values ...interface{}) error {
 	// 序列化所有值
 	serializedValues := make([]interface{}, len(values))
 	for i, value := range values {
@@ -211,9 +251,7 @@ func (r *redisService) AddToList(key string, values ...interface{}) error {
 	return nil
 }
 
-func (r *redisService) GetListRange(key string, start, stop int64) ([]string, error) {
-	ctx := context.Background()
-	
+func (r *redisService) GetListRange(ctx context.Context, key string, start, stop int64) ([]string, error) {
 	result, err := r.client.LRange(ctx, key, start, stop).Result()
 	if err != nil {
 		return nil, fmt.Errorf("failed to get list range for key %s: %v", key, err)
@@ -222,9 +260,7 @@ func (r *redisService) GetListRange(key string, start, stop int64) ([]string, er
 	return result, nil
 }
 
-func (r *redisService) GetListLength(key string) (int64, error) {
-	ctx := context.Background()
-	
+func (r *redisService) GetListLength(ctx context.Context, key string) (int64, error) {
 	length, err := r.client.LLen(ctx, key).Result()
 	if err != nil {
 		return 0, fmt.Errorf("failed to get list length for key %s: %v", key, err)
@@ -233,9 +269,7 @@ func (r *redisService) GetListLength(key string) (int64, error) {
 	return length, nil
 }
 
-func (r *redisService) PopFromList(key string) (string, error) {
-	ctx := context.Background()
-	
+func (r *redisService) PopFromList(ctx context.Context, key string) (string, error) {
 	val, err := r.client.LPop(ctx, key).Result()
 	if err != nil {
 		if err == redis.Nil {
@@ -247,9 +281,7 @@ func (r *redisService) PopFromList(key string) (string, error) {
 	return val, nil
 }
 
-func (r *redisService) AddToSet(key string, members ...interface{}) error {
-	ctx := context.Background()
-	
+func (r *redisService) AddToSet(ctx context.Context, key string, members ...interface{}) error {
 	// 序列化所有成员
 	serializedMembers := make([]interface{}, len(members))
 	for i, member := range members {
@@ -268,9 +300,7 @@ func (r *redisService) AddToSet(key string, members ...interface{}) error {
 	return nil
 }
 
-func (r *redisService) GetSetMembers(key string) ([]string, error) {
-	ctx := context.Background()
-	
+func (r *redisService) GetSetMembers(ctx context.Context, key string) ([]string, error) {
 	members, err := r.client.SMembers(ctx, key).Result()
 	if err != nil {
 		return nil, fmt.Errorf("failed to get set members for key %s: %v", key, err)
@@ -279,9 +309,7 @@ func (r *redisService) GetSetMembers(key string) ([]string, error) {
 	return members, nil
 }
 
-func (r *redisService) IsSetMember(key string, member interface{}) (bool, error) {
-	ctx := context.Background()
-	
+func (r *redisService) IsSetMember(ctx context.Context, key string, member interface{}) (bool, error) {
 	jsonValue, err := json.Marshal(member)
 	if err != nil {
 		return false, fmt.Errorf("failed to marshal member: %v", err)
@@ -295,9 +323,7 @@ func (r *redisService) IsSetMember(key string, member interface{}) (bool, error)
 	return isMember, nil
 }
 
-func (r *redisService) RemoveFromSet(key string, members ...interface{}) error {
-	ctx := context.Background()
-	
+func (r *redisService) RemoveFromSet(ctx context.Context, key string, members ...interface{}) error {
 	// 序列化所有成员
 	serializedMembers := make([]interface{}, len(members))
 	for i, member := range members {
@@ -316,9 +342,7 @@ func (r *redisService) RemoveFromSet(key string, members ...interface{}) error {
 	return nil
 }
 
-func (r *redisService) GetKeys(pattern string) ([]string, error) {
-	ctx := context.Background()
-	
+func (r *redisService) GetKeys(ctx context.Context, pattern string) ([]string, error) {
 	keys, err := r.client.Keys(ctx, pattern).Result()
 	if err != nil {
 		return nil, fmt.Errorf("failed to get keys with pattern %s: %v", pattern, err)
@@ -327,9 +351,7 @@ func (r *redisService) GetKeys(pattern string) ([]string, error) {
 	return keys, nil
 }
 
-func (r *redisService) FlushDB() error {
-	ctx := context.Background()
-	
+func (r *redisService) FlushDB(ctx context.Context) error {
 	err := r.client.FlushDB(ctx).Err()
 	if err != nil {
 		return fmt.Errorf("failed to flush database: %v", err)
@@ -338,9 +360,7 @@ func (r *redisService) FlushDB() error {
 	return nil
 }
 
-func (r *redisService) Ping() error {
-	ctx := context.Background()
-	
+func (r *redisService) Ping(ctx context.Context) error {
 	_, err := r.client.Ping(ctx).Result()
 	if err != nil {
 		return fmt.Errorf("failed to ping Redis: %v", err)
@@ -352,59 +372,105 @@ func (r *redisService) Ping() error {
 // 实现接口要求的方法
 
 // SetBalance 缓存余额信息
-func (r *redisService) SetBalance(address string, balance string, expiration time.Duration) error {
+func (r *redisService) SetBalance(ctx context.Context, address string, balance string, expiration time.Duration) error {
 	key := fmt.Sprintf("balance:%s", address)
-	return r.Set(key, balance, expiration)
+	return r.Set(ctx, key, balance, expiration)
 }
 
 // GetBalance 获取缓存的余额信息
-func (r *redisService) GetBalance(address string) (string, error) {
+func (r *redisService) GetBalance(ctx context.Context, address string) (string, error) {
 	key := fmt.Sprintf("balance:%s", address)
-	return r.Get(key)
+	return r.Get(ctx, key)
 }
 
 // SetGasPrice 缓存Gas价格
-func (r *redisService) SetGasPrice(network string, gasPrice string, expiration time.Duration) error {
+func (r *redisService) SetGasPrice(ctx context.Context, network string, gasPrice string, expiration time.Duration) error {
 	key := fmt.Sprintf("gas_price:%s", network)
-	return r.Set(key, gasPrice, expiration)
+	return r.Set(ctx, key, gasPrice, expiration)
 }
 
 // GetGasPrice 获取缓存的Gas价格
-func (r *redisService) GetGasPrice(network string) (string, error) {
+func (r *redisService) GetGasPrice(ctx context.Context, network string) (string, error) {
 	key := fmt.Sprintf("gas_price:%s", network)
-	return r.Get(key)
+	return r.Get(ctx, key)
 }
 
 // SetTransaction 缓存交易信息
-func (r *redisService) SetTransaction(hash string, tx *domain.TransactionResponse, expiration time.Duration) error {
+func (r *redisService) SetTransaction(ctx context.Context, hash string, tx *domain.TransactionResponse, expiration time.Duration) error {
 	key := fmt.Sprintf("transaction:%s", hash)
-	return r.Set(key, tx, expiration)
+	return r.Set(ctx, key, tx, expiration)
 }
 
 // GetTransaction 获取缓存的交易信息
-func (r *redisService) GetTransaction(hash string) (*domain.TransactionResponse, error) {
+func (r *redisService) GetTransaction(ctx context.Context, hash string) (*domain.TransactionResponse, error) {
 	key := fmt.Sprintf("transaction:%s", hash)
 	var tx domain.TransactionResponse
-	err := r.GetJSON(key, &tx)
+	err := r.GetJSON(ctx, key, &tx)
 	if err != nil {
 		return nil, err
 	}
 	return &tx, nil
 }
 
-// SetBlock 缓存区块信息
-func (r *redisService) SetBlock(number uint64, block *domain.BlockInfo, expiration time.Duration) error {
-	key := fmt.Sprintf("block:%d", number)
-	return r.Set(key, block, expiration)
+// DeleteTransaction 删除缓存的交易信息，交易状态变化时调用
+func (r *redisService) DeleteTransaction(ctx context.Context, hash string) error {
+	key := fmt.Sprintf("transaction:%s", hash)
+	return r.Del(ctx, key)
+}
+
+// SetBlock 缓存区块信息，不同网络的区块号会重复，key包含网络名
+func (r *redisService) SetBlock(ctx context.Context, network string, number uint64, block *domain.BlockInfo, expiration time.Duration) error {
+	key := fmt.Sprintf("block:%s:%d", network, number)
+	return r.Set(ctx, key, block, expiration)
 }
 
 // GetBlock 获取缓存的区块信息
-func (r *redisService) GetBlock(number uint64) (*domain.BlockInfo, error) {
-	key := fmt.Sprintf("block:%d", number)
+func (r *redisService) GetBlock(ctx context.Context, network string, number uint64) (*domain.BlockInfo, error) {
+	key := fmt.Sprintf("block:%s:%d", network, number)
 	var block domain.BlockInfo
-	err := r.GetJSON(key, &block)
+	err := r.GetJSON(ctx, key, &block)
 	if err != nil {
 		return nil, err
 	}
 	return &block, nil
-}
\ No newline at end of file
+}
+
+// DeleteBlock 删除缓存的区块信息，链重组时调用
+func (r *redisService) DeleteBlock(ctx context.Context, network string, number uint64) error {
+	key := fmt.Sprintf("block:%s:%d", network, number)
+	return r.Del(ctx, key)
+}
+
+// SetNetworkStatus 缓存网络状态
+func (r *redisService) SetNetworkStatus(ctx context.Context, network string, status *domain.BlockchainStatus, expiration time.Duration) error {
+	key := fmt.Sprintf("network_status:%s", network)
+	return r.Set(ctx, key, status, expiration)
+}
+
+// GetNetworkStatus 获取缓存的网络状态
+func (r *redisService) GetNetworkStatus(ctx context.Context, network string) (*domain.BlockchainStatus, error) {
+	key := fmt.Sprintf("network_status:%s", network)
+	var status domain.BlockchainStatus
+	err := r.GetJSON(ctx, key, &status)
+	if err != nil {
+		return nil, err
+	}
+	return &status, nil
+}
+
+// SetPrice 缓存代币的USD价格
+func (r *redisService) SetPrice(ctx context.Context, symbol string, price string, expiration time.Duration) error {
+	key := fmt.Sprintf("price:%s", symbol)
+	return r.Set(ctx, key, price, expiration)
+}
+
+// GetPrice 获取缓存的USD价格
+func (r *redisService) GetPrice(ctx context.Context, symbol string) (string, error) {
+	key := fmt.Sprintf("price:%s", symbol)
+	var price string
+	err := r.GetJSON(ctx, key, &price)
+	if err != nil {
+		return "", err
+	}
+	return price, nil
+}
@@ -0,0 +1,63 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient/simulated"
+	"github.com/littlecheny/go-backend/domain"
+)
+
+// SimulatedChainID go-ethereum模拟链固定使用的链ID
+const SimulatedChainID = 1337
+
+// NewSimulatedEthereumService 在进程内运行go-ethereum模拟链，alloc为创世区块中预置余额的账户；
+// blockPeriod大于0时按间隔自动出块，为0时需要调用返回的Backend.Commit出块。
+// Disconnect会关闭模拟链，之后不能再使用
+func NewSimulatedEthereumService(alloc types.GenesisAlloc, blockPeriod time.Duration) (domain.EthereumService, *simulated.Backend, error) {
+	backend := simulated.NewBackend(alloc)
+	client := backend.Client()
+
+	chainID, err := client.ChainID(context.Background())
+	if err != nil {
+		backend.Close()
+		return nil, nil, fmt.Errorf("failed to get simulated chain ID: %v", err)
+	}
+
+	stop := make(chan struct{})
+	var wg sync.WaitGroup
+	if blockPeriod > 0 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ticker := time.NewTicker(blockPeriod)
+			defer ticker.Stop()
+
+			for {
+				select {
+				case <-stop:
+					return
+				case <-ticker.C:
+					backend.Commit()
+				}
+			}
+		}()
+	}
+
+	service := &ethereumService{}
+	service.conn.Store(&ethConnection{
+		client:    client,
+		networkID: chainID.Int64(),
+		rpcURL:    "simulated",
+		close: func() {
+			// 先停止出块，Close之后再Commit会panic
+			close(stop)
+			wg.Wait()
+			backend.Close()
+		},
+	})
+	return service, backend, nil
+}
@@ -0,0 +1,69 @@
+package services
+
+import (
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/littlecheny/go-backend/domain"
+)
+
+type tokenRegistry struct {
+	tokens map[string]map[common.Address]domain.Token
+}
+
+// NewTokenRegistry 同一网络中重复的合约地址以后出现的为准
+func NewTokenRegistry(tokens []domain.Token) domain.TokenRegistry {
+	registry := &tokenRegistry{tokens: make(map[string]map[common.Address]domain.Token)}
+	for _, token := range tokens {
+		address := common.HexToAddress(token.ContractAddress)
+		token.ContractAddress = address.Hex()
+
+		if registry.tokens[token.Network] == nil {
+			registry.tokens[token.Network] = make(map[common.Address]domain.Token)
+		}
+		registry.tokens[token.Network][address] = token
+	}
+	return registry
+}
+
+// GetTokens 按代币符号排序
+func (tr *tokenRegistry) GetTokens(network string) []domain.Token {
+	tokens := make([]domain.Token, 0, len(tr.tokens[network]))
+	for _, token := range tr.tokens[network] {
+		tokens = append(tokens, token)
+	}
+	sortTokens(tokens)
+	return tokens
+}
+
+func (tr *tokenRegistry) GetToken(network, contractAddress string) (*domain.Token, error) {
+	if !common.IsHexAddress(contractAddress) {
+		return nil, domain.ErrTokenNotFound
+	}
+
+	token, ok := tr.tokens[network][common.HexToAddress(contractAddress)]
+	if !ok {
+		return nil, domain.ErrTokenNotFound
+	}
+	return &token, nil
+}
+
+func (tr *tokenRegistry) GetAllTokens() []domain.Token {
+	var tokens []domain.Token
+	for _, networkTokens := range tr.tokens {
+		for _, token := range networkTokens {
+			tokens = append(tokens, token)
+		}
+	}
+	sortTokens(tokens)
+	return tokens
+}
+
+func sortTokens(tokens []domain.Token) {
+	sort.Slice(tokens, func(i, j int) bool {
+		if tokens[i].Network != tokens[j].Network {
+			return tokens[i].Network < tokens[j].Network
+		}
+		return tokens[i].Symbol < tokens[j].Symbol
+	})
+}
@@ -0,0 +1,35 @@
+package services_test
+
+import (
+	"testing"
+
+	"github.com/littlecheny/go-backend/domain"
+	"github.com/littlecheny/go-backend/services"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTokenRegistry(t *testing.T) {
+	registry := services.NewTokenRegistry([]domain.Token{
+		{Network: "mainnet", Symbol: "USDT", ContractAddress: "0xdac17f958d2ee523a2206206994597c13d831ec7", Decimals: 6},
+		{Network: "mainnet", Symbol: "DAI", ContractAddress: "0x6B175474E89094C44Da98b954EedeAC495271d0F", Decimals: 18},
+		{Network: "sepolia", Symbol: "USDC", ContractAddress: "0x1c7D4B196Cb0C7B01d743Fbc6116a902379C7238", Decimals: 6},
+	})
+
+	token, err := registry.GetToken("mainnet", "0xDAC17F958D2EE523A2206206994597C13D831EC7")
+	assert.NoError(t, err)
+	assert.Equal(t, "USDT", token.Symbol)
+	assert.Equal(t, "0xdAC17F958D2ee523a2206206994597C13D831ec7", token.ContractAddress)
+
+	_, err = registry.GetToken("sepolia", "0xdAC17F958D2ee523a2206206994597C13D831ec7")
+	assert.ErrorIs(t, err, domain.ErrTokenNotFound)
+
+	_, err = registry.GetToken("mainnet", "not-an-address")
+	assert.ErrorIs(t, err, domain.ErrTokenNotFound)
+
+	mainnet := registry.GetTokens("mainnet")
+	assert.Len(t, mainnet, 2)
+	assert.Equal(t, "DAI", mainnet[0].Symbol)
+
+	assert.Len(t, registry.GetAllTokens(), 3)
+	assert.Empty(t, registry.GetTokens("goerli"))
+}
@@ -0,0 +1,155 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/littlecheny/go-backend/domain"
+	"github.com/littlecheny/go-backend/internal/units"
+)
+
+// blockReceiptsReader eth_getBlockReceipts一次返回整个区块的收据，*ethclient.Client实现了该方法
+type blockReceiptsReader interface {
+	BlockReceipts(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) ([]*types.Receipt, error)
+}
+
+// decodeTransaction 解析链上交易，发送方从签名中恢复；receipt为nil表示交易还在交易池中，
+// timestamp为交易所在区块的时间
+func decodeTransaction(tx *types.Transaction, receipt *types.Receipt, timestamp time.Time) (*domain.TransactionResponse, error) {
+	from, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to recover sender of %s: %v", tx.Hash().Hex(), err)
+	}
+
+	response := &domain.TransactionResponse{
+		Hash:      tx.Hash().Hex(),
+		From:      from.Hex(),
+		Value:     units.FormatEther(tx.Value()),
+		GasPrice:  units.FormatGwei(tx.GasPrice()),
+		FeeModel:  domain.FeeModelLegacy,
+		GasLimit:  tx.Gas(),
+		Nonce:     tx.Nonce(),
+		Status:    domain.TransactionStatusPending,
+		CreatedAt: timestamp,
+	}
+	// 合约创建交易没有to
+	if tx.To() != nil {
+		response.To = tx.To().Hex()
+	}
+	// type-2及之后的交易类型都使用maxFeePerGas和maxPriorityFeePerGas
+	if tx.Type() != types.LegacyTxType && tx.Type() != types.AccessListTxType {
+		response.FeeModel = domain.FeeModelEIP1559
+		response.MaxFeePerGas = units.FormatGwei(tx.GasFeeCap())
+		response.MaxPriorityFeePerGas = units.FormatGwei(tx.GasTipCap())
+	}
+
+	if receipt == nil {
+		return response, nil
+	}
+
+	response.Status = receiptStatus(receipt)
+	response.BlockNumber = receipt.BlockNumber.Uint64()
+	response.GasUsed = receipt.GasUsed
+	response.Logs = decodeLogs(receipt.Logs)
+
+	effectiveGasPrice := receipt.EffectiveGasPrice
+	if effectiveGasPrice == nil {
+		effectiveGasPrice = tx.GasPrice()
+	}
+	fee := new(big.Int).Mul(effectiveGasPrice, new(big.Int).SetUint64(receipt.GasUsed))
+	response.TransactionFee = units.FormatEther(fee)
+
+	return response, nil
+}
+
+func receiptStatus(receipt *types.Receipt) domain.TransactionStatus {
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return domain.TransactionStatusFailed
+	}
+	return domain.TransactionStatusConfirmed
+}
+
+func decodeLogs(logs []*types.Log) []domain.TransactionLog {
+	if len(logs) == 0 {
+		return nil
+	}
+
+	decoded := make([]domain.TransactionLog, 0, len(logs))
+	for _, log := range logs {
+		topics := make([]string, 0, len(log.Topics))
+		for _, topic := range log.Topics {
+			topics = append(topics, topic.Hex())
+		}
+
+		decoded = append(decoded, domain.TransactionLog{
+			Address:  log.Address.Hex(),
+			Topics:   topics,
+			Data:     hexutil.Encode(log.Data),
+			LogIndex: log.Index,
+		})
+	}
+	return decoded
+}
+
+// GetBlockTransactions 节点支持eth_getBlockReceipts时一次取回全部收据，否则逐笔查询；
+// 无法恢复发送方的交易记录日志后跳过
+func (e *ethereumService) GetBlockTransactions(ctx context.Context, number uint64) ([]domain.TransactionResponse, error) {
+	conn, err := e.connection()
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := conn.client.BlockByNumber(ctx, new(big.Int).SetUint64(number))
+	if errors.Is(err, ethereum.NotFound) {
+		return nil, domain.ErrBlockNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get block: %v", err)
+	}
+
+	receipts, err := conn.blockReceipts(ctx, block)
+	if err != nil {
+		return nil, err
+	}
+
+	timestamp := time.Unix(int64(block.Time()), 0)
+	transactions := make([]domain.TransactionResponse, 0, len(block.Transactions()))
+	for i, tx := range block.Transactions() {
+		// 个别交易无法恢复发送方时跳过该交易，不影响区块中的其他交易
+		response, err := decodeTransaction(tx, receipts[i], timestamp)
+		if err != nil {
+			log.Printf("skipping transaction in block %d: %v", number, err)
+			continue
+		}
+		transactions = append(transactions, *response)
+	}
+	return transactions, nil
+}
+
+// blockReceipts 返回与block.Transactions()顺序一致的收据
+func (conn *ethConnection) blockReceipts(ctx context.Context, block *types.Block) ([]*types.Receipt, error) {
+	if reader, ok := conn.client.(blockReceiptsReader); ok {
+		receipts, err := reader.BlockReceipts(ctx, rpc.BlockNumberOrHashWithHash(block.Hash(), false))
+		if err == nil && len(receipts) == len(block.Transactions()) {
+			return receipts, nil
+		}
+	}
+
+	receipts := make([]*types.Receipt, 0, len(block.Transactions()))
+	for _, tx := range block.Transactions() {
+		receipt, err := conn.client.TransactionReceipt(ctx, tx.Hash())
+		if err != nil {
+			return nil, fmt.Errorf("failed to get transaction receipt: %v", err)
+		}
+		receipts = append(receipts, receipt)
+	}
+	return receipts, nil
+}
@@ -0,0 +1,125 @@
+package services
+
+import (
+	"encoding/base64"
+	"fmt"
+
+	"github.com/littlecheny/go-backend/domain"
+)
+
+type walletKeyManager struct {
+	masterKeys     map[int][]byte
+	currentVersion int
+	legacyKey      string
+}
+
+// NewWalletKeyManager masterKeys为版本号到256位主密钥的映射，新的数据密钥使用currentVersion包裹；
+// legacyKey为信封加密之前的WALLET_ENCRYPTION_KEY，为空时无法解密旧记录
+func NewWalletKeyManager(masterKeys map[int][]byte, currentVersion int, legacyKey string) (domain.WalletKeyManager, error) {
+	if _, ok := masterKeys[currentVersion]; !ok {
+		return nil, fmt.Errorf("master key version %d is not configured", currentVersion)
+	}
+	for version, key := range masterKeys {
+		if version <= 0 {
+			return nil, fmt.Errorf("invalid master key version %d", version)
+		}
+		if len(key) != 32 {
+			return nil, fmt.Errorf("master key version %d must be 32 bytes, got %d", version, len(key))
+		}
+	}
+
+	return &walletKeyManager{
+		masterKeys:     masterKeys,
+		currentVersion: currentVersion,
+		legacyKey:      legacyKey,
+	}, nil
+}
+
+func (m *walletKeyManager) CurrentVersion() int {
+	return m.currentVersion
+}
+
+// WrapDataKey 先用密码派生密钥加密数据密钥，再用当前主密钥加密
+func (m *walletKeyManager) WrapDataKey(dataKey string, passwordKey string) (string, int, error) {
+	dataKeyBytes, err := decodeAESKey(dataKey)
+	if err != nil {
+		return "", 0, err
+	}
+	passwordKeyBytes, err := decodeAESKey(passwordKey)
+	if err != nil {
+		return "", 0, err
+	}
+
+	inner, err := sealAESGCM(passwordKeyBytes, dataKeyBytes)
+	if err != nil {
+		return "", 0, err
+	}
+
+	outer, err := sealAESGCM(m.masterKeys[m.currentVersion], inner)
+	if err != nil {
+		return "", 0, err
+	}
+
+	return base64.StdEncoding.EncodeToString(outer), m.currentVersion, nil
+}
+
+// UnwrapDataKey 内层解密失败说明密码错误，返回ErrInvalidPassword
+func (m *walletKeyManager) UnwrapDataKey(wrappedKey string, version int, passwordKey string) (string, error) {
+	inner, err := m.openMaster(wrappedKey, version)
+	if err != nil {
+		return "", err
+	}
+
+	passwordKeyBytes, err := decodeAESKey(passwordKey)
+	if err != nil {
+		return "", err
+	}
+
+	dataKey, err := openAESGCM(passwordKeyBytes, inner)
+	if err != nil {
+		return "", domain.ErrInvalidPassword
+	}
+
+	return base64.StdEncoding.EncodeToString(dataKey), nil
+}
+
+// RewrapDataKey 用当前主密钥重新包裹外层，内层的密码加密保持不变
+func (m *walletKeyManager) RewrapDataKey(wrappedKey string, version int) (string, int, error) {
+	if version == m.currentVersion {
+		return wrappedKey, version, nil
+	}
+
+	inner, err := m.openMaster(wrappedKey, version)
+	if err != nil {
+		return "", 0, err
+	}
+
+	outer, err := sealAESGCM(m.masterKeys[m.currentVersion], inner)
+	if err != nil {
+		return "", 0, err
+	}
+
+	return base64.StdEncoding.EncodeToString(outer), m.currentVersion, nil
+}
+
+// LegacyKey 旧记录的加密密钥由WALLET_ENCRYPTION_KEY和密码派生密钥拼接而成
+func (m *walletKeyManager) LegacyKey(passwordKey string) (string, error) {
+	if m.legacyKey == "" {
+		return "", domain.ErrLegacyKeyNotFound
+	}
+	return m.legacyKey + passwordKey, nil
+}
+
+func (m *walletKeyManager) openMaster(wrappedKey string, version int) ([]byte, error) {
+	masterKey, ok := m.masterKeys[version]
+	if !ok {
+		return nil, fmt.Errorf("%w: %d", domain.ErrMasterKeyNotFound, version)
+	}
+
+	outer, err := base64.StdEncoding.DecodeString(wrappedKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode wrapped key: %v", err)
+	}
+
+	return openAESGCM(masterKey, outer)
+}
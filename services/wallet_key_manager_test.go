@@ -0,0 +1,107 @@
+package services_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/littlecheny/go-backend/domain"
+	"github.com/littlecheny/go-backend/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWalletKeyManager(t *testing.T) {
+	crypto := services.NewCryptoService()
+
+	salt, err := crypto.GenerateSalt()
+	require.NoError(t, err)
+	passwordKey, err := crypto.DeriveKey("password123", salt)
+	require.NoError(t, err)
+	wrongPasswordKey, err := crypto.DeriveKey("password456", salt)
+	require.NoError(t, err)
+
+	dataKey, err := crypto.GenerateDataKey()
+	require.NoError(t, err)
+	encryptedKey, err := crypto.EncryptWithDataKey("private-key", dataKey)
+	require.NoError(t, err)
+
+	v1, err := services.NewWalletKeyManager(map[int][]byte{1: bytes.Repeat([]byte{1}, 32)}, 1, "")
+	require.NoError(t, err)
+
+	wrapped, version, err := v1.WrapDataKey(dataKey, passwordKey)
+	require.NoError(t, err)
+	assert.Equal(t, 1, version)
+
+	t.Run("unwrap", func(t *testing.T) {
+		unwrapped, err := v1.UnwrapDataKey(wrapped, version, passwordKey)
+		require.NoError(t, err)
+
+		privateKey, err := crypto.DecryptWithDataKey(encryptedKey, unwrapped)
+		require.NoError(t, err)
+		assert.Equal(t, "private-key", privateKey)
+	})
+
+	t.Run("wrong password", func(t *testing.T) {
+		_, err := v1.UnwrapDataKey(wrapped, version, wrongPasswordKey)
+		assert.ErrorIs(t, err, domain.ErrInvalidPassword)
+	})
+
+	t.Run("rotate master key", func(t *testing.T) {
+		v2, err := services.NewWalletKeyManager(map[int][]byte{
+			1: bytes.Repeat([]byte{1}, 32),
+			2: bytes.Repeat([]byte{2}, 32),
+		}, 2, "")
+		require.NoError(t, err)
+
+		// 轮换后旧版本仍可解包
+		unwrapped, err := v2.UnwrapDataKey(wrapped, version, passwordKey)
+		require.NoError(t, err)
+		assert.Equal(t, dataKey, unwrapped)
+
+		// 重新包裹不需要密码，数据密钥不变，私钥密文无需更新
+		rewrapped, newVersion, err := v2.RewrapDataKey(wrapped, version)
+		require.NoError(t, err)
+		assert.Equal(t, 2, newVersion)
+		assert.NotEqual(t, wrapped, rewrapped)
+
+		unwrapped, err = v2.UnwrapDataKey(rewrapped, newVersion, passwordKey)
+		require.NoError(t, err)
+		assert.Equal(t, dataKey, unwrapped)
+
+		// 主密钥1下线后，未迁移的记录无法解包
+		v3, err := services.NewWalletKeyManager(map[int][]byte{2: bytes.Repeat([]byte{2}, 32)}, 2, "")
+		require.NoError(t, err)
+
+		_, err = v3.UnwrapDataKey(wrapped, version, passwordKey)
+		assert.ErrorIs(t, err, domain.ErrMasterKeyNotFound)
+
+		unwrapped, err = v3.UnwrapDataKey(rewrapped, newVersion, passwordKey)
+		require.NoError(t, err)
+		assert.Equal(t, dataKey, unwrapped)
+	})
+
+	t.Run("legacy key", func(t *testing.T) {
+		legacy, err := crypto.Encrypt("private-key", "legacy-secret"+passwordKey)
+		require.NoError(t, err)
+
+		withLegacy, err := services.NewWalletKeyManager(map[int][]byte{1: bytes.Repeat([]byte{1}, 32)}, 1, "legacy-secret")
+		require.NoError(t, err)
+
+		key, err := withLegacy.LegacyKey(passwordKey)
+		require.NoError(t, err)
+		privateKey, err := crypto.Decrypt(legacy, key)
+		require.NoError(t, err)
+		assert.Equal(t, "private-key", privateKey)
+
+		_, err = v1.LegacyKey(passwordKey)
+		assert.ErrorIs(t, err, domain.ErrLegacyKeyNotFound)
+	})
+
+	t.Run("invalid config", func(t *testing.T) {
+		_, err := services.NewWalletKeyManager(map[int][]byte{1: bytes.Repeat([]byte{1}, 32)}, 2, "")
+		assert.Error(t, err)
+
+		_, err = services.NewWalletKeyManager(map[int][]byte{1: []byte("short")}, 1, "")
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,178 @@
+package usecase
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/littlecheny/go-backend/domain"
+)
+
+const (
+	defaultBlockLimit = 10
+	maxBlockLimit     = 50
+	// blockCacheTTL 链重组时ChainFollower会删除失效的区块缓存，已缓存的区块可以保留较长时间；
+	// 每个网络都运行ChainFollower，启动后才连接的网络也不例外
+	blockCacheTTL = 10 * time.Minute
+	// statusCacheTTL 网络状态随出块变化，只缓存较短时间以减少RPC请求
+	statusCacheTTL = 5 * time.Second
+)
+
+type blockchainUsecase struct {
+	networks       domain.NetworkRegistry
+	cache          domain.RedisService
+	contextTimeout time.Duration
+}
+
+func NewBlockchainUsecase(networks domain.NetworkRegistry, cache domain.RedisService, timeout time.Duration) domain.BlockchainUsecase {
+	return &blockchainUsecase{
+		networks:       networks,
+		cache:          cache,
+		contextTimeout: timeout,
+	}
+}
+
+// GetNetworkStatus 优先返回缓存的状态，缓存过期后查询节点
+func (bu *blockchainUsecase) GetNetworkStatus(c context.Context, network string) (*domain.BlockchainStatus, error) {
+	ctx, cancel := context.WithTimeout(c, bu.contextTimeout)
+	defer cancel()
+
+	ethereumService, err := bu.networks.GetClient(network)
+	if err != nil {
+		return nil, err
+	}
+
+	cached, err := bu.cache.GetNetworkStatus(ctx, network)
+	if err == nil {
+		return cached, nil
+	}
+
+	latest, err := ethereumService.GetLatestBlock(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	gasPrice, err := ethereumService.GetGasPrice(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &domain.BlockchainStatus{
+		Network:     network,
+		LatestBlock: latest.Number,
+		GasPrice:    gasPrice,
+		LastUpdated: time.Now(),
+	}
+
+	err = bu.cache.SetNetworkStatus(ctx, network, status, statusCacheTTL)
+	if err != nil {
+		log.Printf("failed to cache status of %s: %v", network, err)
+	}
+	bu.cacheBlock(ctx, network, latest)
+
+	return status, nil
+}
+
+// GetLatestBlocks 从最新区块开始按区块号倒序返回，最新区块之前的区块优先从缓存读取
+func (bu *blockchainUsecase) GetLatestBlocks(c context.Context, network string, limit int) ([]domain.BlockInfo, error) {
+	ctx, cancel := context.WithTimeout(c, bu.contextTimeout)
+	defer cancel()
+
+	if limit < 1 {
+		limit = defaultBlockLimit
+	}
+	if limit > maxBlockLimit {
+		limit = maxBlockLimit
+	}
+
+	ethereumService, err := bu.networks.GetClient(network)
+	if err != nil {
+		return nil, err
+	}
+
+	latest, err := ethereumService.GetLatestBlock(ctx)
+	if err != nil {
+		return nil, err
+	}
+	bu.cacheBlock(ctx, network, latest)
+
+	blocks := []domain.BlockInfo{*latest}
+	for number := latest.Number; number > 0 && len(blocks) < limit; {
+		number--
+		block, err := bu.getBlock(ctx, ethereumService, network, number)
+		if err != nil {
+			return nil, err
+		}
+		blocks = append(blocks, *block)
+	}
+	return blocks, nil
+}
+
+func (bu *blockchainUsecase) GetBlock(c context.Context, network string, number uint64) (*domain.BlockInfo, error) {
+	ctx, cancel := context.WithTimeout(c, bu.contextTimeout)
+	defer cancel()
+
+	ethereumService, err := bu.networks.GetClient(network)
+	if err != nil {
+		return nil, err
+	}
+
+	return bu.getBlock(ctx, ethereumService, network, number)
+}
+
+func (bu *blockchainUsecase) GetBlockTransactions(c context.Context, network string, number uint64) ([]domain.TransactionResponse, error) {
+	ctx, cancel := context.WithTimeout(c, bu.contextTimeout)
+	defer cancel()
+
+	ethereumService, err := bu.networks.GetClient(network)
+	if err != nil {
+		return nil, err
+	}
+
+	transactions, err := ethereumService.GetBlockTransactions(ctx, number)
+	if err != nil {
+		return nil, err
+	}
+	for i := range transactions {
+		transactions[i].Network = network
+	}
+	return transactions, nil
+}
+
+// getBlock 缓存未命中时查询节点并写入缓存
+func (bu *blockchainUsecase) getBlock(ctx context.Context, ethereumService domain.EthereumService, network string, number uint64) (*domain.BlockInfo, error) {
+	cached, err := bu.cache.GetBlock(ctx, network, number)
+	if err == nil {
+		return cached, nil
+	}
+
+	block, err := ethereumService.GetBlockByNumber(ctx, number)
+	if err != nil {
+		return nil, err
+	}
+	bu.cacheBlock(ctx, network, block)
+	return block, nil
+}
+
+// cacheBlock 缓存失败只记录日志，不影响查询结果
+func (bu *blockchainUsecase) cacheBlock(ctx context.Context, network string, block *domain.BlockInfo) {
+	err := bu.cache.SetBlock(ctx, network, block.Number, block, blockCacheTTL)
+	if err != nil {
+		log.Printf("failed to cache block %d of %s: %v", block.Number, network, err)
+	}
+}
+
+// GetSupportedNetworks 返回注册表中的网络，去掉RPC地址
+func (bu *blockchainUsecase) GetSupportedNetworks(c context.Context) ([]domain.NetworkConfig, error) {
+	networks := bu.networks.GetNetworks()
+	for i := range networks {
+		networks[i].RPC = ""
+	}
+	return networks, nil
+}
+
+// SwitchNetwork 所有网络同时保持连接，只需校验网络是否受支持
+func (bu *blockchainUsecase) SwitchNetwork(c context.Context, network string) error {
+	_, err := bu.networks.GetNetwork(network)
+	return err
+}
@@ -0,0 +1,112 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/littlecheny/go-backend/domain"
+	"github.com/littlecheny/go-backend/domain/mocks"
+	"github.com/littlecheny/go-backend/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetSupportedNetworks(t *testing.T) {
+	mockNetworkRegistry := new(mocks.NetworkRegistry)
+	mockNetworkRegistry.On("GetNetworks").Return([]domain.NetworkConfig{
+		{Name: "mainnet", ChainID: 1, RPC: "https://mainnet.infura.io/v3/secret"},
+		{Name: "sepolia", ChainID: 11155111, RPC: "https://sepolia.infura.io/v3/secret"},
+	}).Once()
+
+	u := usecase.NewBlockchainUsecase(mockNetworkRegistry, new(mocks.RedisService), time.Second*2)
+
+	networks, err := u.GetSupportedNetworks(context.Background())
+
+	assert.NoError(t, err)
+	assert.Len(t, networks, 2)
+	assert.Equal(t, "mainnet", networks[0].Name)
+	assert.Empty(t, networks[0].RPC)
+	assert.Empty(t, networks[1].RPC)
+
+	mockNetworkRegistry.AssertExpectations(t)
+}
+
+func TestGetLatestBlocks(t *testing.T) {
+	mockEthereumService := new(mocks.EthereumService)
+	mockRedisService := new(mocks.RedisService)
+
+	mockEthereumService.On("GetLatestBlock", mock.Anything).Return(&domain.BlockInfo{Number: 2}, nil).Once()
+	mockRedisService.On("SetBlock", mock.Anything, "sepolia", uint64(2), mock.Anything, mock.Anything).Return(nil).Once()
+	// 区块1已缓存，区块0需要查询节点
+	mockRedisService.On("GetBlock", mock.Anything, "sepolia", uint64(1)).Return(&domain.BlockInfo{Number: 1, Hash: "0xcached"}, nil).Once()
+	mockRedisService.On("GetBlock", mock.Anything, "sepolia", uint64(0)).Return(nil, errors.New("key block:sepolia:0 not found")).Once()
+	mockEthereumService.On("GetBlockByNumber", mock.Anything, uint64(0)).Return(&domain.BlockInfo{Number: 0}, nil).Once()
+	mockRedisService.On("SetBlock", mock.Anything, "sepolia", uint64(0), mock.Anything, mock.Anything).Return(nil).Once()
+
+	u := usecase.NewBlockchainUsecase(networkRegistry(mockEthereumService), mockRedisService, time.Second*2)
+
+	// 链上只有3个区块
+	blocks, err := u.GetLatestBlocks(context.Background(), "sepolia", 5)
+
+	assert.NoError(t, err)
+	assert.Len(t, blocks, 3)
+	assert.Equal(t, uint64(2), blocks[0].Number)
+	assert.Equal(t, "0xcached", blocks[1].Hash)
+	assert.Equal(t, uint64(0), blocks[2].Number)
+
+	mockEthereumService.AssertExpectations(t)
+	mockRedisService.AssertExpectations(t)
+}
+
+func TestGetNetworkStatus(t *testing.T) {
+	t.Run("cached", func(t *testing.T) {
+		mockRedisService := new(mocks.RedisService)
+		mockRedisService.On("GetNetworkStatus", mock.Anything, "sepolia").Return(&domain.BlockchainStatus{Network: "sepolia", LatestBlock: 100}, nil).Once()
+
+		u := usecase.NewBlockchainUsecase(networkRegistry(new(mocks.EthereumService)), mockRedisService, time.Second*2)
+
+		status, err := u.GetNetworkStatus(context.Background(), "sepolia")
+
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(100), status.LatestBlock)
+
+		mockRedisService.AssertExpectations(t)
+	})
+
+	t.Run("cache miss", func(t *testing.T) {
+		mockEthereumService := new(mocks.EthereumService)
+		mockRedisService := new(mocks.RedisService)
+
+		mockRedisService.On("GetNetworkStatus", mock.Anything, "sepolia").Return(nil, errors.New("key network_status:sepolia not found")).Once()
+		mockEthereumService.On("GetLatestBlock", mock.Anything).Return(&domain.BlockInfo{Number: 101}, nil).Once()
+		mockEthereumService.On("GetGasPrice", mock.Anything).Return("1.5", nil).Once()
+		mockRedisService.On("SetNetworkStatus", mock.Anything, "sepolia", mock.MatchedBy(func(status *domain.BlockchainStatus) bool {
+			return status.LatestBlock == 101 && status.GasPrice == "1.5"
+		}), mock.Anything).Return(nil).Once()
+		mockRedisService.On("SetBlock", mock.Anything, "sepolia", uint64(101), mock.Anything, mock.Anything).Return(nil).Once()
+
+		u := usecase.NewBlockchainUsecase(networkRegistry(mockEthereumService), mockRedisService, time.Second*2)
+
+		status, err := u.GetNetworkStatus(context.Background(), "sepolia")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "sepolia", status.Network)
+		assert.Equal(t, uint64(101), status.LatestBlock)
+
+		mockEthereumService.AssertExpectations(t)
+		mockRedisService.AssertExpectations(t)
+	})
+
+	t.Run("unknown network", func(t *testing.T) {
+		mockNetworkRegistry := new(mocks.NetworkRegistry)
+		mockNetworkRegistry.On("GetClient", "unknown").Return(nil, domain.ErrNetworkNotSupported).Once()
+
+		u := usecase.NewBlockchainUsecase(mockNetworkRegistry, new(mocks.RedisService), time.Second*2)
+
+		_, err := u.GetNetworkStatus(context.Background(), "unknown")
+
+		assert.ErrorIs(t, err, domain.ErrNetworkNotSupported)
+	})
+}
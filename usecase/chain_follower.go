@@ -0,0 +1,197 @@
+package usecase
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/littlecheny/go-backend/domain"
+)
+
+const (
+	// maxReorgDepth 记录的最近区块数，也是向前查找分叉点的最大深度
+	maxReorgDepth    = 64
+	resubscribeDelay = 5 * time.Second
+)
+
+type chainFollower struct {
+	network               string
+	transactionRepository domain.TransactionRepository
+	ethereumService       domain.EthereumService
+	cache                 domain.RedisService
+	contextTimeout        time.Duration
+
+	mu      sync.Mutex
+	recent  map[uint64]string // 区块号 -> 当前认为是主链的区块哈希
+	highest uint64
+}
+
+// NewChainFollower 每个网络各自运行一个ChainFollower
+func NewChainFollower(network string, transactionRepository domain.TransactionRepository, ethereumService domain.EthereumService, cache domain.RedisService, timeout time.Duration) domain.ChainFollower {
+	return &chainFollower{
+		network:               network,
+		transactionRepository: transactionRepository,
+		ethereumService:       ethereumService,
+		cache:                 cache,
+		contextTimeout:        timeout,
+		recent:                make(map[uint64]string),
+	}
+}
+
+// Run 节点连接后订阅新区块头，订阅断开后等待resubscribeDelay重新订阅，直到ctx取消
+func (cf *chainFollower) Run(ctx context.Context) error {
+	for waitConnected(ctx, cf.ethereumService) {
+		heads, err := cf.ethereumService.SubscribeNewHeads(ctx)
+		if err != nil {
+			// 检查之后连接又断开时继续等待重连
+			if !cf.ethereumService.IsConnected() {
+				continue
+			}
+			return err
+		}
+
+		for head := range heads {
+			err = cf.HandleHead(ctx, head)
+			if err != nil {
+				log.Printf("failed to handle block %d: %v", head.Number, err)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(resubscribeDelay):
+		}
+	}
+	return nil
+}
+
+// waitConnected 网络可能在启动后才按需连接，未连接时每隔resubscribeDelay检查一次；ctx取消时返回false
+func waitConnected(ctx context.Context, ethereumService domain.EthereumService) bool {
+	for !ethereumService.IsConnected() {
+		select {
+		case <-ctx.Done():
+			return false
+		case <-time.After(resubscribeDelay):
+		}
+	}
+	return true
+}
+
+// HandleHead 沿ParentHash向前比对已记录的区块哈希，找到分叉点后回滚分叉点之后的区块
+func (cf *chainFollower) HandleHead(c context.Context, head *domain.BlockInfo) error {
+	ctx, cancel := context.WithTimeout(c, cf.contextTimeout)
+	defer cancel()
+
+	cf.mu.Lock()
+	defer cf.mu.Unlock()
+
+	if len(cf.recent) == 0 || head.Number == 0 {
+		cf.record(head.Number, head.Hash)
+		return nil
+	}
+
+	// 新区块头不高于已知链头时，已知链头之上的区块都已被替换
+	reorged := head.Number <= cf.highest && cf.recent[head.Number] != head.Hash
+	canonical := make(map[uint64]string)
+
+	fork := head.Number - 1
+	parentHash := head.ParentHash
+	for depth := 0; fork > 0 && depth < maxReorgDepth; depth++ {
+		known, ok := cf.recent[fork]
+		if ok && known == parentHash {
+			break
+		}
+		// 超出记录窗口，无法继续比对
+		if !ok && fork <= cf.highest {
+			break
+		}
+		if ok {
+			reorged = true
+		}
+
+		// 可能跳过了若干区块，或者该高度的区块已被替换，取当前主链区块继续向前比对
+		block, err := cf.ethereumService.GetBlockByNumber(ctx, fork)
+		if err != nil {
+			return err
+		}
+		canonical[fork] = parentHash
+		parentHash = block.ParentHash
+		fork--
+	}
+
+	if reorged {
+		top := cf.highest
+		if head.Number > top {
+			top = head.Number
+		}
+		log.Printf("chain reorganization detected at block %d, rolling back blocks %d-%d", head.Number, fork+1, top)
+
+		err := cf.rollback(ctx, fork, top)
+		if err != nil {
+			return err
+		}
+		for number := range cf.recent {
+			if number > fork {
+				delete(cf.recent, number)
+			}
+		}
+		cf.highest = fork
+	}
+
+	for number, hash := range canonical {
+		cf.record(number, hash)
+	}
+	cf.record(head.Number, head.Hash)
+	return nil
+}
+
+// rollback 清除(fork, top]区间的区块缓存，并把这些区块中的交易改回pending等待重新打包
+func (cf *chainFollower) rollback(ctx context.Context, fork, top uint64) error {
+	for number := fork + 1; number <= top; number++ {
+		err := cf.cache.DeleteBlock(ctx, cf.network, number)
+		if err != nil {
+			log.Printf("failed to evict cached block %d: %v", number, err)
+		}
+	}
+
+	transactions, err := cf.transactionRepository.GetMinedAfter(ctx, cf.network, fork)
+	if err != nil {
+		return err
+	}
+
+	for i := range transactions {
+		transaction := &transactions[i]
+		transaction.Status = domain.TransactionStatusPending
+		transaction.GasUsed = 0
+		transaction.BlockNumber = 0
+		transaction.BlockHash = ""
+		transaction.TransactionFee = ""
+		transaction.ConfirmedAt = nil
+
+		err = cf.transactionRepository.Update(ctx, transaction)
+		if err != nil {
+			return err
+		}
+
+		err = cf.cache.DeleteTransaction(ctx, transaction.Hash)
+		if err != nil {
+			log.Printf("failed to invalidate cached transaction %s: %v", transaction.Hash, err)
+		}
+	}
+	return nil
+}
+
+// record 记录主链区块并只保留最近maxReorgDepth个
+func (cf *chainFollower) record(number uint64, hash string) {
+	cf.recent[number] = hash
+	if number > cf.highest {
+		cf.highest = number
+	}
+	for n := range cf.recent {
+		if n+maxReorgDepth <= cf.highest {
+			delete(cf.recent, n)
+		}
+	}
+}
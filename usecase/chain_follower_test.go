@@ -0,0 +1,100 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/littlecheny/go-backend/domain"
+	"github.com/littlecheny/go-backend/domain/mocks"
+	"github.com/littlecheny/go-backend/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestHandleHead(t *testing.T) {
+	t.Run("continuous chain", func(t *testing.T) {
+		mockTransactionRepository := new(mocks.TransactionRepository)
+		mockEthereumService := new(mocks.EthereumService)
+		mockRedisService := new(mocks.RedisService)
+
+		follower := usecase.NewChainFollower("sepolia", mockTransactionRepository, mockEthereumService, mockRedisService, time.Second*2)
+
+		assert.NoError(t, follower.HandleHead(context.Background(), &domain.BlockInfo{Number: 100, Hash: "0xa100", ParentHash: "0xa99"}))
+		assert.NoError(t, follower.HandleHead(context.Background(), &domain.BlockInfo{Number: 101, Hash: "0xa101", ParentHash: "0xa100"}))
+		assert.NoError(t, follower.HandleHead(context.Background(), &domain.BlockInfo{Number: 102, Hash: "0xa102", ParentHash: "0xa101"}))
+
+		mockTransactionRepository.AssertExpectations(t)
+		mockEthereumService.AssertExpectations(t)
+		mockRedisService.AssertExpectations(t)
+	})
+
+	t.Run("skipped blocks", func(t *testing.T) {
+		mockEthereumService := new(mocks.EthereumService)
+		mockEthereumService.On("GetBlockByNumber", mock.Anything, uint64(102)).Return(&domain.BlockInfo{Number: 102, Hash: "0xa102", ParentHash: "0xa101"}, nil).Once()
+		mockEthereumService.On("GetBlockByNumber", mock.Anything, uint64(101)).Return(&domain.BlockInfo{Number: 101, Hash: "0xa101", ParentHash: "0xa100"}, nil).Once()
+
+		follower := usecase.NewChainFollower("sepolia", new(mocks.TransactionRepository), mockEthereumService, new(mocks.RedisService), time.Second*2)
+
+		assert.NoError(t, follower.HandleHead(context.Background(), &domain.BlockInfo{Number: 100, Hash: "0xa100", ParentHash: "0xa99"}))
+		assert.NoError(t, follower.HandleHead(context.Background(), &domain.BlockInfo{Number: 103, Hash: "0xa103", ParentHash: "0xa102"}))
+
+		mockEthereumService.AssertExpectations(t)
+	})
+
+	t.Run("reorg", func(t *testing.T) {
+		reorged := domain.Transaction{
+			ID:             primitive.NewObjectID(),
+			Hash:           "0xhash",
+			Status:         domain.TransactionStatusConfirmed,
+			BlockNumber:    101,
+			BlockHash:      "0xa101",
+			GasUsed:        21000,
+			TransactionFee: "21000000000000",
+		}
+
+		mockTransactionRepository := new(mocks.TransactionRepository)
+		mockEthereumService := new(mocks.EthereumService)
+		mockRedisService := new(mocks.RedisService)
+
+		mockEthereumService.On("GetBlockByNumber", mock.Anything, uint64(101)).Return(&domain.BlockInfo{Number: 101, Hash: "0xb101", ParentHash: "0xa100"}, nil).Once()
+		mockRedisService.On("DeleteBlock", mock.Anything, "sepolia", uint64(101)).Return(nil).Once()
+		mockRedisService.On("DeleteBlock", mock.Anything, "sepolia", uint64(102)).Return(nil).Once()
+		mockTransactionRepository.On("GetMinedAfter", mock.Anything, "sepolia", uint64(100)).Return([]domain.Transaction{reorged}, nil).Once()
+		mockTransactionRepository.On("Update", mock.Anything, mock.MatchedBy(func(tx *domain.Transaction) bool {
+			return tx.Hash == "0xhash" && tx.Status == domain.TransactionStatusPending && tx.BlockNumber == 0 &&
+				tx.BlockHash == "" && tx.GasUsed == 0 && tx.TransactionFee == "" && tx.ConfirmedAt == nil
+		})).Return(nil).Once()
+		mockRedisService.On("DeleteTransaction", mock.Anything, "0xhash").Return(nil).Once()
+
+		follower := usecase.NewChainFollower("sepolia", mockTransactionRepository, mockEthereumService, mockRedisService, time.Second*2)
+
+		assert.NoError(t, follower.HandleHead(context.Background(), &domain.BlockInfo{Number: 100, Hash: "0xa100", ParentHash: "0xa99"}))
+		assert.NoError(t, follower.HandleHead(context.Background(), &domain.BlockInfo{Number: 101, Hash: "0xa101", ParentHash: "0xa100"}))
+		assert.NoError(t, follower.HandleHead(context.Background(), &domain.BlockInfo{Number: 102, Hash: "0xa102", ParentHash: "0xa101"}))
+		// 新链在101分叉
+		assert.NoError(t, follower.HandleHead(context.Background(), &domain.BlockInfo{Number: 102, Hash: "0xb102", ParentHash: "0xb101"}))
+		// 新链继续增长，不再回滚
+		assert.NoError(t, follower.HandleHead(context.Background(), &domain.BlockInfo{Number: 103, Hash: "0xb103", ParentHash: "0xb102"}))
+
+		mockTransactionRepository.AssertExpectations(t)
+		mockEthereumService.AssertExpectations(t)
+		mockRedisService.AssertExpectations(t)
+	})
+}
+
+func TestChainFollowerRun(t *testing.T) {
+	t.Run("waits until connected", func(t *testing.T) {
+		mockEthereumService := new(mocks.EthereumService)
+		mockEthereumService.On("IsConnected").Return(false)
+
+		follower := usecase.NewChainFollower("sepolia", new(mocks.TransactionRepository), mockEthereumService, new(mocks.RedisService), time.Second*2)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		assert.NoError(t, follower.Run(ctx))
+		mockEthereumService.AssertNotCalled(t, "SubscribeNewHeads", mock.Anything)
+	})
+}
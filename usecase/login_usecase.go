@@ -9,14 +9,18 @@ import(
 )
 
 type loginUsecase struct {
-	userRepository domain.UserRepository
-	contextTimeout time.Duration
+	userRepository         domain.UserRepository
+	refreshTokenRepository domain.RefreshTokenRepository
+	accessTokenKeys        *tokenutil.KeySet
+	contextTimeout         time.Duration
 }
 
-func NewLoginUsecase(userRepository domain.UserRepository, timeout time.Duration) domain.LoginUsecase{
+func NewLoginUsecase(userRepository domain.UserRepository, refreshTokenRepository domain.RefreshTokenRepository, accessTokenKeys *tokenutil.KeySet, timeout time.Duration) domain.LoginUsecase{
 	return &loginUsecase{
-		userRepository: userRepository,
-		contextTimeout: timeout,
+		userRepository:         userRepository,
+		refreshTokenRepository: refreshTokenRepository,
+		accessTokenKeys:        accessTokenKeys,
+		contextTimeout:         timeout,
 	}
 }
 
@@ -27,9 +31,11 @@ func (lu *loginUsecase) GetUserByEmail(c context.Context, email string) (domain.
 }
 
 func (lu *loginUsecase) CreateAccessToken(user *domain.User, secret string, expiry int) (accessToken string, err error) {
-	return tokenutil.CreateAccessToken(user, secret, expiry)
+	return tokenutil.CreateAccessToken(user, lu.accessTokenKeys, secret, expiry)
 }
 
 func (lu *loginUsecase) CreateRefreshToken(user *domain.User, secret string, expiry int) (refreshToken string, err error) {
-	return tokenutil.CreateRefreshToken(user,secret, expiry)
+	ctx, cancel := context.WithTimeout(context.Background(), lu.contextTimeout)
+	defer cancel()
+	return issueRefreshToken(ctx, lu.refreshTokenRepository, user, "", secret, expiry)
 }
\ No newline at end of file
@@ -0,0 +1,53 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/littlecheny/go-backend/domain"
+	"github.com/littlecheny/go-backend/internal/tokenutil"
+)
+
+type logoutUsecase struct {
+	tokenRevocationRepository domain.TokenRevocationRepository
+	refreshTokenRepository    domain.RefreshTokenRepository
+	contextTimeout            time.Duration
+}
+
+func NewLogoutUsecase(tokenRevocationRepository domain.TokenRevocationRepository, refreshTokenRepository domain.RefreshTokenRepository, timeout time.Duration) domain.LogoutUsecase {
+	return &logoutUsecase{
+		tokenRevocationRepository: tokenRevocationRepository,
+		refreshTokenRepository:    refreshTokenRepository,
+		contextTimeout:            timeout,
+	}
+}
+
+func (lu *logoutUsecase) RevokeAccessToken(c context.Context, tokenID string, expiresAt int64) error {
+	ctx, cancel := context.WithTimeout(c, lu.contextTimeout)
+	defer cancel()
+	return lu.tokenRevocationRepository.RevokeToken(ctx, tokenID, expiresAt)
+}
+
+// RevokeRefreshToken 吊销刷新令牌所属的家族，令牌必须属于当前用户
+func (lu *logoutUsecase) RevokeRefreshToken(c context.Context, userID string, refreshToken string, secret string) error {
+	ctx, cancel := context.WithTimeout(c, lu.contextTimeout)
+	defer cancel()
+
+	claims, err := tokenutil.ExtractRefreshClaimsFromToken(refreshToken, secret)
+	if err != nil || claims.ID != userID || claims.FamilyID == "" {
+		return domain.ErrRefreshTokenInvalid
+	}
+	return lu.refreshTokenRepository.RevokeFamily(ctx, claims.FamilyID)
+}
+
+// RevokeAllTokens 退出所有设备：吊销此刻之前签发的访问令牌以及全部刷新令牌家族
+func (lu *logoutUsecase) RevokeAllTokens(c context.Context, userID string, expiry int) error {
+	ctx, cancel := context.WithTimeout(c, lu.contextTimeout)
+	defer cancel()
+
+	err := lu.tokenRevocationRepository.RevokeUserTokens(ctx, userID, time.Now().Unix(), time.Hour*time.Duration(expiry))
+	if err != nil {
+		return err
+	}
+	return lu.refreshTokenRepository.RevokeUserFamilies(ctx, userID)
+}
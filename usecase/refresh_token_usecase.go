@@ -0,0 +1,107 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/littlecheny/go-backend/domain"
+	"github.com/littlecheny/go-backend/internal/tokenutil"
+)
+
+type refreshTokenUsecase struct {
+	userRepository         domain.UserRepository
+	refreshTokenRepository domain.RefreshTokenRepository
+	accessTokenKeys        *tokenutil.KeySet
+	contextTimeout         time.Duration
+}
+
+func NewRefreshTokenUsecase(userRepository domain.UserRepository, refreshTokenRepository domain.RefreshTokenRepository, accessTokenKeys *tokenutil.KeySet, timeout time.Duration) domain.RefreshTokenUsecase {
+	return &refreshTokenUsecase{
+		userRepository:         userRepository,
+		refreshTokenRepository: refreshTokenRepository,
+		accessTokenKeys:        accessTokenKeys,
+		contextTimeout:         timeout,
+	}
+}
+
+func (rtu *refreshTokenUsecase) GetUserByID(c context.Context, id string) (domain.User, error) {
+	ctx, cancel := context.WithTimeout(c, rtu.contextTimeout)
+	defer cancel()
+	return rtu.userRepository.GetByID(ctx, id)
+}
+
+func (rtu *refreshTokenUsecase) CreateAccessToken(user *domain.User, secret string, expiry int) (accessToken string, err error) {
+	return tokenutil.CreateAccessToken(user, rtu.accessTokenKeys, secret, expiry)
+}
+
+// ValidateRefreshToken 校验签名并确认令牌是所属家族当前有效的令牌；
+// 已被轮换的令牌再次出现时吊销整个家族
+func (rtu *refreshTokenUsecase) ValidateRefreshToken(c context.Context, requestToken string, secret string) (*domain.JwtCustomRefreshClaims, error) {
+	ctx, cancel := context.WithTimeout(c, rtu.contextTimeout)
+	defer cancel()
+
+	claims, err := tokenutil.ExtractRefreshClaimsFromToken(requestToken, secret)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrRefreshTokenInvalid, err)
+	}
+	if claims.FamilyID == "" || claims.Id == "" {
+		return nil, domain.ErrRefreshTokenInvalid
+	}
+
+	current, err := rtu.refreshTokenRepository.GetCurrent(ctx, claims.FamilyID)
+	if err != nil {
+		return nil, err
+	}
+
+	if current != claims.Id {
+		err = rtu.refreshTokenRepository.RevokeFamily(ctx, claims.FamilyID)
+		if err != nil {
+			return nil, err
+		}
+		return nil, domain.ErrRefreshTokenReused
+	}
+
+	return claims, nil
+}
+
+// RotateRefreshToken 在同一家族内签发新的刷新令牌，旧令牌随即失效；
+// 同一令牌并发刷新时只有一个请求能替换成功，其余按重用处理并吊销整个家族
+func (rtu *refreshTokenUsecase) RotateRefreshToken(c context.Context, user *domain.User, claims *domain.JwtCustomRefreshClaims, secret string, expiry int) (refreshToken string, err error) {
+	ctx, cancel := context.WithTimeout(c, rtu.contextTimeout)
+	defer cancel()
+
+	refreshToken, next, err := tokenutil.CreateRefreshToken(user, claims.FamilyID, secret, expiry)
+	if err != nil {
+		return "", err
+	}
+
+	err = rtu.refreshTokenRepository.RotateCurrent(ctx, claims.Id, next)
+	if errors.Is(err, domain.ErrRefreshTokenReused) {
+		revokeErr := rtu.refreshTokenRepository.RevokeFamily(ctx, claims.FamilyID)
+		if revokeErr != nil {
+			return "", revokeErr
+		}
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return refreshToken, nil
+}
+
+// issueRefreshToken 签发刷新令牌并登记为家族当前有效的令牌，familyID为空时开启新家族
+func issueRefreshToken(c context.Context, refreshTokenRepository domain.RefreshTokenRepository, user *domain.User, familyID string, secret string, expiry int) (string, error) {
+	refreshToken, claims, err := tokenutil.CreateRefreshToken(user, familyID, secret, expiry)
+	if err != nil {
+		return "", err
+	}
+
+	err = refreshTokenRepository.SaveCurrent(c, claims)
+	if err != nil {
+		return "", err
+	}
+
+	return refreshToken, nil
+}
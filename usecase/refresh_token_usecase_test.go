@@ -0,0 +1,123 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/littlecheny/go-backend/domain"
+	"github.com/littlecheny/go-backend/domain/mocks"
+	"github.com/littlecheny/go-backend/internal/tokenutil"
+	"github.com/littlecheny/go-backend/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestValidateRefreshToken(t *testing.T) {
+	secret := "refresh-secret"
+	mockUser := &domain.User{
+		ID:   primitive.NewObjectID(),
+		Name: "Test",
+	}
+
+	refreshToken, claims, err := tokenutil.CreateRefreshToken(mockUser, "", secret, 1)
+	assert.NoError(t, err)
+
+	t.Run("success", func(t *testing.T) {
+		mockRefreshTokenRepository := new(mocks.RefreshTokenRepository)
+		mockRefreshTokenRepository.On("GetCurrent", mock.Anything, claims.FamilyID).Return(claims.Id, nil).Once()
+
+		u := usecase.NewRefreshTokenUsecase(new(mocks.UserRepository), mockRefreshTokenRepository, nil, time.Second*2)
+
+		got, err := u.ValidateRefreshToken(context.Background(), refreshToken, secret)
+
+		assert.NoError(t, err)
+		assert.Equal(t, mockUser.ID.Hex(), got.ID)
+		assert.Equal(t, claims.FamilyID, got.FamilyID)
+
+		mockRefreshTokenRepository.AssertExpectations(t)
+	})
+
+	t.Run("reuse revokes family", func(t *testing.T) {
+		mockRefreshTokenRepository := new(mocks.RefreshTokenRepository)
+		mockRefreshTokenRepository.On("GetCurrent", mock.Anything, claims.FamilyID).Return("newer-jti", nil).Once()
+		mockRefreshTokenRepository.On("RevokeFamily", mock.Anything, claims.FamilyID).Return(nil).Once()
+
+		u := usecase.NewRefreshTokenUsecase(new(mocks.UserRepository), mockRefreshTokenRepository, nil, time.Second*2)
+
+		_, err := u.ValidateRefreshToken(context.Background(), refreshToken, secret)
+
+		assert.ErrorIs(t, err, domain.ErrRefreshTokenReused)
+
+		mockRefreshTokenRepository.AssertExpectations(t)
+	})
+
+	t.Run("revoked family", func(t *testing.T) {
+		mockRefreshTokenRepository := new(mocks.RefreshTokenRepository)
+		mockRefreshTokenRepository.On("GetCurrent", mock.Anything, claims.FamilyID).Return("", domain.ErrRefreshTokenInvalid).Once()
+
+		u := usecase.NewRefreshTokenUsecase(new(mocks.UserRepository), mockRefreshTokenRepository, nil, time.Second*2)
+
+		_, err := u.ValidateRefreshToken(context.Background(), refreshToken, secret)
+
+		assert.ErrorIs(t, err, domain.ErrRefreshTokenInvalid)
+
+		mockRefreshTokenRepository.AssertExpectations(t)
+	})
+
+	t.Run("wrong secret", func(t *testing.T) {
+		mockRefreshTokenRepository := new(mocks.RefreshTokenRepository)
+
+		u := usecase.NewRefreshTokenUsecase(new(mocks.UserRepository), mockRefreshTokenRepository, nil, time.Second*2)
+
+		_, err := u.ValidateRefreshToken(context.Background(), refreshToken, "other-secret")
+
+		assert.ErrorIs(t, err, domain.ErrRefreshTokenInvalid)
+
+		mockRefreshTokenRepository.AssertExpectations(t)
+	})
+}
+
+func TestRotateRefreshToken(t *testing.T) {
+	secret := "refresh-secret"
+	mockUser := &domain.User{
+		ID:   primitive.NewObjectID(),
+		Name: "Test",
+	}
+
+	_, claims, err := tokenutil.CreateRefreshToken(mockUser, "", secret, 1)
+	assert.NoError(t, err)
+
+	t.Run("success", func(t *testing.T) {
+		mockRefreshTokenRepository := new(mocks.RefreshTokenRepository)
+		mockRefreshTokenRepository.On("RotateCurrent", mock.Anything, claims.Id, mock.MatchedBy(func(next *domain.JwtCustomRefreshClaims) bool {
+			return next.FamilyID == claims.FamilyID && next.Id != claims.Id
+		})).Return(nil).Once()
+
+		u := usecase.NewRefreshTokenUsecase(new(mocks.UserRepository), mockRefreshTokenRepository, nil, time.Second*2)
+
+		refreshToken, err := u.RotateRefreshToken(context.Background(), mockUser, claims, secret, 1)
+		assert.NoError(t, err)
+
+		next, err := tokenutil.ExtractRefreshClaimsFromToken(refreshToken, secret)
+		assert.NoError(t, err)
+		assert.Equal(t, claims.FamilyID, next.FamilyID)
+
+		mockRefreshTokenRepository.AssertExpectations(t)
+	})
+
+	// 并发刷新中另一个请求已替换了当前令牌
+	t.Run("concurrent refresh revokes family", func(t *testing.T) {
+		mockRefreshTokenRepository := new(mocks.RefreshTokenRepository)
+		mockRefreshTokenRepository.On("RotateCurrent", mock.Anything, claims.Id, mock.Anything).Return(domain.ErrRefreshTokenReused).Once()
+		mockRefreshTokenRepository.On("RevokeFamily", mock.Anything, claims.FamilyID).Return(nil).Once()
+
+		u := usecase.NewRefreshTokenUsecase(new(mocks.UserRepository), mockRefreshTokenRepository, nil, time.Second*2)
+
+		_, err := u.RotateRefreshToken(context.Background(), mockUser, claims, secret, 1)
+		assert.ErrorIs(t, err, domain.ErrRefreshTokenReused)
+
+		mockRefreshTokenRepository.AssertExpectations(t)
+	})
+}
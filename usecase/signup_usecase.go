@@ -10,14 +10,18 @@ import (
 )
 
 type signupUsecase struct {
-	userRepository domain.UserRepository
-	contextTimeout time.Duration
+	userRepository         domain.UserRepository
+	refreshTokenRepository domain.RefreshTokenRepository
+	accessTokenKeys        *tokenutil.KeySet
+	contextTimeout         time.Duration
 }
 
-func NewSignupUsecase(userRepository domain.UserRepository, timeout time.Duration) domain.SignupUsecase{
+func NewSignupUsecase(userRepository domain.UserRepository, refreshTokenRepository domain.RefreshTokenRepository, accessTokenKeys *tokenutil.KeySet, timeout time.Duration) domain.SignupUsecase{
 	return &signupUsecase{
-		userRepository: userRepository,
-		contextTimeout: timeout,
+		userRepository:         userRepository,
+		refreshTokenRepository: refreshTokenRepository,
+		accessTokenKeys:        accessTokenKeys,
+		contextTimeout:         timeout,
 	}
 }
 
@@ -34,9 +38,11 @@ func (su *signupUsecase) GetUserByEmail(c context.Context, email string) (domain
 }
 
 func (su *signupUsecase) CreateAccessToken(user *domain.User, secret string, expiry int) (accessToken string, err error) {
-	return tokenutil.CreateAccessToken(user, secret, expiry)
+	return tokenutil.CreateAccessToken(user, su.accessTokenKeys, secret, expiry)
 }
 
 func (su *signupUsecase) CreateRefreshToken(user *domain.User, secret string, expiry int) (refreshToken string, err error) {
-	return tokenutil.CreateRefreshToken(user, secret, expiry)
+	ctx, cancel := context.WithTimeout(context.Background(), su.contextTimeout)
+	defer cancel()
+	return issueRefreshToken(ctx, su.refreshTokenRepository, user, "", secret, expiry)
 }
\ No newline at end of file
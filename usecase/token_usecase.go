@@ -0,0 +1,25 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/littlecheny/go-backend/domain"
+)
+
+type tokenUsecase struct {
+	tokenRegistry domain.TokenRegistry
+}
+
+func NewTokenUsecase(tokenRegistry domain.TokenRegistry) domain.TokenUsecase {
+	return &tokenUsecase{
+		tokenRegistry: tokenRegistry,
+	}
+}
+
+// GetTokens network为空时返回所有网络的代币
+func (tu *tokenUsecase) GetTokens(c context.Context, network string) ([]domain.Token, error) {
+	if network == "" {
+		return tu.tokenRegistry.GetAllTokens(), nil
+	}
+	return tu.tokenRegistry.GetTokens(network), nil
+}
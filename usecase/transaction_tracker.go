@@ -0,0 +1,207 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log"
+	"math/big"
+	"time"
+
+	"github.com/littlecheny/go-backend/domain"
+)
+
+const (
+	defaultConfirmations = 12
+	defaultPollInterval  = 15 * time.Second
+	// maxReplacementDepth 沿替换链向前检查的最大交易数
+	maxReplacementDepth = 10
+)
+
+type transactionTracker struct {
+	transactionRepository domain.TransactionRepository
+	networks              domain.NetworkRegistry
+	cache                 domain.RedisService
+	confirmations         uint64
+	pollInterval          time.Duration
+	contextTimeout        time.Duration
+}
+
+// NewTransactionTracker confirmations为交易所在区块之后(含)需要的区块数，未配置时使用默认值
+func NewTransactionTracker(transactionRepository domain.TransactionRepository, networks domain.NetworkRegistry, cache domain.RedisService, confirmations int, pollInterval time.Duration, timeout time.Duration) domain.TransactionTracker {
+	if confirmations < 1 {
+		confirmations = defaultConfirmations
+	}
+	if pollInterval <= 0 {
+		pollInterval = defaultPollInterval
+	}
+
+	return &transactionTracker{
+		transactionRepository: transactionRepository,
+		networks:              networks,
+		cache:                 cache,
+		confirmations:         uint64(confirmations),
+		pollInterval:          pollInterval,
+		contextTimeout:        timeout,
+	}
+}
+
+// Run 定时轮询pending交易的收据，直到ctx取消
+func (tt *transactionTracker) Run(ctx context.Context) {
+	ticker := time.NewTicker(tt.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		err := tt.CheckPending(ctx)
+		if err != nil {
+			log.Printf("failed to check pending transactions: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// CheckPending 按网络检查一轮pending交易，单个网络或单笔交易出错只记录日志，不影响其他交易
+func (tt *transactionTracker) CheckPending(c context.Context) error {
+	ctx, cancel := context.WithTimeout(c, tt.contextTimeout)
+	defer cancel()
+
+	transactions, err := tt.transactionRepository.GetPendingTransactions(ctx)
+	if err != nil {
+		return err
+	}
+
+	byNetwork := make(map[string][]*domain.Transaction)
+	for i := range transactions {
+		network := transactions[i].Network
+		byNetwork[network] = append(byNetwork[network], &transactions[i])
+	}
+
+	for network, pending := range byNetwork {
+		err = tt.checkNetwork(ctx, network, pending)
+		if err != nil {
+			log.Printf("failed to check pending transactions on %s: %v", network, err)
+		}
+	}
+	return nil
+}
+
+func (tt *transactionTracker) checkNetwork(ctx context.Context, network string, transactions []*domain.Transaction) error {
+	ethereumService, err := tt.networks.GetClient(network)
+	if err != nil {
+		return err
+	}
+
+	latest, err := ethereumService.GetLatestBlock(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, transaction := range transactions {
+		err = tt.checkTransaction(ctx, ethereumService, transaction, latest.Number)
+		if err != nil {
+			log.Printf("failed to check transaction %s: %v", transaction.Hash, err)
+		}
+	}
+	return nil
+}
+
+func (tt *transactionTracker) checkTransaction(ctx context.Context, ethereumService domain.EthereumService, transaction *domain.Transaction, latestBlock uint64) error {
+	receipt, err := ethereumService.GetTransactionReceipt(ctx, transaction.Hash)
+	if errors.Is(err, domain.ErrReceiptNotFound) {
+		if transaction.Replaces != "" {
+			return tt.checkReplaced(ctx, ethereumService, transaction, latestBlock)
+		}
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if !tt.isConfirmed(receipt, latestBlock) {
+		return nil
+	}
+	return tt.applyReceipt(ctx, transaction, receipt)
+}
+
+// checkReplaced 替换交易还没有收据时，沿替换链检查被替换的交易是否抢先上链；
+// 同一nonce只有一笔交易能上链，此时替换交易改为replaced，上链的交易按收据更新
+func (tt *transactionTracker) checkReplaced(ctx context.Context, ethereumService domain.EthereumService, transaction *domain.Transaction, latestBlock uint64) error {
+	hash := transaction.Replaces
+	for depth := 0; hash != "" && depth < maxReplacementDepth; depth++ {
+		receipt, err := ethereumService.GetTransactionReceipt(ctx, hash)
+		mined := err == nil
+		if err != nil && !errors.Is(err, domain.ErrReceiptNotFound) {
+			return err
+		}
+
+		replaced, err := tt.transactionRepository.GetByHashAndWalletID(ctx, hash, transaction.WalletID.Hex())
+		if err != nil {
+			return err
+		}
+		if !mined {
+			hash = replaced.Replaces
+			continue
+		}
+
+		if !tt.isConfirmed(receipt, latestBlock) {
+			return nil
+		}
+
+		replaced.ReplacedBy = ""
+		err = tt.applyReceipt(ctx, &replaced, receipt)
+		if err != nil {
+			return err
+		}
+
+		transaction.Status = domain.TransactionStatusReplaced
+		transaction.ReplacedBy = replaced.Hash
+		err = tt.transactionRepository.Update(ctx, transaction)
+		if err != nil {
+			return err
+		}
+		tt.invalidate(ctx, transaction.Hash)
+		return nil
+	}
+	return nil
+}
+
+func (tt *transactionTracker) isConfirmed(receipt *domain.TransactionReceipt, latestBlock uint64) bool {
+	return latestBlock >= receipt.BlockNumber && latestBlock-receipt.BlockNumber+1 >= tt.confirmations
+}
+
+// applyReceipt 按收据更新交易状态、实际费用和所在区块
+func (tt *transactionTracker) applyReceipt(ctx context.Context, transaction *domain.Transaction, receipt *domain.TransactionReceipt) error {
+	fee, ok := new(big.Int).SetString(receipt.EffectiveGasPrice, 10)
+	if !ok {
+		return domain.ErrInvalidAmount
+	}
+	fee.Mul(fee, new(big.Int).SetUint64(receipt.GasUsed))
+
+	now := time.Now()
+	transaction.Status = receipt.Status
+	transaction.GasUsed = receipt.GasUsed
+	transaction.BlockNumber = receipt.BlockNumber
+	transaction.BlockHash = receipt.BlockHash
+	transaction.TransactionFee = fee.String()
+	transaction.ConfirmedAt = &now
+
+	err := tt.transactionRepository.Update(ctx, transaction)
+	if err != nil {
+		return err
+	}
+
+	tt.invalidate(ctx, transaction.Hash)
+	return nil
+}
+
+// invalidate 缓存中还是pending状态，删除后下次查询重新加载
+func (tt *transactionTracker) invalidate(ctx context.Context, hash string) {
+	err := tt.cache.DeleteTransaction(ctx, hash)
+	if err != nil {
+		log.Printf("failed to invalidate cached transaction %s: %v", hash, err)
+	}
+}
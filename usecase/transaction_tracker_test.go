@@ -0,0 +1,124 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/littlecheny/go-backend/domain"
+	"github.com/littlecheny/go-backend/domain/mocks"
+	"github.com/littlecheny/go-backend/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestCheckPending(t *testing.T) {
+	confirmed := domain.Transaction{ID: primitive.NewObjectID(), Hash: "0xconfirmed", Status: domain.TransactionStatusPending}
+	reverted := domain.Transaction{ID: primitive.NewObjectID(), Hash: "0xreverted", Status: domain.TransactionStatusPending}
+	recent := domain.Transaction{ID: primitive.NewObjectID(), Hash: "0xrecent", Status: domain.TransactionStatusPending}
+	unmined := domain.Transaction{ID: primitive.NewObjectID(), Hash: "0xunmined", Status: domain.TransactionStatusPending}
+
+	mockTransactionRepository := new(mocks.TransactionRepository)
+	mockEthereumService := new(mocks.EthereumService)
+	mockRedisService := new(mocks.RedisService)
+
+	mockTransactionRepository.On("GetPendingTransactions", mock.Anything).Return([]domain.Transaction{confirmed, reverted, recent, unmined}, nil).Once()
+	mockEthereumService.On("GetLatestBlock", mock.Anything).Return(&domain.BlockInfo{Number: 102}, nil).Once()
+	mockEthereumService.On("GetTransactionReceipt", mock.Anything, "0xconfirmed").Return(&domain.TransactionReceipt{
+		Hash:              "0xconfirmed",
+		Status:            domain.TransactionStatusConfirmed,
+		BlockNumber:       100,
+		BlockHash:         "0xblock100",
+		GasUsed:           21000,
+		EffectiveGasPrice: "2000000000",
+	}, nil).Once()
+	mockEthereumService.On("GetTransactionReceipt", mock.Anything, "0xreverted").Return(&domain.TransactionReceipt{
+		Hash:              "0xreverted",
+		Status:            domain.TransactionStatusFailed,
+		BlockNumber:       99,
+		BlockHash:         "0xblock99",
+		GasUsed:           50000,
+		EffectiveGasPrice: "1000000000",
+	}, nil).Once()
+	mockEthereumService.On("GetTransactionReceipt", mock.Anything, "0xrecent").Return(&domain.TransactionReceipt{
+		Hash:              "0xrecent",
+		Status:            domain.TransactionStatusConfirmed,
+		BlockNumber:       101,
+		EffectiveGasPrice: "1000000000",
+	}, nil).Once()
+	mockEthereumService.On("GetTransactionReceipt", mock.Anything, "0xunmined").Return(nil, domain.ErrReceiptNotFound).Once()
+	mockTransactionRepository.On("Update", mock.Anything, mock.MatchedBy(func(tx *domain.Transaction) bool {
+		return tx.Hash == "0xconfirmed" && tx.Status == domain.TransactionStatusConfirmed && tx.GasUsed == 21000 &&
+			tx.BlockNumber == 100 && tx.BlockHash == "0xblock100" && tx.TransactionFee == "42000000000000" && tx.ConfirmedAt != nil
+	})).Return(nil).Once()
+	mockTransactionRepository.On("Update", mock.Anything, mock.MatchedBy(func(tx *domain.Transaction) bool {
+		return tx.Hash == "0xreverted" && tx.Status == domain.TransactionStatusFailed && tx.TransactionFee == "50000000000000"
+	})).Return(nil).Once()
+	mockRedisService.On("DeleteTransaction", mock.Anything, "0xconfirmed").Return(nil).Once()
+	mockRedisService.On("DeleteTransaction", mock.Anything, "0xreverted").Return(nil).Once()
+
+	tracker := usecase.NewTransactionTracker(mockTransactionRepository, networkRegistry(mockEthereumService), mockRedisService, 3, time.Second, time.Second*2)
+
+	err := tracker.CheckPending(context.Background())
+
+	assert.NoError(t, err)
+
+	mockTransactionRepository.AssertExpectations(t)
+	mockEthereumService.AssertExpectations(t)
+	mockRedisService.AssertExpectations(t)
+
+	t.Run("no pending transactions", func(t *testing.T) {
+		mockTransactionRepository := new(mocks.TransactionRepository)
+		mockTransactionRepository.On("GetPendingTransactions", mock.Anything).Return([]domain.Transaction{}, nil).Once()
+
+		tracker := usecase.NewTransactionTracker(mockTransactionRepository, new(mocks.NetworkRegistry), new(mocks.RedisService), 3, time.Second, time.Second*2)
+
+		err := tracker.CheckPending(context.Background())
+
+		assert.NoError(t, err)
+
+		mockTransactionRepository.AssertExpectations(t)
+	})
+}
+
+func TestCheckPendingReplaced(t *testing.T) {
+	walletID := primitive.NewObjectID()
+	original := domain.Transaction{ID: primitive.NewObjectID(), WalletID: walletID, Hash: "0xoriginal", Status: domain.TransactionStatusReplaced, ReplacedBy: "0xspeedup"}
+	replacement := domain.Transaction{ID: primitive.NewObjectID(), WalletID: walletID, Hash: "0xspeedup", Status: domain.TransactionStatusPending, Replaces: "0xoriginal"}
+
+	mockTransactionRepository := new(mocks.TransactionRepository)
+	mockEthereumService := new(mocks.EthereumService)
+	mockRedisService := new(mocks.RedisService)
+
+	// 原交易在替换交易广播之前已经被打包
+	mockTransactionRepository.On("GetPendingTransactions", mock.Anything).Return([]domain.Transaction{replacement}, nil).Once()
+	mockEthereumService.On("GetLatestBlock", mock.Anything).Return(&domain.BlockInfo{Number: 102}, nil).Once()
+	mockEthereumService.On("GetTransactionReceipt", mock.Anything, "0xspeedup").Return(nil, domain.ErrReceiptNotFound).Once()
+	mockEthereumService.On("GetTransactionReceipt", mock.Anything, "0xoriginal").Return(&domain.TransactionReceipt{
+		Hash:              "0xoriginal",
+		Status:            domain.TransactionStatusConfirmed,
+		BlockNumber:       100,
+		GasUsed:           21000,
+		EffectiveGasPrice: "1000000000",
+	}, nil).Once()
+	mockTransactionRepository.On("GetByHashAndWalletID", mock.Anything, "0xoriginal", walletID.Hex()).Return(original, nil).Once()
+	mockTransactionRepository.On("Update", mock.Anything, mock.MatchedBy(func(tx *domain.Transaction) bool {
+		return tx.Hash == "0xoriginal" && tx.Status == domain.TransactionStatusConfirmed && tx.ReplacedBy == "" && tx.BlockNumber == 100
+	})).Return(nil).Once()
+	mockTransactionRepository.On("Update", mock.Anything, mock.MatchedBy(func(tx *domain.Transaction) bool {
+		return tx.Hash == "0xspeedup" && tx.Status == domain.TransactionStatusReplaced && tx.ReplacedBy == "0xoriginal"
+	})).Return(nil).Once()
+	mockRedisService.On("DeleteTransaction", mock.Anything, "0xoriginal").Return(nil).Once()
+	mockRedisService.On("DeleteTransaction", mock.Anything, "0xspeedup").Return(nil).Once()
+
+	tracker := usecase.NewTransactionTracker(mockTransactionRepository, networkRegistry(mockEthereumService), mockRedisService, 3, time.Second, time.Second*2)
+
+	err := tracker.CheckPending(context.Background())
+
+	assert.NoError(t, err)
+
+	mockTransactionRepository.AssertExpectations(t)
+	mockEthereumService.AssertExpectations(t)
+	mockRedisService.AssertExpectations(t)
+}
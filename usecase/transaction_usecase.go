@@ -0,0 +1,681 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/littlecheny/go-backend/domain"
+	"github.com/littlecheny/go-backend/internal/units"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	defaultTransactionLimit = 20
+	maxTransactionLimit     = 100
+	transactionCacheTTL     = time.Minute
+	// minReplacementBump 节点接受替换交易要求的最低费用涨幅(%)
+	minReplacementBump = 10
+	// defaultReplacementBump 替换交易未指定费用时的涨幅(%)
+	defaultReplacementBump = 20
+	// droppedGracePeriod 交易广播后等待节点同步的时间，期间查不到不视为已丢弃
+	droppedGracePeriod = 5 * time.Minute
+)
+
+type transactionUsecase struct {
+	transactionRepository domain.TransactionRepository
+	walletUsecase         domain.WalletUsecase
+	networks              domain.NetworkRegistry
+	cache                 domain.RedisService
+	tokenRegistry         domain.TokenRegistry
+	nonceManager          domain.NonceManager
+	contextTimeout        time.Duration
+}
+
+func NewTransactionUsecase(transactionRepository domain.TransactionRepository, walletUsecase domain.WalletUsecase, networks domain.NetworkRegistry, cache domain.RedisService, tokenRegistry domain.TokenRegistry, nonceManager domain.NonceManager, timeout time.Duration) domain.TransactionUsecase {
+	return &transactionUsecase{
+		transactionRepository: transactionRepository,
+		walletUsecase:         walletUsecase,
+		networks:              networks,
+		cache:                 cache,
+		tokenRegistry:         tokenRegistry,
+		nonceManager:          nonceManager,
+		contextTimeout:        timeout,
+	}
+}
+
+// SendTransaction 解密钱包私钥，签名并广播交易，保存为pending状态；
+// 指定ContractAddress时转账钱包所在网络已登记的ERC-20代币
+func (tu *transactionUsecase) SendTransaction(c context.Context, userID string, req *domain.TransactionSendRequest) (*domain.TransactionResponse, error) {
+	ctx, cancel := context.WithTimeout(c, tu.contextTimeout)
+	defer cancel()
+
+	if !common.IsHexAddress(req.To) {
+		return nil, domain.ErrInvalidAddress
+	}
+
+	// 代币精度取决于钱包所在网络，查到钱包后再解析金额
+	var value *big.Int
+	var err error
+	if req.ContractAddress == "" {
+		value, err = parseDecimal(req.Amount, units.EtherDecimals)
+		if err != nil {
+			return nil, err
+		}
+	} else if !common.IsHexAddress(req.ContractAddress) {
+		return nil, domain.ErrInvalidAddress
+	}
+
+	fees, err := parseFees(req)
+	if err != nil {
+		return nil, err
+	}
+
+	wallet, err := tu.walletUsecase.GetWallet(ctx, userID, req.WalletID)
+	if err != nil {
+		return nil, err
+	}
+
+	var token *domain.Token
+	if req.ContractAddress != "" {
+		token, err = tu.tokenRegistry.GetToken(wallet.Network, req.ContractAddress)
+		if err != nil {
+			return nil, err
+		}
+
+		value, err = parseDecimal(req.Amount, token.Decimals)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	ethereumService, err := tu.networks.GetClient(wallet.Network)
+	if err != nil {
+		return nil, err
+	}
+
+	privateKey, err := tu.walletUsecase.ExportPrivateKey(ctx, userID, req.WalletID, req.Password)
+	if err != nil {
+		return nil, err
+	}
+
+	// 同一钱包的发送串行执行，避免并发请求拿到相同的nonce
+	unlock, err := tu.nonceManager.Lock(ctx, wallet.Network, wallet.Address)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	nonce, err := tu.nextNonce(ctx, ethereumService, wallet.Network, wallet.Address)
+	if err != nil {
+		return nil, err
+	}
+	fees.Nonce = &nonce
+
+	to := common.HexToAddress(req.To).Hex()
+	var signed *domain.SignedTransaction
+	if token != nil {
+		signed, err = ethereumService.SendTokenTransfer(ctx, wallet.Address, token.ContractAddress, to, privateKey, value.String(), fees)
+	} else {
+		signed, err = ethereumService.SendTransaction(ctx, wallet.Address, to, privateKey, value.String(), fees)
+	}
+	if err != nil {
+		tu.resyncNonce(ctx, wallet.Network, wallet.Address, err)
+		return nil, err
+	}
+
+	err = tu.nonceManager.Set(ctx, wallet.Network, wallet.Address, signed.Nonce+1)
+	if err != nil {
+		log.Printf("failed to record nonce for %s: %v", wallet.Address, err)
+	}
+
+	userIDHex, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	transaction := &domain.Transaction{
+		ID:                   primitive.NewObjectID(),
+		UserID:               userIDHex,
+		WalletID:             wallet.ID,
+		Hash:                 signed.Hash,
+		From:                 signed.From,
+		To:                   to,
+		Value:                value.String(),
+		GasPrice:             signed.GasPrice,
+		FeeModel:             signed.FeeModel,
+		GasLimit:             signed.GasLimit,
+		Nonce:                signed.Nonce,
+		MaxFeePerGas:         signed.MaxFeePerGas,
+		MaxPriorityFeePerGas: signed.MaxPriorityFeePerGas,
+		Status:               domain.TransactionStatusPending,
+		Type:                 domain.TransactionTypeSend,
+		Network:              wallet.Network,
+		CreatedAt:            now,
+		UpdatedAt:            now,
+	}
+	if token != nil {
+		transaction.ContractAddress = token.ContractAddress
+		transaction.TokenSymbol = token.Symbol
+		transaction.TokenDecimals = token.Decimals
+	}
+
+	// 交易已经广播，保存失败时不能返回错误，否则用户重试会重复转账
+	err = tu.transactionRepository.Create(ctx, transaction)
+	if err != nil {
+		log.Printf("failed to save transaction %s: %v", transaction.Hash, err)
+	}
+
+	return toTransactionResponse(transaction), nil
+}
+
+// nextNonce 取本地记录和节点pending nonce中较大的一个，本地记录领先时检查是否出现nonce gap
+func (tu *transactionUsecase) nextNonce(ctx context.Context, ethereumService domain.EthereumService, network, address string) (uint64, error) {
+	chainNonce, err := ethereumService.GetNonce(ctx, address)
+	if err != nil {
+		return 0, err
+	}
+
+	stored, ok, err := tu.nonceManager.Get(ctx, network, address)
+	if err != nil {
+		return 0, err
+	}
+	if !ok || stored <= chainNonce {
+		return chainNonce, nil
+	}
+
+	// 本地记录领先可能是节点还没同步到刚发出的交易，也可能是中间的交易已被丢弃；
+	// 后一种情况继续使用本地记录会让之后的交易永远无法上链
+	if tu.isDropped(ctx, ethereumService, network, address, chainNonce) {
+		log.Printf("nonce gap detected for %s on %s: next nonce %d, node pending nonce %d, resyncing", address, network, stored, chainNonce)
+		return chainNonce, nil
+	}
+	return stored, nil
+}
+
+// isDropped 地址以nonce发出的交易广播超过宽限期后节点仍明确查不到时才视为已丢弃。
+// 节点可能还没同步到刚广播的交易，查询失败也不能说明交易不存在；
+// 没有记录可能是广播后保存失败，同样无法确认，继续使用本地记录
+func (tu *transactionUsecase) isDropped(ctx context.Context, ethereumService domain.EthereumService, network, address string, nonce uint64) bool {
+	transaction, err := tu.transactionRepository.GetByNonce(ctx, network, address, nonce)
+	if err != nil {
+		return false
+	}
+	if time.Since(transaction.CreatedAt) < droppedGracePeriod {
+		return false
+	}
+
+	_, err = ethereumService.GetTransaction(ctx, transaction.Hash)
+	return errors.Is(err, domain.ErrTransactionNotFound)
+}
+
+// resyncNonce 节点因nonce拒绝交易时说明本地记录已经不准确，删除后下次从节点同步
+func (tu *transactionUsecase) resyncNonce(ctx context.Context, network, address string, sendErr error) {
+	message := sendErr.Error()
+	if !strings.Contains(message, "nonce too low") && !strings.Contains(message, "already known") &&
+		!strings.Contains(message, "replacement transaction underpriced") {
+		return
+	}
+
+	err := tu.nonceManager.Reset(ctx, network, address)
+	if err != nil {
+		log.Printf("failed to reset nonce for %s: %v", address, err)
+	}
+}
+
+// SpeedUpTransaction 以原交易的nonce、接收方和金额重新签名，费用按替换规则提高
+func (tu *transactionUsecase) SpeedUpTransaction(c context.Context, userID, transactionID string, req *domain.TransactionReplaceRequest) (*domain.TransactionResponse, error) {
+	return tu.replaceTransaction(c, userID, transactionID, req, false)
+}
+
+// CancelTransaction 以原交易的nonce向钱包自身发送0金额交易，上链后原交易失效
+func (tu *transactionUsecase) CancelTransaction(c context.Context, userID, transactionID string, req *domain.TransactionReplaceRequest) (*domain.TransactionResponse, error) {
+	return tu.replaceTransaction(c, userID, transactionID, req, true)
+}
+
+// replaceTransaction 广播替换交易并保存为新记录，保存成功后原记录才标记为replaced并指向新交易
+func (tu *transactionUsecase) replaceTransaction(c context.Context, userID, transactionID string, req *domain.TransactionReplaceRequest, cancelTx bool) (*domain.TransactionResponse, error) {
+	ctx, cancel := context.WithTimeout(c, tu.contextTimeout)
+	defer cancel()
+
+	original, err := tu.replaceableTransaction(ctx, userID, transactionID)
+	if err != nil {
+		return nil, err
+	}
+
+	fees, err := replacementFees(&original, req)
+	if err != nil {
+		return nil, err
+	}
+	fees.Nonce = &original.Nonce
+
+	wallet, err := tu.walletUsecase.GetWallet(ctx, userID, original.WalletID.Hex())
+	if err != nil {
+		return nil, err
+	}
+
+	ethereumService, err := tu.networks.GetClient(original.Network)
+	if err != nil {
+		return nil, err
+	}
+
+	privateKey, err := tu.walletUsecase.ExportPrivateKey(ctx, userID, original.WalletID.Hex(), req.Password)
+	if err != nil {
+		return nil, err
+	}
+
+	unlock, err := tu.nonceManager.Lock(ctx, original.Network, original.From)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	// 持有发送锁后重新读取，防止并发请求重复替换同一笔交易
+	original, err = tu.replaceableTransaction(ctx, userID, transactionID)
+	if err != nil {
+		return nil, err
+	}
+
+	replacement := original
+	replacement.ID = primitive.NewObjectID()
+	if cancelTx {
+		replacement.To = wallet.Address
+		replacement.Value = "0"
+		replacement.ContractAddress = ""
+		replacement.TokenSymbol = ""
+		replacement.TokenDecimals = 0
+	}
+
+	var signed *domain.SignedTransaction
+	if replacement.ContractAddress != "" {
+		signed, err = ethereumService.SendTokenTransfer(ctx, wallet.Address, replacement.ContractAddress, replacement.To, privateKey, replacement.Value, fees)
+	} else {
+		signed, err = ethereumService.SendTransaction(ctx, wallet.Address, replacement.To, privateKey, replacement.Value, fees)
+	}
+	if err != nil {
+		// 原交易已经上链，nonce不能再使用
+		if strings.Contains(err.Error(), "nonce too low") {
+			return nil, domain.ErrNotReplaceable
+		}
+		return nil, err
+	}
+
+	now := time.Now()
+	replacement.Hash = signed.Hash
+	replacement.GasPrice = signed.GasPrice
+	replacement.FeeModel = signed.FeeModel
+	replacement.MaxFeePerGas = signed.MaxFeePerGas
+	replacement.MaxPriorityFeePerGas = signed.MaxPriorityFeePerGas
+	replacement.GasLimit = signed.GasLimit
+	replacement.Status = domain.TransactionStatusPending
+	replacement.Replaces = original.Hash
+	replacement.ReplacedBy = ""
+	replacement.CreatedAt = now
+	replacement.UpdatedAt = now
+
+	// 替换交易保存失败时原交易保持pending，TransactionTracker继续跟踪该nonce
+	err = tu.transactionRepository.Create(ctx, &replacement)
+	if err != nil {
+		return nil, fmt.Errorf("replacement %s was broadcast but could not be saved: %w", replacement.Hash, err)
+	}
+
+	original.Status = domain.TransactionStatusReplaced
+	original.ReplacedBy = replacement.Hash
+	err = tu.transactionRepository.Update(ctx, &original)
+	if err != nil {
+		log.Printf("failed to mark transaction %s as replaced: %v", original.Hash, err)
+	}
+
+	err = tu.cache.DeleteTransaction(ctx, original.Hash)
+	if err != nil {
+		log.Printf("failed to invalidate cached transaction %s: %v", original.Hash, err)
+	}
+
+	return toTransactionResponse(&replacement), nil
+}
+
+// replaceableTransaction 只有用户自己发出的pending交易可以替换
+func (tu *transactionUsecase) replaceableTransaction(ctx context.Context, userID, transactionID string) (domain.Transaction, error) {
+	transaction, err := tu.transactionRepository.GetByID(ctx, transactionID)
+	if err != nil {
+		return domain.Transaction{}, err
+	}
+	if transaction.UserID.Hex() != userID {
+		return domain.Transaction{}, domain.ErrTransactionNotFound
+	}
+	if transaction.Type != domain.TransactionTypeSend || transaction.Status != domain.TransactionStatusPending {
+		return domain.Transaction{}, domain.ErrNotReplaceable
+	}
+	return transaction, nil
+}
+
+func (tu *transactionUsecase) GetTransactions(c context.Context, userID string, limit, offset int) ([]domain.TransactionResponse, error) {
+	ctx, cancel := context.WithTimeout(c, tu.contextTimeout)
+	defer cancel()
+
+	if limit < 1 {
+		limit = defaultTransactionLimit
+	}
+	if limit > maxTransactionLimit {
+		limit = maxTransactionLimit
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	transactions, err := tu.transactionRepository.GetByUserID(ctx, userID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]domain.TransactionResponse, 0, len(transactions))
+	for i := range transactions {
+		responses = append(responses, *toTransactionResponse(&transactions[i]))
+	}
+	return responses, nil
+}
+
+// GetWalletHistory 钱包的交易记录按创建时间倒序返回；pending记录在TransactionTracker确认之前，
+// 用链上数据补充状态、区块和日志，查询失败时保留本地记录
+func (tu *transactionUsecase) GetWalletHistory(c context.Context, userID, walletID string, limit, offset int) ([]domain.TransactionResponse, error) {
+	ctx, cancel := context.WithTimeout(c, tu.contextTimeout)
+	defer cancel()
+
+	if limit < 1 {
+		limit = defaultTransactionLimit
+	}
+	if limit > maxTransactionLimit {
+		limit = maxTransactionLimit
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	wallet, err := tu.walletUsecase.GetWallet(ctx, userID, walletID)
+	if err != nil {
+		return nil, err
+	}
+
+	transactions, err := tu.transactionRepository.GetByWalletID(ctx, wallet.ID.Hex(), limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	ethereumService, err := tu.networks.GetClient(wallet.Network)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]domain.TransactionResponse, 0, len(transactions))
+	for i := range transactions {
+		response := toTransactionResponse(&transactions[i])
+		if transactions[i].Status == domain.TransactionStatusPending && ethereumService.IsConnected() {
+			mergeOnChain(ctx, ethereumService, response)
+		}
+		responses = append(responses, *response)
+	}
+	return responses, nil
+}
+
+// mergeOnChain 只覆盖链上才有的字段，金额、代币等以本地记录为准
+func mergeOnChain(ctx context.Context, ethereumService domain.EthereumService, response *domain.TransactionResponse) {
+	onChain, err := ethereumService.GetTransaction(ctx, response.Hash)
+	if err != nil {
+		return
+	}
+
+	response.Status = onChain.Status
+	response.BlockNumber = onChain.BlockNumber
+	response.GasUsed = onChain.GasUsed
+	response.TransactionFee = onChain.TransactionFee
+	response.Logs = onChain.Logs
+}
+
+func (tu *transactionUsecase) GetTransaction(c context.Context, userID, transactionID string) (*domain.TransactionResponse, error) {
+	ctx, cancel := context.WithTimeout(c, tu.contextTimeout)
+	defer cancel()
+
+	transaction, err := tu.transactionRepository.GetByID(ctx, transactionID)
+	if err != nil {
+		return nil, err
+	}
+	if transaction.UserID.Hex() != userID {
+		return nil, domain.ErrTransactionNotFound
+	}
+
+	return toTransactionResponse(&transaction), nil
+}
+
+// GetTransactionByHash 优先返回用户自己的记录；其他交易依次查询缓存和各网络链上数据，
+// 缓存只保存链上数据，不会把某个用户的记录返回给其他用户
+func (tu *transactionUsecase) GetTransactionByHash(c context.Context, userID, hash string) (*domain.TransactionResponse, error) {
+	ctx, cancel := context.WithTimeout(c, tu.contextTimeout)
+	defer cancel()
+
+	transactions, err := tu.transactionRepository.GetByHashAndUserID(ctx, hash, userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(transactions) > 0 {
+		// 用户自己钱包之间的转账有send和receive两条记录，返回发送方记录
+		transaction := transactions[0]
+		for _, t := range transactions {
+			if t.Type == domain.TransactionTypeSend {
+				transaction = t
+				break
+			}
+		}
+		return toTransactionResponse(&transaction), nil
+	}
+
+	cached, err := tu.cache.GetTransaction(ctx, hash)
+	if err == nil {
+		return cached, nil
+	}
+
+	response := tu.findTransaction(ctx, hash)
+	if response == nil {
+		return nil, domain.ErrTransactionNotFound
+	}
+
+	err = tu.cache.SetTransaction(ctx, hash, response, transactionCacheTTL)
+	if err != nil {
+		log.Printf("failed to cache transaction %s: %v", hash, err)
+	}
+
+	return response, nil
+}
+
+func (tu *transactionUsecase) UpdateTransactionStatus(c context.Context, hash string, status domain.TransactionStatus) error {
+	ctx, cancel := context.WithTimeout(c, tu.contextTimeout)
+	defer cancel()
+
+	return tu.transactionRepository.UpdateStatus(ctx, hash, status)
+}
+
+// findTransaction 按配置顺序在已连接的网络中查找交易
+func (tu *transactionUsecase) findTransaction(ctx context.Context, hash string) *domain.TransactionResponse {
+	for _, network := range tu.networks.GetNetworks() {
+		ethereumService, err := tu.networks.GetClient(network.Name)
+		if err != nil || !ethereumService.IsConnected() {
+			continue
+		}
+
+		response, err := ethereumService.GetTransaction(ctx, hash)
+		if err == nil {
+			response.Network = network.Name
+			return response
+		}
+	}
+	return nil
+}
+
+// EstimateGas value为ETH格式的金额
+func (tu *transactionUsecase) EstimateGas(c context.Context, network, from, to, value string) (uint64, error) {
+	ctx, cancel := context.WithTimeout(c, tu.contextTimeout)
+	defer cancel()
+
+	if !common.IsHexAddress(from) || !common.IsHexAddress(to) {
+		return 0, domain.ErrInvalidAddress
+	}
+
+	valueWei, err := parseDecimal(value, units.EtherDecimals)
+	if err != nil {
+		return 0, err
+	}
+
+	ethereumService, err := tu.networks.GetClient(network)
+	if err != nil {
+		return 0, err
+	}
+	return ethereumService.EstimateGas(ctx, from, to, valueWei.String())
+}
+
+func (tu *transactionUsecase) GetGasPrice(c context.Context, network string) (string, error) {
+	ctx, cancel := context.WithTimeout(c, tu.contextTimeout)
+	defer cancel()
+
+	ethereumService, err := tu.networks.GetClient(network)
+	if err != nil {
+		return "", err
+	}
+	return ethereumService.GetGasPrice(ctx)
+}
+
+// parseFees 将请求中Gwei格式的费用参数转换为wei，未指定的由EthereumService估算
+func parseFees(req *domain.TransactionSendRequest) (*domain.TransactionFees, error) {
+	fees, err := gweiToWeiFees(req.GasPrice, req.MaxFeePerGas, req.MaxPriorityFeePerGas)
+	if err != nil {
+		return nil, err
+	}
+
+	if fees.GasPrice == "0" || fees.MaxFeePerGas == "0" {
+		return nil, domain.ErrInvalidAmount
+	}
+	return fees, nil
+}
+
+// gweiToWeiFees 空字段保持为空
+func gweiToWeiFees(gasPrice, maxFeePerGas, maxPriorityFeePerGas string) (*domain.TransactionFees, error) {
+	fees := &domain.TransactionFees{}
+	for _, field := range []struct {
+		gwei string
+		wei  *string
+	}{
+		{gasPrice, &fees.GasPrice},
+		{maxFeePerGas, &fees.MaxFeePerGas},
+		{maxPriorityFeePerGas, &fees.MaxPriorityFeePerGas},
+	} {
+		if field.gwei == "" {
+			continue
+		}
+		wei, err := parseDecimal(field.gwei, units.GweiDecimals)
+		if err != nil {
+			return nil, err
+		}
+		*field.wei = wei.String()
+	}
+	return fees, nil
+}
+
+// replacementFees 未指定的费用在原交易基础上提高defaultReplacementBump，指定的费用不能低于节点要求的涨幅；
+// EIP-1559交易只给出GasPrice时同时作为maxFeePerGas和maxPriorityFeePerGas
+func replacementFees(original *domain.Transaction, req *domain.TransactionReplaceRequest) (*domain.TransactionFees, error) {
+	requested, err := gweiToWeiFees(req.GasPrice, req.MaxFeePerGas, req.MaxPriorityFeePerGas)
+	if err != nil {
+		return nil, err
+	}
+	if requested.MaxFeePerGas == "" {
+		requested.MaxFeePerGas = requested.GasPrice
+	}
+	if requested.MaxPriorityFeePerGas == "" {
+		requested.MaxPriorityFeePerGas = requested.GasPrice
+	}
+
+	type field struct {
+		original  string
+		requested string
+		wei       *string
+	}
+	fees := &domain.TransactionFees{}
+	fields := []field{{original.GasPrice, requested.GasPrice, &fees.GasPrice}}
+	if original.FeeModel == domain.FeeModelEIP1559 {
+		fields = []field{
+			{original.MaxFeePerGas, requested.MaxFeePerGas, &fees.MaxFeePerGas},
+			{original.MaxPriorityFeePerGas, requested.MaxPriorityFeePerGas, &fees.MaxPriorityFeePerGas},
+		}
+	}
+
+	for _, f := range fields {
+		current, ok := new(big.Int).SetString(f.original, 10)
+		if !ok {
+			return nil, domain.ErrInvalidAmount
+		}
+		if f.requested == "" {
+			*f.wei = bumpFee(current, defaultReplacementBump).String()
+			continue
+		}
+
+		value, _ := new(big.Int).SetString(f.requested, 10)
+		if value.Cmp(bumpFee(current, minReplacementBump)) < 0 {
+			return nil, domain.ErrReplacementUnderpriced
+		}
+		*f.wei = f.requested
+	}
+	return fees, nil
+}
+
+// bumpFee 按百分比提高费用，向上取整
+func bumpFee(fee *big.Int, percent int64) *big.Int {
+	bumped := new(big.Int).Mul(fee, big.NewInt(100+percent))
+	bumped.Add(bumped, big.NewInt(99))
+	return bumped.Div(bumped, big.NewInt(100))
+}
+
+func toTransactionResponse(transaction *domain.Transaction) *domain.TransactionResponse {
+	decimals := units.EtherDecimals
+	if transaction.ContractAddress != "" {
+		decimals = transaction.TokenDecimals
+	}
+
+	return &domain.TransactionResponse{
+		ID:                   transaction.ID,
+		Hash:                 transaction.Hash,
+		From:                 transaction.From,
+		To:                   transaction.To,
+		Value:                units.FormatString(transaction.Value, decimals),
+		ContractAddress:      transaction.ContractAddress,
+		TokenSymbol:          transaction.TokenSymbol,
+		GasPrice:             units.FormatString(transaction.GasPrice, units.GweiDecimals),
+		FeeModel:             transaction.FeeModel,
+		MaxFeePerGas:         units.FormatString(transaction.MaxFeePerGas, units.GweiDecimals),
+		MaxPriorityFeePerGas: units.FormatString(transaction.MaxPriorityFeePerGas, units.GweiDecimals),
+		GasLimit:             transaction.GasLimit,
+		GasUsed:              transaction.GasUsed,
+		Nonce:                transaction.Nonce,
+		Status:               transaction.Status,
+		Type:                 transaction.Type,
+		Network:              transaction.Network,
+		BlockNumber:          transaction.BlockNumber,
+		TransactionFee:       units.FormatString(transaction.TransactionFee, units.EtherDecimals),
+		CreatedAt:            transaction.CreatedAt,
+		ConfirmedAt:          transaction.ConfirmedAt,
+		Replaces:             transaction.Replaces,
+		ReplacedBy:           transaction.ReplacedBy,
+	}
+}
+
+// parseDecimal 将十进制金额精确转换为最小单位整数，格式错误统一返回domain.ErrInvalidAmount
+func parseDecimal(amount string, decimals int) (*big.Int, error) {
+	value, err := units.Parse(amount, decimals)
+	if err != nil {
+		return nil, domain.ErrInvalidAmount
+	}
+	return value, nil
+}
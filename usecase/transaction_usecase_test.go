@@ -0,0 +1,689 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/littlecheny/go-backend/domain"
+	"github.com/littlecheny/go-backend/domain/mocks"
+	"github.com/littlecheny/go-backend/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestSendTransaction(t *testing.T) {
+	userID := primitive.NewObjectID()
+	wallet := &domain.WalletResponse{
+		ID:      primitive.NewObjectID(),
+		Address: "0x1111111111111111111111111111111111111111",
+		Network: "sepolia",
+	}
+	to := "0x2222222222222222222222222222222222222222"
+
+	t.Run("success", func(t *testing.T) {
+		mockTransactionRepository := new(mocks.TransactionRepository)
+		mockWalletUsecase := new(mocks.WalletUsecase)
+		mockEthereumService := new(mocks.EthereumService)
+
+		mockWalletUsecase.On("GetWallet", mock.Anything, userID.Hex(), wallet.ID.Hex()).Return(wallet, nil).Once()
+		mockWalletUsecase.On("ExportPrivateKey", mock.Anything, userID.Hex(), wallet.ID.Hex(), "password123").Return("private-key", nil).Once()
+		mockEthereumService.On("GetNonce", mock.Anything, wallet.Address).Return(uint64(7), nil).Once()
+		mockEthereumService.On("SendTransaction", mock.Anything, wallet.Address, to, "private-key", "1500000000000000000", &domain.TransactionFees{
+			MaxFeePerGas:         "30000000000",
+			MaxPriorityFeePerGas: "1500000000",
+			Nonce:                nonce(7),
+		}).Return(&domain.SignedTransaction{
+			Hash:                 "0xhash",
+			From:                 wallet.Address,
+			Nonce:                7,
+			GasLimit:             21000,
+			FeeModel:             domain.FeeModelEIP1559,
+			GasPrice:             "30000000000",
+			MaxFeePerGas:         "30000000000",
+			MaxPriorityFeePerGas: "1500000000",
+		}, nil).Once()
+		mockTransactionRepository.On("Create", mock.Anything, mock.MatchedBy(func(tx *domain.Transaction) bool {
+			return tx.Hash == "0xhash" && tx.Value == "1500000000000000000" && tx.Nonce == 7 &&
+				tx.Status == domain.TransactionStatusPending && tx.Type == domain.TransactionTypeSend && tx.Network == "sepolia" &&
+				tx.FeeModel == domain.FeeModelEIP1559 && tx.MaxFeePerGas == "30000000000" && tx.MaxPriorityFeePerGas == "1500000000"
+		})).Return(nil).Once()
+
+		u := usecase.NewTransactionUsecase(mockTransactionRepository, mockWalletUsecase, networkRegistry(mockEthereumService), new(mocks.RedisService), new(mocks.TokenRegistry), nonceManager(), time.Second*2)
+
+		response, err := u.SendTransaction(context.Background(), userID.Hex(), &domain.TransactionSendRequest{
+			WalletID:             wallet.ID.Hex(),
+			To:                   to,
+			Amount:               "1.5",
+			Password:             "password123",
+			MaxFeePerGas:         "30",
+			MaxPriorityFeePerGas: "1.5",
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "1.5", response.Value)
+		assert.Equal(t, domain.FeeModelEIP1559, response.FeeModel)
+		assert.Equal(t, "30", response.MaxFeePerGas)
+		assert.Equal(t, "1.5", response.MaxPriorityFeePerGas)
+		assert.Equal(t, domain.TransactionStatusPending, response.Status)
+
+		mockTransactionRepository.AssertExpectations(t)
+		mockWalletUsecase.AssertExpectations(t)
+		mockEthereumService.AssertExpectations(t)
+	})
+
+	t.Run("invalid amount", func(t *testing.T) {
+		u := usecase.NewTransactionUsecase(new(mocks.TransactionRepository), new(mocks.WalletUsecase), new(mocks.NetworkRegistry), new(mocks.RedisService), new(mocks.TokenRegistry), new(mocks.NonceManager), time.Second*2)
+
+		for _, amount := range []string{"", "-1", "1e18", "0.0000000000000000001", "1.2.3"} {
+			_, err := u.SendTransaction(context.Background(), userID.Hex(), &domain.TransactionSendRequest{
+				WalletID: wallet.ID.Hex(),
+				To:       to,
+				Amount:   amount,
+				Password: "password123",
+			})
+
+			assert.ErrorIs(t, err, domain.ErrInvalidAmount, amount)
+		}
+	})
+
+	t.Run("legacy gas price", func(t *testing.T) {
+		mockTransactionRepository := new(mocks.TransactionRepository)
+		mockWalletUsecase := new(mocks.WalletUsecase)
+		mockEthereumService := new(mocks.EthereumService)
+
+		mockWalletUsecase.On("GetWallet", mock.Anything, userID.Hex(), wallet.ID.Hex()).Return(wallet, nil).Once()
+		mockWalletUsecase.On("ExportPrivateKey", mock.Anything, userID.Hex(), wallet.ID.Hex(), "password123").Return("private-key", nil).Once()
+		mockEthereumService.On("GetNonce", mock.Anything, wallet.Address).Return(uint64(7), nil).Once()
+		mockEthereumService.On("SendTransaction", mock.Anything, wallet.Address, to, "private-key", "1000000000000000000", &domain.TransactionFees{GasPrice: "2000000000", Nonce: nonce(7)}).Return(&domain.SignedTransaction{
+			Hash:     "0xhash",
+			From:     wallet.Address,
+			FeeModel: domain.FeeModelLegacy,
+			GasPrice: "2000000000",
+		}, nil).Once()
+		mockTransactionRepository.On("Create", mock.Anything, mock.MatchedBy(func(tx *domain.Transaction) bool {
+			return tx.FeeModel == domain.FeeModelLegacy && tx.GasPrice == "2000000000" && tx.MaxFeePerGas == ""
+		})).Return(nil).Once()
+
+		u := usecase.NewTransactionUsecase(mockTransactionRepository, mockWalletUsecase, networkRegistry(mockEthereumService), new(mocks.RedisService), new(mocks.TokenRegistry), nonceManager(), time.Second*2)
+
+		response, err := u.SendTransaction(context.Background(), userID.Hex(), &domain.TransactionSendRequest{
+			WalletID: wallet.ID.Hex(),
+			To:       to,
+			Amount:   "1",
+			Password: "password123",
+			GasPrice: "2",
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, domain.FeeModelLegacy, response.FeeModel)
+		assert.Equal(t, "2", response.GasPrice)
+
+		mockTransactionRepository.AssertExpectations(t)
+		mockWalletUsecase.AssertExpectations(t)
+		mockEthereumService.AssertExpectations(t)
+	})
+
+	t.Run("token transfer", func(t *testing.T) {
+		usdc := &domain.Token{Network: "sepolia", Symbol: "USDC", ContractAddress: "0x1c7D4B196Cb0C7B01d743Fbc6116a902379C7238", Decimals: 6}
+
+		mockTransactionRepository := new(mocks.TransactionRepository)
+		mockWalletUsecase := new(mocks.WalletUsecase)
+		mockEthereumService := new(mocks.EthereumService)
+		mockTokenRegistry := new(mocks.TokenRegistry)
+
+		mockWalletUsecase.On("GetWallet", mock.Anything, userID.Hex(), wallet.ID.Hex()).Return(wallet, nil).Once()
+		mockTokenRegistry.On("GetToken", "sepolia", "0x1c7d4b196cb0c7b01d743fbc6116a902379c7238").Return(usdc, nil).Once()
+		mockWalletUsecase.On("ExportPrivateKey", mock.Anything, userID.Hex(), wallet.ID.Hex(), "password123").Return("private-key", nil).Once()
+		mockEthereumService.On("GetNonce", mock.Anything, wallet.Address).Return(uint64(7), nil).Once()
+		mockEthereumService.On("SendTokenTransfer", mock.Anything, wallet.Address, usdc.ContractAddress, to, "private-key", "12500000", &domain.TransactionFees{Nonce: nonce(7)}).Return(&domain.SignedTransaction{
+			Hash:     "0xhash",
+			From:     wallet.Address,
+			FeeModel: domain.FeeModelEIP1559,
+		}, nil).Once()
+		mockTransactionRepository.On("Create", mock.Anything, mock.MatchedBy(func(tx *domain.Transaction) bool {
+			return tx.To == to && tx.Value == "12500000" && tx.ContractAddress == usdc.ContractAddress &&
+				tx.TokenSymbol == "USDC" && tx.TokenDecimals == 6
+		})).Return(nil).Once()
+
+		u := usecase.NewTransactionUsecase(mockTransactionRepository, mockWalletUsecase, networkRegistry(mockEthereumService), new(mocks.RedisService), mockTokenRegistry, nonceManager(), time.Second*2)
+
+		response, err := u.SendTransaction(context.Background(), userID.Hex(), &domain.TransactionSendRequest{
+			WalletID:        wallet.ID.Hex(),
+			To:              to,
+			Amount:          "12.5",
+			Password:        "password123",
+			ContractAddress: "0x1c7d4b196cb0c7b01d743fbc6116a902379c7238",
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "12.5", response.Value)
+		assert.Equal(t, "USDC", response.TokenSymbol)
+
+		mockTransactionRepository.AssertExpectations(t)
+		mockWalletUsecase.AssertExpectations(t)
+		mockEthereumService.AssertExpectations(t)
+		mockTokenRegistry.AssertExpectations(t)
+	})
+
+	t.Run("unknown token", func(t *testing.T) {
+		mockWalletUsecase := new(mocks.WalletUsecase)
+		mockTokenRegistry := new(mocks.TokenRegistry)
+
+		mockWalletUsecase.On("GetWallet", mock.Anything, userID.Hex(), wallet.ID.Hex()).Return(wallet, nil).Once()
+		mockTokenRegistry.On("GetToken", "sepolia", "0x3333333333333333333333333333333333333333").Return(nil, domain.ErrTokenNotFound).Once()
+
+		u := usecase.NewTransactionUsecase(new(mocks.TransactionRepository), mockWalletUsecase, new(mocks.NetworkRegistry), new(mocks.RedisService), mockTokenRegistry, new(mocks.NonceManager), time.Second*2)
+
+		_, err := u.SendTransaction(context.Background(), userID.Hex(), &domain.TransactionSendRequest{
+			WalletID:        wallet.ID.Hex(),
+			To:              to,
+			Amount:          "1",
+			Password:        "password123",
+			ContractAddress: "0x3333333333333333333333333333333333333333",
+		})
+
+		assert.ErrorIs(t, err, domain.ErrTokenNotFound)
+
+		mockWalletUsecase.AssertExpectations(t)
+		mockTokenRegistry.AssertExpectations(t)
+	})
+
+	t.Run("wrong password", func(t *testing.T) {
+		mockWalletUsecase := new(mocks.WalletUsecase)
+		mockWalletUsecase.On("GetWallet", mock.Anything, userID.Hex(), wallet.ID.Hex()).Return(wallet, nil).Once()
+		mockWalletUsecase.On("ExportPrivateKey", mock.Anything, userID.Hex(), wallet.ID.Hex(), "wrong-password").Return("", domain.ErrInvalidPassword).Once()
+
+		u := usecase.NewTransactionUsecase(new(mocks.TransactionRepository), mockWalletUsecase, networkRegistry(new(mocks.EthereumService)), new(mocks.RedisService), new(mocks.TokenRegistry), nonceManager(), time.Second*2)
+
+		_, err := u.SendTransaction(context.Background(), userID.Hex(), &domain.TransactionSendRequest{
+			WalletID: wallet.ID.Hex(),
+			To:       to,
+			Amount:   "1",
+			Password: "wrong-password",
+		})
+
+		assert.ErrorIs(t, err, domain.ErrInvalidPassword)
+
+		mockWalletUsecase.AssertExpectations(t)
+	})
+}
+
+func TestGetTransaction(t *testing.T) {
+	userID := primitive.NewObjectID()
+	transaction := domain.Transaction{
+		ID:     primitive.NewObjectID(),
+		UserID: userID,
+		Hash:   "0xhash",
+		Value:  "1000000000000000",
+	}
+
+	t.Run("owner", func(t *testing.T) {
+		mockTransactionRepository := new(mocks.TransactionRepository)
+		mockTransactionRepository.On("GetByID", mock.Anything, transaction.ID.Hex()).Return(transaction, nil).Once()
+
+		u := usecase.NewTransactionUsecase(mockTransactionRepository, new(mocks.WalletUsecase), new(mocks.NetworkRegistry), new(mocks.RedisService), new(mocks.TokenRegistry), new(mocks.NonceManager), time.Second*2)
+
+		response, err := u.GetTransaction(context.Background(), userID.Hex(), transaction.ID.Hex())
+
+		assert.NoError(t, err)
+		assert.Equal(t, "0.001", response.Value)
+
+		mockTransactionRepository.AssertExpectations(t)
+	})
+
+	t.Run("other user", func(t *testing.T) {
+		mockTransactionRepository := new(mocks.TransactionRepository)
+		mockTransactionRepository.On("GetByID", mock.Anything, transaction.ID.Hex()).Return(transaction, nil).Once()
+
+		u := usecase.NewTransactionUsecase(mockTransactionRepository, new(mocks.WalletUsecase), new(mocks.NetworkRegistry), new(mocks.RedisService), new(mocks.TokenRegistry), new(mocks.NonceManager), time.Second*2)
+
+		_, err := u.GetTransaction(context.Background(), primitive.NewObjectID().Hex(), transaction.ID.Hex())
+
+		assert.ErrorIs(t, err, domain.ErrTransactionNotFound)
+
+		mockTransactionRepository.AssertExpectations(t)
+	})
+}
+
+func TestGetTransactionByHash(t *testing.T) {
+	userID := primitive.NewObjectID().Hex()
+
+	t.Run("transfer between own wallets", func(t *testing.T) {
+		receive := domain.Transaction{ID: primitive.NewObjectID(), Hash: "0xhash", Type: domain.TransactionTypeReceive}
+		send := domain.Transaction{ID: primitive.NewObjectID(), Hash: "0xhash", Type: domain.TransactionTypeSend}
+
+		mockTransactionRepository := new(mocks.TransactionRepository)
+		mockRedisService := new(mocks.RedisService)
+
+		mockTransactionRepository.On("GetByHashAndUserID", mock.Anything, "0xhash", userID).Return([]domain.Transaction{receive, send}, nil).Once()
+
+		u := usecase.NewTransactionUsecase(mockTransactionRepository, new(mocks.WalletUsecase), new(mocks.NetworkRegistry), mockRedisService, new(mocks.TokenRegistry), new(mocks.NonceManager), time.Second*2)
+
+		response, err := u.GetTransactionByHash(context.Background(), userID, "0xhash")
+
+		assert.NoError(t, err)
+		assert.Equal(t, send.ID, response.ID)
+
+		mockTransactionRepository.AssertExpectations(t)
+		mockRedisService.AssertExpectations(t)
+	})
+
+	t.Run("other user gets on-chain data", func(t *testing.T) {
+		onChain := &domain.TransactionResponse{Hash: "0xhash", From: "0xfrom"}
+
+		mockTransactionRepository := new(mocks.TransactionRepository)
+		mockRedisService := new(mocks.RedisService)
+		mockNetworkRegistry := new(mocks.NetworkRegistry)
+		mockEthereumService := new(mocks.EthereumService)
+
+		mockTransactionRepository.On("GetByHashAndUserID", mock.Anything, "0xhash", userID).Return([]domain.Transaction{}, nil).Once()
+		mockRedisService.On("GetTransaction", mock.Anything, "0xhash").Return(nil, errors.New("key transaction:0xhash not found")).Once()
+		mockNetworkRegistry.On("GetNetworks").Return([]domain.NetworkConfig{{Name: "sepolia"}}).Once()
+		mockNetworkRegistry.On("GetClient", "sepolia").Return(mockEthereumService, nil).Once()
+		mockEthereumService.On("IsConnected").Return(true).Once()
+		mockEthereumService.On("GetTransaction", mock.Anything, "0xhash").Return(onChain, nil).Once()
+		mockRedisService.On("SetTransaction", mock.Anything, "0xhash", onChain, time.Minute).Return(nil).Once()
+
+		u := usecase.NewTransactionUsecase(mockTransactionRepository, new(mocks.WalletUsecase), mockNetworkRegistry, mockRedisService, new(mocks.TokenRegistry), new(mocks.NonceManager), time.Second*2)
+
+		response, err := u.GetTransactionByHash(context.Background(), userID, "0xhash")
+
+		assert.NoError(t, err)
+		assert.Equal(t, primitive.NilObjectID, response.ID)
+		assert.Equal(t, "sepolia", response.Network)
+
+		mockTransactionRepository.AssertExpectations(t)
+		mockRedisService.AssertExpectations(t)
+		mockEthereumService.AssertExpectations(t)
+	})
+}
+
+// networkRegistry 所有网络都返回同一个EthereumService
+func networkRegistry(ethereumService domain.EthereumService) *mocks.NetworkRegistry {
+	registry := new(mocks.NetworkRegistry)
+	registry.On("GetClient", mock.Anything).Return(ethereumService, nil)
+	return registry
+}
+
+func TestSendTransactionNonce(t *testing.T) {
+	userID := primitive.NewObjectID()
+	wallet := &domain.WalletResponse{
+		ID:      primitive.NewObjectID(),
+		Address: "0x1111111111111111111111111111111111111111",
+		Network: "sepolia",
+	}
+	to := "0x2222222222222222222222222222222222222222"
+	req := &domain.TransactionSendRequest{
+		WalletID: wallet.ID.Hex(),
+		To:       to,
+		Amount:   "1",
+		Password: "password123",
+		GasPrice: "2",
+	}
+
+	// send 本地记录的下一个nonce为9，节点pending nonce为7，期望以expected发送
+	send := func(t *testing.T, expected uint64, setup func(*mocks.TransactionRepository, *mocks.EthereumService)) {
+		mockTransactionRepository := new(mocks.TransactionRepository)
+		mockWalletUsecase := new(mocks.WalletUsecase)
+		mockEthereumService := new(mocks.EthereumService)
+		mockNonceManager := new(mocks.NonceManager)
+
+		mockWalletUsecase.On("GetWallet", mock.Anything, userID.Hex(), wallet.ID.Hex()).Return(wallet, nil).Once()
+		mockWalletUsecase.On("ExportPrivateKey", mock.Anything, userID.Hex(), wallet.ID.Hex(), "password123").Return("private-key", nil).Once()
+		mockNonceManager.On("Lock", mock.Anything, "sepolia", wallet.Address).Return(func() {}, nil).Once()
+		mockNonceManager.On("Get", mock.Anything, "sepolia", wallet.Address).Return(uint64(9), true, nil).Once()
+		mockEthereumService.On("GetNonce", mock.Anything, wallet.Address).Return(uint64(7), nil).Once()
+		setup(mockTransactionRepository, mockEthereumService)
+		mockEthereumService.On("SendTransaction", mock.Anything, wallet.Address, to, "private-key", "1000000000000000000", &domain.TransactionFees{GasPrice: "2000000000", Nonce: nonce(expected)}).Return(&domain.SignedTransaction{
+			Hash:     "0xnew",
+			From:     wallet.Address,
+			Nonce:    expected,
+			FeeModel: domain.FeeModelLegacy,
+			GasPrice: "2000000000",
+		}, nil).Once()
+		mockNonceManager.On("Set", mock.Anything, "sepolia", wallet.Address, expected+1).Return(nil).Once()
+		mockTransactionRepository.On("Create", mock.Anything, mock.Anything).Return(nil).Once()
+
+		u := usecase.NewTransactionUsecase(mockTransactionRepository, mockWalletUsecase, networkRegistry(mockEthereumService), new(mocks.RedisService), new(mocks.TokenRegistry), mockNonceManager, time.Second*2)
+
+		response, err := u.SendTransaction(context.Background(), userID.Hex(), req)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "0xnew", response.Hash)
+
+		mockTransactionRepository.AssertExpectations(t)
+		mockEthereumService.AssertExpectations(t)
+		mockNonceManager.AssertExpectations(t)
+	}
+
+	sent := time.Now().Add(-10 * time.Minute)
+
+	t.Run("node lagging behind", func(t *testing.T) {
+		send(t, 9, func(repository *mocks.TransactionRepository, ethereumService *mocks.EthereumService) {
+			repository.On("GetByNonce", mock.Anything, "sepolia", wallet.Address, uint64(7)).Return(domain.Transaction{Hash: "0xseven", CreatedAt: sent}, nil).Once()
+			ethereumService.On("GetTransaction", mock.Anything, "0xseven").Return(&domain.TransactionResponse{Hash: "0xseven"}, nil).Once()
+		})
+	})
+
+	t.Run("recently broadcast transaction not seen yet", func(t *testing.T) {
+		send(t, 9, func(repository *mocks.TransactionRepository, ethereumService *mocks.EthereumService) {
+			repository.On("GetByNonce", mock.Anything, "sepolia", wallet.Address, uint64(7)).Return(domain.Transaction{Hash: "0xseven", CreatedAt: time.Now()}, nil).Once()
+		})
+	})
+
+	t.Run("node lookup failed", func(t *testing.T) {
+		send(t, 9, func(repository *mocks.TransactionRepository, ethereumService *mocks.EthereumService) {
+			repository.On("GetByNonce", mock.Anything, "sepolia", wallet.Address, uint64(7)).Return(domain.Transaction{Hash: "0xseven", CreatedAt: sent}, nil).Once()
+			ethereumService.On("GetTransaction", mock.Anything, "0xseven").Return(nil, errors.New("connection refused")).Once()
+		})
+	})
+
+	t.Run("dropped transaction leaves a gap", func(t *testing.T) {
+		send(t, 7, func(repository *mocks.TransactionRepository, ethereumService *mocks.EthereumService) {
+			repository.On("GetByNonce", mock.Anything, "sepolia", wallet.Address, uint64(7)).Return(domain.Transaction{Hash: "0xseven", CreatedAt: sent}, nil).Once()
+			ethereumService.On("GetTransaction", mock.Anything, "0xseven").Return(nil, domain.ErrTransactionNotFound).Once()
+		})
+	})
+
+	t.Run("unrecorded nonce is not treated as dropped", func(t *testing.T) {
+		send(t, 9, func(repository *mocks.TransactionRepository, ethereumService *mocks.EthereumService) {
+			repository.On("GetByNonce", mock.Anything, "sepolia", wallet.Address, uint64(7)).Return(domain.Transaction{}, domain.ErrTransactionNotFound).Once()
+		})
+	})
+
+	t.Run("wallet busy", func(t *testing.T) {
+		mockWalletUsecase := new(mocks.WalletUsecase)
+		mockNonceManager := new(mocks.NonceManager)
+
+		mockWalletUsecase.On("GetWallet", mock.Anything, userID.Hex(), wallet.ID.Hex()).Return(wallet, nil).Once()
+		mockWalletUsecase.On("ExportPrivateKey", mock.Anything, userID.Hex(), wallet.ID.Hex(), "password123").Return("private-key", nil).Once()
+		mockNonceManager.On("Lock", mock.Anything, "sepolia", wallet.Address).Return(nil, domain.ErrWalletBusy).Once()
+
+		u := usecase.NewTransactionUsecase(new(mocks.TransactionRepository), mockWalletUsecase, networkRegistry(new(mocks.EthereumService)), new(mocks.RedisService), new(mocks.TokenRegistry), mockNonceManager, time.Second*2)
+
+		_, err := u.SendTransaction(context.Background(), userID.Hex(), req)
+
+		assert.ErrorIs(t, err, domain.ErrWalletBusy)
+
+		mockNonceManager.AssertExpectations(t)
+	})
+}
+
+// nonceManager 没有本地nonce记录，发送锁总能立即获取
+func nonceManager() *mocks.NonceManager {
+	manager := new(mocks.NonceManager)
+	manager.On("Lock", mock.Anything, mock.Anything, mock.Anything).Return(func() {}, nil)
+	manager.On("Get", mock.Anything, mock.Anything, mock.Anything).Return(uint64(0), false, nil)
+	manager.On("Set", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	return manager
+}
+
+func nonce(n uint64) *uint64 {
+	return &n
+}
+
+func TestSpeedUpTransaction(t *testing.T) {
+	userID := primitive.NewObjectID()
+	wallet := &domain.WalletResponse{
+		ID:      primitive.NewObjectID(),
+		Address: "0x1111111111111111111111111111111111111111",
+		Network: "sepolia",
+	}
+	original := domain.Transaction{
+		ID:                   primitive.NewObjectID(),
+		UserID:               userID,
+		WalletID:             wallet.ID,
+		Hash:                 "0xoriginal",
+		From:                 wallet.Address,
+		To:                   "0x2222222222222222222222222222222222222222",
+		Value:                "1000000000000000000",
+		FeeModel:             domain.FeeModelEIP1559,
+		GasPrice:             "20000000000",
+		MaxFeePerGas:         "20000000000",
+		MaxPriorityFeePerGas: "1000000000",
+		Nonce:                5,
+		Status:               domain.TransactionStatusPending,
+		Type:                 domain.TransactionTypeSend,
+		Network:              "sepolia",
+	}
+
+	t.Run("default bump", func(t *testing.T) {
+		mockTransactionRepository := new(mocks.TransactionRepository)
+		mockWalletUsecase := new(mocks.WalletUsecase)
+		mockEthereumService := new(mocks.EthereumService)
+		mockRedisService := new(mocks.RedisService)
+
+		mockTransactionRepository.On("GetByID", mock.Anything, original.ID.Hex()).Return(original, nil).Twice()
+		mockWalletUsecase.On("GetWallet", mock.Anything, userID.Hex(), wallet.ID.Hex()).Return(wallet, nil).Once()
+		mockWalletUsecase.On("ExportPrivateKey", mock.Anything, userID.Hex(), wallet.ID.Hex(), "password123").Return("private-key", nil).Once()
+		mockEthereumService.On("SendTransaction", mock.Anything, wallet.Address, original.To, "private-key", original.Value, &domain.TransactionFees{
+			MaxFeePerGas:         "24000000000",
+			MaxPriorityFeePerGas: "1200000000",
+			Nonce:                nonce(5),
+		}).Return(&domain.SignedTransaction{
+			Hash:                 "0xreplacement",
+			From:                 wallet.Address,
+			Nonce:                5,
+			GasLimit:             21000,
+			FeeModel:             domain.FeeModelEIP1559,
+			GasPrice:             "24000000000",
+			MaxFeePerGas:         "24000000000",
+			MaxPriorityFeePerGas: "1200000000",
+		}, nil).Once()
+		mockTransactionRepository.On("Create", mock.Anything, mock.MatchedBy(func(tx *domain.Transaction) bool {
+			return tx.ID != original.ID && tx.Hash == "0xreplacement" && tx.Replaces == "0xoriginal" && tx.Nonce == 5 &&
+				tx.To == original.To && tx.Value == original.Value && tx.Status == domain.TransactionStatusPending
+		})).Return(nil).Once()
+		mockTransactionRepository.On("Update", mock.Anything, mock.MatchedBy(func(tx *domain.Transaction) bool {
+			return tx.ID == original.ID && tx.Status == domain.TransactionStatusReplaced && tx.ReplacedBy == "0xreplacement"
+		})).Return(nil).Once()
+		mockRedisService.On("DeleteTransaction", mock.Anything, "0xoriginal").Return(nil).Once()
+
+		u := usecase.NewTransactionUsecase(mockTransactionRepository, mockWalletUsecase, networkRegistry(mockEthereumService), mockRedisService, new(mocks.TokenRegistry), nonceManager(), time.Second*2)
+
+		response, err := u.SpeedUpTransaction(context.Background(), userID.Hex(), original.ID.Hex(), &domain.TransactionReplaceRequest{Password: "password123"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "0xreplacement", response.Hash)
+		assert.Equal(t, "0xoriginal", response.Replaces)
+		assert.Equal(t, "24", response.MaxFeePerGas)
+		assert.Equal(t, "1", response.Value)
+
+		mockTransactionRepository.AssertExpectations(t)
+		mockWalletUsecase.AssertExpectations(t)
+		mockEthereumService.AssertExpectations(t)
+		mockRedisService.AssertExpectations(t)
+	})
+
+	t.Run("replacement not saved", func(t *testing.T) {
+		mockTransactionRepository := new(mocks.TransactionRepository)
+		mockWalletUsecase := new(mocks.WalletUsecase)
+		mockEthereumService := new(mocks.EthereumService)
+
+		mockTransactionRepository.On("GetByID", mock.Anything, original.ID.Hex()).Return(original, nil).Twice()
+		mockWalletUsecase.On("GetWallet", mock.Anything, userID.Hex(), wallet.ID.Hex()).Return(wallet, nil).Once()
+		mockWalletUsecase.On("ExportPrivateKey", mock.Anything, userID.Hex(), wallet.ID.Hex(), "password123").Return("private-key", nil).Once()
+		mockEthereumService.On("SendTransaction", mock.Anything, wallet.Address, original.To, "private-key", original.Value, mock.Anything).Return(&domain.SignedTransaction{
+			Hash:     "0xreplacement",
+			From:     wallet.Address,
+			Nonce:    5,
+			FeeModel: domain.FeeModelEIP1559,
+		}, nil).Once()
+		mockTransactionRepository.On("Create", mock.Anything, mock.Anything).Return(errors.New("connection refused")).Once()
+
+		u := usecase.NewTransactionUsecase(mockTransactionRepository, mockWalletUsecase, networkRegistry(mockEthereumService), new(mocks.RedisService), new(mocks.TokenRegistry), nonceManager(), time.Second*2)
+
+		_, err := u.SpeedUpTransaction(context.Background(), userID.Hex(), original.ID.Hex(), &domain.TransactionReplaceRequest{Password: "password123"})
+
+		assert.Error(t, err)
+
+		// 原交易保持pending，不标记为replaced
+		mockTransactionRepository.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+		mockTransactionRepository.AssertExpectations(t)
+	})
+
+	t.Run("underpriced", func(t *testing.T) {
+		mockTransactionRepository := new(mocks.TransactionRepository)
+		mockTransactionRepository.On("GetByID", mock.Anything, original.ID.Hex()).Return(original, nil).Once()
+
+		u := usecase.NewTransactionUsecase(mockTransactionRepository, new(mocks.WalletUsecase), new(mocks.NetworkRegistry), new(mocks.RedisService), new(mocks.TokenRegistry), new(mocks.NonceManager), time.Second*2)
+
+		// maxFeePerGas只提高了5%
+		_, err := u.SpeedUpTransaction(context.Background(), userID.Hex(), original.ID.Hex(), &domain.TransactionReplaceRequest{
+			Password:     "password123",
+			MaxFeePerGas: "21",
+		})
+
+		assert.ErrorIs(t, err, domain.ErrReplacementUnderpriced)
+	})
+
+	t.Run("not pending", func(t *testing.T) {
+		confirmed := original
+		confirmed.Status = domain.TransactionStatusConfirmed
+
+		mockTransactionRepository := new(mocks.TransactionRepository)
+		mockTransactionRepository.On("GetByID", mock.Anything, original.ID.Hex()).Return(confirmed, nil).Once()
+
+		u := usecase.NewTransactionUsecase(mockTransactionRepository, new(mocks.WalletUsecase), new(mocks.NetworkRegistry), new(mocks.RedisService), new(mocks.TokenRegistry), new(mocks.NonceManager), time.Second*2)
+
+		_, err := u.SpeedUpTransaction(context.Background(), userID.Hex(), original.ID.Hex(), &domain.TransactionReplaceRequest{Password: "password123"})
+
+		assert.ErrorIs(t, err, domain.ErrNotReplaceable)
+	})
+
+	t.Run("other user", func(t *testing.T) {
+		mockTransactionRepository := new(mocks.TransactionRepository)
+		mockTransactionRepository.On("GetByID", mock.Anything, original.ID.Hex()).Return(original, nil).Once()
+
+		u := usecase.NewTransactionUsecase(mockTransactionRepository, new(mocks.WalletUsecase), new(mocks.NetworkRegistry), new(mocks.RedisService), new(mocks.TokenRegistry), new(mocks.NonceManager), time.Second*2)
+
+		_, err := u.SpeedUpTransaction(context.Background(), primitive.NewObjectID().Hex(), original.ID.Hex(), &domain.TransactionReplaceRequest{Password: "password123"})
+
+		assert.ErrorIs(t, err, domain.ErrTransactionNotFound)
+	})
+}
+
+func TestCancelTransaction(t *testing.T) {
+	userID := primitive.NewObjectID()
+	wallet := &domain.WalletResponse{
+		ID:      primitive.NewObjectID(),
+		Address: "0x1111111111111111111111111111111111111111",
+		Network: "sepolia",
+	}
+	// 代币转账取消后变为0金额的ETH转账
+	original := domain.Transaction{
+		ID:              primitive.NewObjectID(),
+		UserID:          userID,
+		WalletID:        wallet.ID,
+		Hash:            "0xoriginal",
+		From:            wallet.Address,
+		To:              "0x2222222222222222222222222222222222222222",
+		Value:           "5000000",
+		ContractAddress: "0x3333333333333333333333333333333333333333",
+		TokenSymbol:     "USDC",
+		TokenDecimals:   6,
+		FeeModel:        domain.FeeModelLegacy,
+		GasPrice:        "10000000000",
+		Nonce:           3,
+		Status:          domain.TransactionStatusPending,
+		Type:            domain.TransactionTypeSend,
+		Network:         "sepolia",
+	}
+
+	mockTransactionRepository := new(mocks.TransactionRepository)
+	mockWalletUsecase := new(mocks.WalletUsecase)
+	mockEthereumService := new(mocks.EthereumService)
+	mockRedisService := new(mocks.RedisService)
+
+	mockTransactionRepository.On("GetByID", mock.Anything, original.ID.Hex()).Return(original, nil).Twice()
+	mockWalletUsecase.On("GetWallet", mock.Anything, userID.Hex(), wallet.ID.Hex()).Return(wallet, nil).Once()
+	mockWalletUsecase.On("ExportPrivateKey", mock.Anything, userID.Hex(), wallet.ID.Hex(), "password123").Return("private-key", nil).Once()
+	mockEthereumService.On("SendTransaction", mock.Anything, wallet.Address, wallet.Address, "private-key", "0", &domain.TransactionFees{
+		GasPrice: "15000000000",
+		Nonce:    nonce(3),
+	}).Return(&domain.SignedTransaction{
+		Hash:     "0xcancel",
+		From:     wallet.Address,
+		Nonce:    3,
+		GasLimit: 21000,
+		FeeModel: domain.FeeModelLegacy,
+		GasPrice: "15000000000",
+	}, nil).Once()
+	mockTransactionRepository.On("Create", mock.Anything, mock.MatchedBy(func(tx *domain.Transaction) bool {
+		return tx.Hash == "0xcancel" && tx.Replaces == "0xoriginal" && tx.To == wallet.Address && tx.Value == "0" &&
+			tx.ContractAddress == "" && tx.TokenSymbol == ""
+	})).Return(nil).Once()
+	mockTransactionRepository.On("Update", mock.Anything, mock.MatchedBy(func(tx *domain.Transaction) bool {
+		return tx.ID == original.ID && tx.Status == domain.TransactionStatusReplaced && tx.ReplacedBy == "0xcancel"
+	})).Return(nil).Once()
+	mockRedisService.On("DeleteTransaction", mock.Anything, "0xoriginal").Return(nil).Once()
+
+	u := usecase.NewTransactionUsecase(mockTransactionRepository, mockWalletUsecase, networkRegistry(mockEthereumService), mockRedisService, new(mocks.TokenRegistry), nonceManager(), time.Second*2)
+
+	response, err := u.CancelTransaction(context.Background(), userID.Hex(), original.ID.Hex(), &domain.TransactionReplaceRequest{
+		Password: "password123",
+		GasPrice: "15",
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "0xcancel", response.Hash)
+	assert.Equal(t, "0", response.Value)
+	assert.Equal(t, "15", response.GasPrice)
+
+	mockTransactionRepository.AssertExpectations(t)
+	mockWalletUsecase.AssertExpectations(t)
+	mockEthereumService.AssertExpectations(t)
+	mockRedisService.AssertExpectations(t)
+}
+
+func TestGetWalletHistory(t *testing.T) {
+	userID := primitive.NewObjectID()
+	wallet := &domain.WalletResponse{
+		ID:      primitive.NewObjectID(),
+		Address: "0x1111111111111111111111111111111111111111",
+		Network: "sepolia",
+	}
+	confirmed := domain.Transaction{ID: primitive.NewObjectID(), Hash: "0xconfirmed", Value: "1000000000000000000", Status: domain.TransactionStatusConfirmed, BlockNumber: 90}
+	pending := domain.Transaction{ID: primitive.NewObjectID(), Hash: "0xpending", Value: "2000000000000000000", Status: domain.TransactionStatusPending}
+	dropped := domain.Transaction{ID: primitive.NewObjectID(), Hash: "0xdropped", Value: "3000000000000000000", Status: domain.TransactionStatusPending}
+
+	mockTransactionRepository := new(mocks.TransactionRepository)
+	mockWalletUsecase := new(mocks.WalletUsecase)
+	mockEthereumService := new(mocks.EthereumService)
+
+	mockWalletUsecase.On("GetWallet", mock.Anything, userID.Hex(), wallet.ID.Hex()).Return(wallet, nil).Once()
+	mockTransactionRepository.On("GetByWalletID", mock.Anything, wallet.ID.Hex(), 20, 0).Return([]domain.Transaction{pending, dropped, confirmed}, nil).Once()
+	mockEthereumService.On("IsConnected").Return(true)
+	// 已上链但还没有达到确认数
+	mockEthereumService.On("GetTransaction", mock.Anything, "0xpending").Return(&domain.TransactionResponse{
+		Hash:           "0xpending",
+		Value:          "2",
+		Status:         domain.TransactionStatusConfirmed,
+		BlockNumber:    100,
+		GasUsed:        21000,
+		TransactionFee: "0.000042",
+		Logs:           []domain.TransactionLog{{Address: wallet.Address}},
+	}, nil).Once()
+	mockEthereumService.On("GetTransaction", mock.Anything, "0xdropped").Return(nil, errors.New("not found")).Once()
+
+	u := usecase.NewTransactionUsecase(mockTransactionRepository, mockWalletUsecase, networkRegistry(mockEthereumService), new(mocks.RedisService), new(mocks.TokenRegistry), new(mocks.NonceManager), time.Second*2)
+
+	history, err := u.GetWalletHistory(context.Background(), userID.Hex(), wallet.ID.Hex(), 0, 0)
+
+	assert.NoError(t, err)
+	assert.Len(t, history, 3)
+	assert.Equal(t, domain.TransactionStatusConfirmed, history[0].Status)
+	assert.Equal(t, uint64(100), history[0].BlockNumber)
+	assert.Equal(t, "0.000042", history[0].TransactionFee)
+	assert.Len(t, history[0].Logs, 1)
+	assert.Equal(t, domain.TransactionStatusPending, history[1].Status)
+	assert.Equal(t, "3", history[1].Value)
+	assert.Equal(t, uint64(90), history[2].BlockNumber)
+
+	mockTransactionRepository.AssertExpectations(t)
+	mockWalletUsecase.AssertExpectations(t)
+	mockEthereumService.AssertExpectations(t)
+}
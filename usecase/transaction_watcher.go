@@ -0,0 +1,342 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/littlecheny/go-backend/domain"
+	"github.com/littlecheny/go-backend/internal/units"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// scanBatchBlocks 每批扫描的区块数，每批完成后保存进度；同时限制单次eth_getLogs的区块范围
+const scanBatchBlocks = 100
+
+type transactionWatcher struct {
+	network               string
+	walletRepository      domain.WalletRepository
+	transactionRepository domain.TransactionRepository
+	ethereumService       domain.EthereumService
+	tokenRegistry         domain.TokenRegistry
+	cache                 domain.RedisService
+	contextTimeout        time.Duration
+}
+
+// NewTransactionWatcher 每个网络各自运行一个TransactionWatcher，只记录该网络钱包的交易；
+// 已处理到的区块号保存在cache中，重启后从中断处继续
+func NewTransactionWatcher(network string, walletRepository domain.WalletRepository, transactionRepository domain.TransactionRepository, ethereumService domain.EthereumService, tokenRegistry domain.TokenRegistry, cache domain.RedisService, timeout time.Duration) domain.TransactionWatcher {
+	return &transactionWatcher{
+		network:               network,
+		walletRepository:      walletRepository,
+		transactionRepository: transactionRepository,
+		ethereumService:       ethereumService,
+		tokenRegistry:         tokenRegistry,
+		cache:                 cache,
+		contextTimeout:        timeout,
+	}
+}
+
+// Run 节点连接后订阅新区块头，每次(重新)订阅后先补扫到当前链头，之后每个新区块头触发一次CatchUp；
+// 订阅断开后等待resubscribeDelay重新订阅，直到ctx取消
+func (tw *transactionWatcher) Run(ctx context.Context) error {
+	for waitConnected(ctx, tw.ethereumService) {
+		heads, err := tw.ethereumService.SubscribeNewHeads(ctx)
+		if err != nil {
+			// 检查之后连接又断开时继续等待重连
+			if !tw.ethereumService.IsConnected() {
+				continue
+			}
+			return err
+		}
+
+		err = tw.catchUpLatest(ctx)
+		if err != nil {
+			log.Printf("failed to scan missed blocks on %s: %v", tw.network, err)
+		}
+
+		for head := range heads {
+			err = tw.CatchUp(ctx, head.Number)
+			if err != nil {
+				log.Printf("failed to scan blocks up to %d on %s: %v", head.Number, tw.network, err)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(resubscribeDelay):
+		}
+	}
+	return nil
+}
+
+func (tw *transactionWatcher) catchUpLatest(c context.Context) error {
+	ctx, cancel := context.WithTimeout(c, tw.contextTimeout)
+	latest, err := tw.ethereumService.GetLatestBlock(ctx)
+	cancel()
+	if err != nil {
+		return err
+	}
+
+	return tw.CatchUp(c, latest.Number)
+}
+
+// CatchUp 没有进度记录时(首次运行)只扫描head；每批扫描前重新加载钱包列表，新建的钱包无需重新订阅。
+// 扫描失败时不保存进度，下次从失败的批次重试，重复推送的交易由createReceive去重
+func (tw *transactionWatcher) CatchUp(c context.Context, head uint64) error {
+	last, ok, err := tw.lastBlock(c)
+	if err != nil {
+		return err
+	}
+
+	from := head
+	if ok {
+		if last >= head {
+			return nil
+		}
+		from = last + 1
+	}
+
+	for from <= head {
+		to := from + scanBatchBlocks - 1
+		if to > head {
+			to = head
+		}
+
+		err = tw.scan(c, from, to)
+		if err != nil {
+			return err
+		}
+
+		err = tw.saveLastBlock(c, to)
+		if err != nil {
+			return err
+		}
+		from = to + 1
+	}
+	return nil
+}
+
+// scan 扫描[from, to]区间内的ETH转账和代币Transfer事件
+func (tw *transactionWatcher) scan(c context.Context, from, to uint64) error {
+	addresses, err := tw.addresses(c)
+	if err != nil || len(addresses) == 0 {
+		return err
+	}
+
+	for number := from; number <= to; number++ {
+		ctx, cancel := context.WithTimeout(c, tw.contextTimeout)
+		transactions, err := tw.ethereumService.ScanTransactions(ctx, number, addresses)
+		cancel()
+		if err != nil {
+			return fmt.Errorf("block %d: %w", number, err)
+		}
+
+		for i := range transactions {
+			err = tw.HandleTransaction(c, &transactions[i])
+			if err != nil {
+				return fmt.Errorf("transaction %s: %w", transactions[i].Hash, err)
+			}
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(c, tw.contextTimeout)
+	transfers, err := tw.ethereumService.GetTokenTransfers(ctx, from, to, tw.tokenAddresses(), addresses)
+	cancel()
+	if err != nil {
+		return err
+	}
+
+	for i := range transfers {
+		err = tw.HandleTokenTransfer(c, &transfers[i])
+		if err != nil {
+			return fmt.Errorf("token transfer %s: %w", transfers[i].Hash, err)
+		}
+	}
+	return nil
+}
+
+func (tw *transactionWatcher) lastBlock(c context.Context) (uint64, bool, error) {
+	ctx, cancel := context.WithTimeout(c, tw.contextTimeout)
+	defer cancel()
+
+	key := watcherBlockKey(tw.network)
+	exists, err := tw.cache.Exists(ctx, key)
+	if err != nil || !exists {
+		return 0, false, err
+	}
+
+	value, err := tw.cache.Get(ctx, key)
+	if err != nil {
+		return 0, false, err
+	}
+
+	var number uint64
+	err = json.Unmarshal([]byte(value), &number)
+	if err != nil {
+		return 0, false, fmt.Errorf("invalid block number for key %s: %v", key, err)
+	}
+	return number, true, nil
+}
+
+// saveLastBlock 进度不过期，停机再久重启后也能补扫
+func (tw *transactionWatcher) saveLastBlock(c context.Context, number uint64) error {
+	ctx, cancel := context.WithTimeout(c, tw.contextTimeout)
+	defer cancel()
+
+	return tw.cache.Set(ctx, watcherBlockKey(tw.network), number, 0)
+}
+
+func watcherBlockKey(network string) string {
+	return fmt.Sprintf("watcher_block:%s", network)
+}
+
+// HandleTransaction 转入托管钱包的交易创建为receive记录，状态由TransactionTracker确认；
+// 多个用户托管同一地址时每个钱包各有一条记录
+func (tw *transactionWatcher) HandleTransaction(c context.Context, tx *domain.TransactionResponse) error {
+	ctx, cancel := context.WithTimeout(c, tw.contextTimeout)
+	defer cancel()
+
+	if !common.IsHexAddress(tx.To) {
+		return nil
+	}
+
+	wallets, err := tw.wallets(ctx, tx.To)
+	if err != nil || len(wallets) == 0 {
+		return err
+	}
+
+	value, err := parseDecimal(tx.Value, units.EtherDecimals)
+	if err != nil {
+		return err
+	}
+
+	fees, err := gweiToWeiFees(tx.GasPrice, tx.MaxFeePerGas, tx.MaxPriorityFeePerGas)
+	if err != nil {
+		return err
+	}
+
+	for _, wallet := range wallets {
+		now := time.Now()
+		err = tw.createReceive(ctx, &domain.Transaction{
+			ID:                   primitive.NewObjectID(),
+			UserID:               wallet.UserID,
+			WalletID:             wallet.ID,
+			Hash:                 tx.Hash,
+			From:                 tx.From,
+			To:                   wallet.Address,
+			Value:                value.String(),
+			GasPrice:             fees.GasPrice,
+			FeeModel:             tx.FeeModel,
+			MaxFeePerGas:         fees.MaxFeePerGas,
+			MaxPriorityFeePerGas: fees.MaxPriorityFeePerGas,
+			GasLimit:             tx.GasLimit,
+			Status:               domain.TransactionStatusPending,
+			Type:                 domain.TransactionTypeReceive,
+			Network:              wallet.Network,
+			CreatedAt:            now,
+			UpdatedAt:            now,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// HandleTokenTransfer 转入托管钱包的已登记代币创建为receive记录，未登记的代币忽略
+func (tw *transactionWatcher) HandleTokenTransfer(c context.Context, transfer *domain.TokenTransfer) error {
+	ctx, cancel := context.WithTimeout(c, tw.contextTimeout)
+	defer cancel()
+
+	// 被重组移除的日志由ChainFollower回滚
+	if transfer.Removed {
+		return nil
+	}
+
+	wallets, err := tw.wallets(ctx, transfer.To)
+	if err != nil || len(wallets) == 0 {
+		return err
+	}
+
+	token, err := tw.tokenRegistry.GetToken(tw.network, transfer.ContractAddress)
+	if errors.Is(err, domain.ErrTokenNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	for _, wallet := range wallets {
+		now := time.Now()
+		err = tw.createReceive(ctx, &domain.Transaction{
+			ID:              primitive.NewObjectID(),
+			UserID:          wallet.UserID,
+			WalletID:        wallet.ID,
+			Hash:            transfer.Hash,
+			From:            transfer.From,
+			To:              wallet.Address,
+			Value:           transfer.Amount,
+			ContractAddress: token.ContractAddress,
+			TokenSymbol:     token.Symbol,
+			TokenDecimals:   token.Decimals,
+			Status:          domain.TransactionStatusPending,
+			Type:            domain.TransactionTypeReceive,
+			Network:         wallet.Network,
+			CreatedAt:       now,
+			UpdatedAt:       now,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// createReceive 钱包已有该哈希的记录时跳过，同一交易可能被重复推送
+func (tw *transactionWatcher) createReceive(ctx context.Context, transaction *domain.Transaction) error {
+	_, err := tw.transactionRepository.GetByHashAndWalletID(ctx, transaction.Hash, transaction.WalletID.Hex())
+	if err == nil {
+		return nil
+	}
+	if !errors.Is(err, domain.ErrTransactionNotFound) {
+		return err
+	}
+
+	return tw.transactionRepository.Create(ctx, transaction)
+}
+
+// wallets 查找本网络中地址对应的所有托管钱包，同一地址在其他网络上的钱包与本网络的转账无关
+func (tw *transactionWatcher) wallets(ctx context.Context, address string) ([]domain.Wallet, error) {
+	return tw.walletRepository.GetByAddressAndNetwork(ctx, common.HexToAddress(address).Hex(), tw.network)
+}
+
+func (tw *transactionWatcher) tokenAddresses() []string {
+	tokens := tw.tokenRegistry.GetTokens(tw.network)
+	addresses := make([]string, 0, len(tokens))
+	for _, token := range tokens {
+		addresses = append(addresses, token.ContractAddress)
+	}
+	return addresses
+}
+
+func (tw *transactionWatcher) addresses(c context.Context) ([]string, error) {
+	ctx, cancel := context.WithTimeout(c, tw.contextTimeout)
+	defer cancel()
+
+	wallets, err := tw.walletRepository.GetActiveWallets(ctx, tw.network)
+	if err != nil {
+		return nil, err
+	}
+
+	addresses := make([]string, 0, len(wallets))
+	for _, wallet := range wallets {
+		addresses = append(addresses, wallet.Address)
+	}
+	return addresses, nil
+}
@@ -0,0 +1,281 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/littlecheny/go-backend/domain"
+	"github.com/littlecheny/go-backend/domain/mocks"
+	"github.com/littlecheny/go-backend/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestHandleTransaction(t *testing.T) {
+	wallet := &domain.Wallet{
+		ID:      primitive.NewObjectID(),
+		UserID:  primitive.NewObjectID(),
+		Address: "0x2222222222222222222222222222222222222222",
+		Network: "sepolia",
+	}
+	incoming := &domain.TransactionResponse{
+		Hash:                 "0xhash",
+		From:                 "0x1111111111111111111111111111111111111111",
+		To:                   "0x2222222222222222222222222222222222222222",
+		Value:                "0.25",
+		GasPrice:             "30",
+		FeeModel:             domain.FeeModelEIP1559,
+		MaxFeePerGas:         "30",
+		MaxPriorityFeePerGas: "1.5",
+		GasLimit:             21000,
+		Status:               domain.TransactionStatusConfirmed,
+		Type:                 domain.TransactionTypeReceive,
+	}
+
+	t.Run("create receive record", func(t *testing.T) {
+		mockWalletRepository := new(mocks.WalletRepository)
+		mockTransactionRepository := new(mocks.TransactionRepository)
+
+		mockWalletRepository.On("GetByAddressAndNetwork", mock.Anything, wallet.Address, wallet.Network).Return([]domain.Wallet{*wallet}, nil).Once()
+		mockTransactionRepository.On("GetByHashAndWalletID", mock.Anything, "0xhash", wallet.ID.Hex()).Return(domain.Transaction{}, domain.ErrTransactionNotFound).Once()
+		mockTransactionRepository.On("Create", mock.Anything, mock.MatchedBy(func(tx *domain.Transaction) bool {
+			return tx.Hash == "0xhash" && tx.UserID == wallet.UserID && tx.WalletID == wallet.ID &&
+				tx.Type == domain.TransactionTypeReceive && tx.Status == domain.TransactionStatusPending &&
+				tx.Value == "250000000000000000" && tx.MaxPriorityFeePerGas == "1500000000" && tx.Network == "sepolia"
+		})).Return(nil).Once()
+
+		watcher := usecase.NewTransactionWatcher("sepolia", mockWalletRepository, mockTransactionRepository, new(mocks.EthereumService), new(mocks.TokenRegistry), new(mocks.RedisService), time.Second*2)
+
+		err := watcher.HandleTransaction(context.Background(), incoming)
+
+		assert.NoError(t, err)
+
+		mockWalletRepository.AssertExpectations(t)
+		mockTransactionRepository.AssertExpectations(t)
+	})
+
+	t.Run("already recorded", func(t *testing.T) {
+		mockWalletRepository := new(mocks.WalletRepository)
+		mockTransactionRepository := new(mocks.TransactionRepository)
+
+		mockWalletRepository.On("GetByAddressAndNetwork", mock.Anything, wallet.Address, wallet.Network).Return([]domain.Wallet{*wallet}, nil).Once()
+		mockTransactionRepository.On("GetByHashAndWalletID", mock.Anything, "0xhash", wallet.ID.Hex()).Return(domain.Transaction{Hash: "0xhash"}, nil).Once()
+
+		watcher := usecase.NewTransactionWatcher("sepolia", mockWalletRepository, mockTransactionRepository, new(mocks.EthereumService), new(mocks.TokenRegistry), new(mocks.RedisService), time.Second*2)
+
+		err := watcher.HandleTransaction(context.Background(), incoming)
+
+		assert.NoError(t, err)
+
+		mockWalletRepository.AssertExpectations(t)
+		mockTransactionRepository.AssertExpectations(t)
+	})
+
+	t.Run("address managed by several users", func(t *testing.T) {
+		other := domain.Wallet{ID: primitive.NewObjectID(), UserID: primitive.NewObjectID(), Address: wallet.Address, Network: "sepolia"}
+		mockWalletRepository := new(mocks.WalletRepository)
+		mockTransactionRepository := new(mocks.TransactionRepository)
+
+		mockWalletRepository.On("GetByAddressAndNetwork", mock.Anything, wallet.Address, "sepolia").Return([]domain.Wallet{*wallet, other}, nil).Once()
+		mockTransactionRepository.On("GetByHashAndWalletID", mock.Anything, "0xhash", wallet.ID.Hex()).Return(domain.Transaction{Hash: "0xhash"}, nil).Once()
+		mockTransactionRepository.On("GetByHashAndWalletID", mock.Anything, "0xhash", other.ID.Hex()).Return(domain.Transaction{}, domain.ErrTransactionNotFound).Once()
+		mockTransactionRepository.On("Create", mock.Anything, mock.MatchedBy(func(tx *domain.Transaction) bool {
+			return tx.UserID == other.UserID && tx.WalletID == other.ID
+		})).Return(nil).Once()
+
+		watcher := usecase.NewTransactionWatcher("sepolia", mockWalletRepository, mockTransactionRepository, new(mocks.EthereumService), new(mocks.TokenRegistry), new(mocks.RedisService), time.Second*2)
+
+		err := watcher.HandleTransaction(context.Background(), incoming)
+
+		assert.NoError(t, err)
+
+		mockWalletRepository.AssertExpectations(t)
+		mockTransactionRepository.AssertExpectations(t)
+	})
+
+	t.Run("not a managed wallet", func(t *testing.T) {
+		mockWalletRepository := new(mocks.WalletRepository)
+		mockWalletRepository.On("GetByAddressAndNetwork", mock.Anything, wallet.Address, "sepolia").Return([]domain.Wallet{}, nil).Once()
+
+		watcher := usecase.NewTransactionWatcher("sepolia", mockWalletRepository, new(mocks.TransactionRepository), new(mocks.EthereumService), new(mocks.TokenRegistry), new(mocks.RedisService), time.Second*2)
+
+		err := watcher.HandleTransaction(context.Background(), incoming)
+
+		assert.NoError(t, err)
+
+		mockWalletRepository.AssertExpectations(t)
+	})
+}
+
+func TestHandleTokenTransfer(t *testing.T) {
+	wallet := &domain.Wallet{
+		ID:      primitive.NewObjectID(),
+		UserID:  primitive.NewObjectID(),
+		Address: "0x2222222222222222222222222222222222222222",
+		Network: "mainnet",
+	}
+	usdc := &domain.Token{Network: "mainnet", Symbol: "USDC", ContractAddress: "0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48", Decimals: 6}
+	transfer := &domain.TokenTransfer{
+		Hash:            "0xhash",
+		ContractAddress: usdc.ContractAddress,
+		From:            "0x1111111111111111111111111111111111111111",
+		To:              wallet.Address,
+		Amount:          "2500000",
+	}
+
+	t.Run("registered token", func(t *testing.T) {
+		mockWalletRepository := new(mocks.WalletRepository)
+		mockTransactionRepository := new(mocks.TransactionRepository)
+		mockTokenRegistry := new(mocks.TokenRegistry)
+
+		mockWalletRepository.On("GetByAddressAndNetwork", mock.Anything, wallet.Address, wallet.Network).Return([]domain.Wallet{*wallet}, nil).Once()
+		mockTokenRegistry.On("GetToken", "mainnet", usdc.ContractAddress).Return(usdc, nil).Once()
+		mockTransactionRepository.On("GetByHashAndWalletID", mock.Anything, "0xhash", wallet.ID.Hex()).Return(domain.Transaction{}, domain.ErrTransactionNotFound).Once()
+		mockTransactionRepository.On("Create", mock.Anything, mock.MatchedBy(func(tx *domain.Transaction) bool {
+			return tx.Type == domain.TransactionTypeReceive && tx.Value == "2500000" && tx.ContractAddress == usdc.ContractAddress &&
+				tx.TokenSymbol == "USDC" && tx.TokenDecimals == 6 && tx.WalletID == wallet.ID
+		})).Return(nil).Once()
+
+		watcher := usecase.NewTransactionWatcher("mainnet", mockWalletRepository, mockTransactionRepository, new(mocks.EthereumService), mockTokenRegistry, new(mocks.RedisService), time.Second*2)
+
+		err := watcher.HandleTokenTransfer(context.Background(), transfer)
+
+		assert.NoError(t, err)
+
+		mockWalletRepository.AssertExpectations(t)
+		mockTransactionRepository.AssertExpectations(t)
+		mockTokenRegistry.AssertExpectations(t)
+	})
+
+	t.Run("unregistered token", func(t *testing.T) {
+		mockWalletRepository := new(mocks.WalletRepository)
+		mockTokenRegistry := new(mocks.TokenRegistry)
+
+		mockWalletRepository.On("GetByAddressAndNetwork", mock.Anything, wallet.Address, wallet.Network).Return([]domain.Wallet{*wallet}, nil).Once()
+		mockTokenRegistry.On("GetToken", "mainnet", usdc.ContractAddress).Return(nil, domain.ErrTokenNotFound).Once()
+
+		watcher := usecase.NewTransactionWatcher("mainnet", mockWalletRepository, new(mocks.TransactionRepository), new(mocks.EthereumService), mockTokenRegistry, new(mocks.RedisService), time.Second*2)
+
+		err := watcher.HandleTokenTransfer(context.Background(), transfer)
+
+		assert.NoError(t, err)
+
+		mockWalletRepository.AssertExpectations(t)
+		mockTokenRegistry.AssertExpectations(t)
+	})
+}
+
+func TestCatchUp(t *testing.T) {
+	wallet := domain.Wallet{
+		ID:      primitive.NewObjectID(),
+		UserID:  primitive.NewObjectID(),
+		Address: "0x2222222222222222222222222222222222222222",
+		Network: "sepolia",
+	}
+	usdc := domain.Token{Network: "sepolia", Symbol: "USDC", ContractAddress: "0x1c7D4B196Cb0C7B01d743Fbc6116a902379C7238", Decimals: 6}
+	incoming := domain.TransactionResponse{
+		Hash:     "0xmissed",
+		From:     "0x1111111111111111111111111111111111111111",
+		To:       wallet.Address,
+		Value:    "0.25",
+		GasPrice: "30",
+		FeeModel: domain.FeeModelLegacy,
+		GasLimit: 21000,
+		Type:     domain.TransactionTypeReceive,
+	}
+	key := "watcher_block:sepolia"
+
+	t.Run("backfills blocks since last run", func(t *testing.T) {
+		mockWalletRepository := new(mocks.WalletRepository)
+		mockTransactionRepository := new(mocks.TransactionRepository)
+		mockEthereumService := new(mocks.EthereumService)
+		mockTokenRegistry := new(mocks.TokenRegistry)
+		mockCache := new(mocks.RedisService)
+
+		mockCache.On("Exists", mock.Anything, key).Return(true, nil).Once()
+		mockCache.On("Get", mock.Anything, key).Return("10", nil).Once()
+		mockWalletRepository.On("GetActiveWallets", mock.Anything, "sepolia").Return([]domain.Wallet{wallet}, nil).Once()
+		mockEthereumService.On("ScanTransactions", mock.Anything, uint64(11), []string{wallet.Address}).Return([]domain.TransactionResponse{incoming}, nil).Once()
+		mockEthereumService.On("ScanTransactions", mock.Anything, uint64(12), []string{wallet.Address}).Return(nil, nil).Once()
+		mockTokenRegistry.On("GetTokens", "sepolia").Return([]domain.Token{usdc}).Once()
+		mockEthereumService.On("GetTokenTransfers", mock.Anything, uint64(11), uint64(12), []string{usdc.ContractAddress}, []string{wallet.Address}).Return(nil, nil).Once()
+		mockWalletRepository.On("GetByAddressAndNetwork", mock.Anything, wallet.Address, "sepolia").Return([]domain.Wallet{wallet}, nil).Once()
+		mockTransactionRepository.On("GetByHashAndWalletID", mock.Anything, "0xmissed", wallet.ID.Hex()).Return(domain.Transaction{}, domain.ErrTransactionNotFound).Once()
+		mockTransactionRepository.On("Create", mock.Anything, mock.MatchedBy(func(tx *domain.Transaction) bool {
+			return tx.Hash == "0xmissed" && tx.WalletID == wallet.ID && tx.Type == domain.TransactionTypeReceive
+		})).Return(nil).Once()
+		mockCache.On("Set", mock.Anything, key, uint64(12), time.Duration(0)).Return(nil).Once()
+
+		watcher := usecase.NewTransactionWatcher("sepolia", mockWalletRepository, mockTransactionRepository, mockEthereumService, mockTokenRegistry, mockCache, time.Second*2)
+
+		err := watcher.CatchUp(context.Background(), 12)
+
+		assert.NoError(t, err)
+
+		mockWalletRepository.AssertExpectations(t)
+		mockTransactionRepository.AssertExpectations(t)
+		mockEthereumService.AssertExpectations(t)
+		mockCache.AssertExpectations(t)
+	})
+
+	t.Run("first run starts at head", func(t *testing.T) {
+		mockWalletRepository := new(mocks.WalletRepository)
+		mockEthereumService := new(mocks.EthereumService)
+		mockTokenRegistry := new(mocks.TokenRegistry)
+		mockCache := new(mocks.RedisService)
+
+		mockCache.On("Exists", mock.Anything, key).Return(false, nil).Once()
+		mockWalletRepository.On("GetActiveWallets", mock.Anything, "sepolia").Return([]domain.Wallet{wallet}, nil).Once()
+		mockEthereumService.On("ScanTransactions", mock.Anything, uint64(7), []string{wallet.Address}).Return(nil, nil).Once()
+		mockTokenRegistry.On("GetTokens", "sepolia").Return([]domain.Token{}).Once()
+		mockEthereumService.On("GetTokenTransfers", mock.Anything, uint64(7), uint64(7), []string{}, []string{wallet.Address}).Return(nil, nil).Once()
+		mockCache.On("Set", mock.Anything, key, uint64(7), time.Duration(0)).Return(nil).Once()
+
+		watcher := usecase.NewTransactionWatcher("sepolia", mockWalletRepository, new(mocks.TransactionRepository), mockEthereumService, mockTokenRegistry, mockCache, time.Second*2)
+
+		err := watcher.CatchUp(context.Background(), 7)
+
+		assert.NoError(t, err)
+
+		mockEthereumService.AssertExpectations(t)
+		mockCache.AssertExpectations(t)
+	})
+
+	t.Run("failed scan keeps progress", func(t *testing.T) {
+		mockWalletRepository := new(mocks.WalletRepository)
+		mockEthereumService := new(mocks.EthereumService)
+		mockCache := new(mocks.RedisService)
+
+		mockCache.On("Exists", mock.Anything, key).Return(true, nil).Once()
+		mockCache.On("Get", mock.Anything, key).Return("10", nil).Once()
+		mockWalletRepository.On("GetActiveWallets", mock.Anything, "sepolia").Return([]domain.Wallet{wallet}, nil).Once()
+		mockEthereumService.On("ScanTransactions", mock.Anything, uint64(11), []string{wallet.Address}).Return(nil, errors.New("connection refused")).Once()
+
+		watcher := usecase.NewTransactionWatcher("sepolia", mockWalletRepository, new(mocks.TransactionRepository), mockEthereumService, new(mocks.TokenRegistry), mockCache, time.Second*2)
+
+		err := watcher.CatchUp(context.Background(), 12)
+
+		assert.Error(t, err)
+
+		mockEthereumService.AssertExpectations(t)
+		mockCache.AssertNotCalled(t, "Set", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("already up to date", func(t *testing.T) {
+		mockCache := new(mocks.RedisService)
+
+		mockCache.On("Exists", mock.Anything, key).Return(true, nil).Once()
+		mockCache.On("Get", mock.Anything, key).Return("12", nil).Once()
+
+		watcher := usecase.NewTransactionWatcher("sepolia", new(mocks.WalletRepository), new(mocks.TransactionRepository), new(mocks.EthereumService), new(mocks.TokenRegistry), mockCache, time.Second*2)
+
+		err := watcher.CatchUp(context.Background(), 12)
+
+		assert.NoError(t, err)
+
+		mockCache.AssertExpectations(t)
+	})
+}
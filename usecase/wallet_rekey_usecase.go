@@ -0,0 +1,85 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/littlecheny/go-backend/domain"
+)
+
+type walletRekeyUsecase struct {
+	walletRepository domain.WalletRepository
+	keyManager       domain.WalletKeyManager
+	contextTimeout   time.Duration
+}
+
+func NewWalletRekeyUsecase(walletRepository domain.WalletRepository, keyManager domain.WalletKeyManager, timeout time.Duration) domain.WalletRekeyUsecase {
+	return &walletRekeyUsecase{
+		walletRepository: walletRepository,
+		keyManager:       keyManager,
+		contextTimeout:   timeout,
+	}
+}
+
+// RekeyPrivateData 分批将旧主密钥包裹的数据密钥改用当前主密钥包裹，私钥密文保持不变；单条失败不影响其余记录。
+// 信封加密之前的旧记录没有数据密钥，只能在用户输入密码时迁移，这里单独计数。
+// batchSize必须为正数，Mongo的limit为0时不限制数量
+func (ru *walletRekeyUsecase) RekeyPrivateData(c context.Context, batchSize int) (*domain.WalletRekeyResult, error) {
+	if batchSize < 1 {
+		return nil, fmt.Errorf("batch size must be positive, got %d", batchSize)
+	}
+
+	result := &domain.WalletRekeyResult{Version: ru.keyManager.CurrentVersion()}
+
+	afterID := ""
+	for {
+		batch, err := ru.rekeyBatch(c, result, afterID, batchSize)
+		if err != nil {
+			return result, err
+		}
+		if len(batch) < batchSize {
+			return result, nil
+		}
+		afterID = batch[len(batch)-1].ID.Hex()
+	}
+}
+
+func (ru *walletRekeyUsecase) rekeyBatch(c context.Context, result *domain.WalletRekeyResult, afterID string, batchSize int) ([]domain.WalletPrivateData, error) {
+	ctx, cancel := context.WithTimeout(c, ru.contextTimeout)
+	defer cancel()
+
+	batch, err := ru.walletRepository.GetStalePrivateData(ctx, result.Version, afterID, batchSize)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, data := range batch {
+		if data.IsLegacy() {
+			log.Printf("wallet %s still uses legacy encryption, it is migrated on next unlock", data.WalletID.Hex())
+			result.Legacy++
+			continue
+		}
+
+		wrappedKey, version, err := ru.keyManager.RewrapDataKey(data.EncryptedDataKey, data.MasterKeyVersion)
+		if err != nil {
+			log.Printf("failed to rewrap data key of wallet %s: %v", data.WalletID.Hex(), err)
+			result.Failed++
+			continue
+		}
+
+		updated, err := ru.walletRepository.UpdateDataKey(ctx, data.ID.Hex(), data.MasterKeyVersion, wrappedKey, version)
+		if err != nil {
+			return nil, err
+		}
+		if !updated {
+			// 记录在读取后已被并发修改，版本条件未匹配，留给下次运行处理
+			result.Skipped++
+			continue
+		}
+		result.Rewrapped++
+	}
+
+	return batch, nil
+}
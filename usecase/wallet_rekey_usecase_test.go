@@ -0,0 +1,71 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/littlecheny/go-backend/domain"
+	"github.com/littlecheny/go-backend/domain/mocks"
+	"github.com/littlecheny/go-backend/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestRekeyPrivateData(t *testing.T) {
+	first := domain.WalletPrivateData{ID: primitive.NewObjectID(), EncryptedDataKey: "wrapped-1", MasterKeyVersion: 1}
+	second := domain.WalletPrivateData{ID: primitive.NewObjectID(), EncryptedDataKey: "wrapped-2", MasterKeyVersion: 1}
+	broken := domain.WalletPrivateData{ID: primitive.NewObjectID(), EncryptedDataKey: "wrapped-3", MasterKeyVersion: 9}
+	legacy := domain.WalletPrivateData{ID: primitive.NewObjectID(), EncryptedKey: "legacy-encrypted-key"}
+
+	mockWalletRepository := new(mocks.WalletRepository)
+	mockWalletKeyManager := new(mocks.WalletKeyManager)
+
+	mockWalletKeyManager.On("CurrentVersion").Return(2)
+	mockWalletRepository.On("GetStalePrivateData", mock.Anything, 2, "", 2).Return([]domain.WalletPrivateData{first, second}, nil).Once()
+	mockWalletRepository.On("GetStalePrivateData", mock.Anything, 2, second.ID.Hex(), 2).Return([]domain.WalletPrivateData{broken, legacy}, nil).Once()
+	mockWalletRepository.On("GetStalePrivateData", mock.Anything, 2, legacy.ID.Hex(), 2).Return([]domain.WalletPrivateData{}, nil).Once()
+	mockWalletKeyManager.On("RewrapDataKey", "wrapped-1", 1).Return("rewrapped-1", 2, nil).Once()
+	mockWalletKeyManager.On("RewrapDataKey", "wrapped-2", 1).Return("rewrapped-2", 2, nil).Once()
+	mockWalletKeyManager.On("RewrapDataKey", "wrapped-3", 9).Return("", 0, domain.ErrMasterKeyNotFound).Once()
+	mockWalletRepository.On("UpdateDataKey", mock.Anything, first.ID.Hex(), 1, "rewrapped-1", 2).Return(true, nil).Once()
+	mockWalletRepository.On("UpdateDataKey", mock.Anything, second.ID.Hex(), 1, "rewrapped-2", 2).Return(false, nil).Once()
+
+	u := usecase.NewWalletRekeyUsecase(mockWalletRepository, mockWalletKeyManager, time.Second*2)
+
+	result, err := u.RekeyPrivateData(context.Background(), 2)
+
+	assert.NoError(t, err)
+	assert.Equal(t, &domain.WalletRekeyResult{Version: 2, Rewrapped: 1, Skipped: 1, Legacy: 1, Failed: 1}, result)
+
+	mockWalletRepository.AssertExpectations(t)
+	mockWalletKeyManager.AssertExpectations(t)
+
+	t.Run("repository error", func(t *testing.T) {
+		mockWalletRepository := new(mocks.WalletRepository)
+		mockWalletRepository.On("GetStalePrivateData", mock.Anything, 2, "", 100).Return(nil, errors.New("connection refused")).Once()
+
+		u := usecase.NewWalletRekeyUsecase(mockWalletRepository, mockWalletKeyManager, time.Second*2)
+
+		_, err := u.RekeyPrivateData(context.Background(), 100)
+
+		assert.Error(t, err)
+
+		mockWalletRepository.AssertExpectations(t)
+	})
+
+	t.Run("invalid batch size", func(t *testing.T) {
+		mockWalletRepository := new(mocks.WalletRepository)
+
+		u := usecase.NewWalletRekeyUsecase(mockWalletRepository, mockWalletKeyManager, time.Second*2)
+
+		for _, batchSize := range []int{0, -1} {
+			_, err := u.RekeyPrivateData(context.Background(), batchSize)
+			assert.Error(t, err)
+		}
+
+		mockWalletRepository.AssertNotCalled(t, "GetStalePrivateData", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,810 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/littlecheny/go-backend/domain"
+	"github.com/littlecheny/go-backend/internal/hdwallet"
+	"github.com/littlecheny/go-backend/internal/units"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	defaultWalletPageSize = 20
+	maxWalletPageSize     = 100
+)
+
+type walletUsecase struct {
+	walletRepository domain.WalletRepository
+	networks         domain.NetworkRegistry
+	cryptoService    domain.CryptoService
+	keyManager       domain.WalletKeyManager
+	tokenRegistry    domain.TokenRegistry
+	prices           domain.PriceProvider
+	contextTimeout   time.Duration
+}
+
+func NewWalletUsecase(walletRepository domain.WalletRepository, networks domain.NetworkRegistry, cryptoService domain.CryptoService, keyManager domain.WalletKeyManager, tokenRegistry domain.TokenRegistry, prices domain.PriceProvider, timeout time.Duration) domain.WalletUsecase {
+	return &walletUsecase{
+		walletRepository: walletRepository,
+		networks:         networks,
+		cryptoService:    cryptoService,
+		keyManager:       keyManager,
+		tokenRegistry:    tokenRegistry,
+		prices:           prices,
+		contextTimeout:   timeout,
+	}
+}
+
+// walletAccount 新钱包的地址及需要加密保存的私密数据
+type walletAccount struct {
+	address    string
+	privateKey string
+	mnemonic   string
+	passphrase string
+	index      uint32
+}
+
+func (wu *walletUsecase) CreateWallet(c context.Context, userID string, req *domain.WalletCreateRequest) (*domain.WalletResponse, error) {
+	ctx, cancel := context.WithTimeout(c, wu.contextTimeout)
+	defer cancel()
+
+	ethereumService, err := wu.networks.GetClient(req.Network)
+	if err != nil {
+		return nil, err
+	}
+
+	account := walletAccount{passphrase: req.Passphrase}
+
+	switch req.Type {
+	case domain.WalletTypeHD:
+		account.address, account.privateKey, account.mnemonic, err = ethereumService.CreateAccount(req.Passphrase)
+	case domain.WalletTypeImported:
+		if req.Mnemonic == "" {
+			return nil, errors.New("mnemonic is required for imported wallets")
+		}
+		account.mnemonic = req.Mnemonic
+		account.address, account.privateKey, err = ethereumService.ImportAccount(req.Mnemonic, req.Passphrase)
+	case domain.WalletTypeWatchOnly:
+		if !common.IsHexAddress(req.Address) {
+			return nil, errors.New("a valid address is required for watch-only wallets")
+		}
+		account = walletAccount{address: common.HexToAddress(req.Address).Hex()}
+	default:
+		return nil, domain.ErrInvalidWalletType
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return wu.saveWallet(ctx, userID, req.Name, req.Type, req.Network, account, req.Password)
+}
+
+func (wu *walletUsecase) ImportWallet(c context.Context, userID string, req *domain.WalletImportRequest) (*domain.WalletResponse, error) {
+	ctx, cancel := context.WithTimeout(c, wu.contextTimeout)
+	defer cancel()
+
+	ethereumService, err := wu.networks.GetClient(req.Network)
+	if err != nil {
+		return nil, err
+	}
+
+	address, privateKey, err := ethereumService.ImportAccount(req.Mnemonic, req.Passphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	account := walletAccount{
+		address:    address,
+		privateKey: privateKey,
+		mnemonic:   req.Mnemonic,
+		passphrase: req.Passphrase,
+	}
+	return wu.saveWallet(ctx, userID, req.Name, domain.WalletTypeImported, req.Network, account, req.Password)
+}
+
+// DeriveWallet 从已有钱包的助记词派生指定索引的账户，作为同一网络下的新HD钱包保存
+func (wu *walletUsecase) DeriveWallet(c context.Context, userID string, walletID string, req *domain.WalletDeriveRequest) (*domain.WalletResponse, error) {
+	ctx, cancel := context.WithTimeout(c, wu.contextTimeout)
+	defer cancel()
+
+	wallet, err := wu.getUserWallet(ctx, userID, walletID)
+	if err != nil {
+		return nil, err
+	}
+	if wallet.Type == domain.WalletTypeWatchOnly {
+		return nil, domain.ErrWalletIsWatchOnly
+	}
+
+	privateData, err := wu.walletRepository.GetPrivateData(ctx, walletID)
+	if err != nil {
+		return nil, err
+	}
+	if privateData.EncryptedMnemonic == "" {
+		return nil, domain.ErrWalletNoMnemonic
+	}
+
+	key, err := wu.unlockDataKey(ctx, privateData, req.Password)
+	if err != nil {
+		return nil, err
+	}
+
+	account := walletAccount{index: req.Index}
+	account.mnemonic, err = wu.cryptoService.DecryptWithDataKey(privateData.EncryptedMnemonic, key)
+	if err != nil {
+		return nil, err
+	}
+	if privateData.EncryptedPassphrase != "" {
+		account.passphrase, err = wu.cryptoService.DecryptWithDataKey(privateData.EncryptedPassphrase, key)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	ethereumService, err := wu.networks.GetClient(wallet.Network)
+	if err != nil {
+		return nil, err
+	}
+
+	account.address, account.privateKey, err = ethereumService.DeriveAccount(account.mnemonic, account.passphrase, req.Index)
+	if err != nil {
+		return nil, err
+	}
+
+	return wu.saveWallet(ctx, userID, req.Name, domain.WalletTypeHD, wallet.Network, account, req.Password)
+}
+
+// saveWallet 保存钱包及其加密后的私钥、助记词；用户在该网络的第一个钱包自动成为默认钱包
+func (wu *walletUsecase) saveWallet(ctx context.Context, userID string, name string, walletType domain.WalletType, network string, account walletAccount, password string) (*domain.WalletResponse, error) {
+	userIDHex, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = wu.checkDuplicate(ctx, userIDHex, account.address, network)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = wu.walletRepository.GetDefaultWallet(ctx, userID, network)
+	if err != nil && !errors.Is(err, domain.ErrWalletNotFound) {
+		return nil, err
+	}
+	isDefault := errors.Is(err, domain.ErrWalletNotFound)
+
+	now := time.Now()
+	wallet := &domain.Wallet{
+		ID:           primitive.NewObjectID(),
+		UserID:       userIDHex,
+		Name:         name,
+		Address:      account.address,
+		Type:         walletType,
+		Status:       domain.WalletStatusActive,
+		Network:      network,
+		Balance:      "0",
+		IsDefault:    isDefault,
+		AccountIndex: account.index,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+
+	var privateData *domain.WalletPrivateData
+	if walletType != domain.WalletTypeWatchOnly {
+		privateData, err = wu.encryptPrivateData(wallet.ID, account, password)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = wu.walletRepository.Create(ctx, wallet)
+	if err != nil {
+		return nil, err
+	}
+
+	if privateData != nil {
+		err = wu.walletRepository.CreatePrivateData(ctx, privateData)
+		if err != nil {
+			// 私钥未能保存的钱包不可用，回滚钱包记录
+			_ = wu.walletRepository.Delete(ctx, wallet.ID.Hex())
+			return nil, err
+		}
+	}
+
+	return toWalletResponse(wallet), nil
+}
+
+// checkDuplicate 同一用户在同一网络下不能重复添加地址，不同网络或不同用户可以
+func (wu *walletUsecase) checkDuplicate(ctx context.Context, userID primitive.ObjectID, address string, network string) error {
+	existing, err := wu.walletRepository.GetByAddressAndNetwork(ctx, address, network)
+	if err != nil {
+		return err
+	}
+	for _, wallet := range existing {
+		if wallet.UserID == userID {
+			return domain.ErrWalletExists
+		}
+	}
+	return nil
+}
+
+func (wu *walletUsecase) GetWallet(c context.Context, userID string, walletID string) (*domain.WalletResponse, error) {
+	ctx, cancel := context.WithTimeout(c, wu.contextTimeout)
+	defer cancel()
+
+	wallet, err := wu.getUserWallet(ctx, userID, walletID)
+	if err != nil {
+		return nil, err
+	}
+
+	return toWalletResponse(wallet), nil
+}
+
+func (wu *walletUsecase) GetWallets(c context.Context, userID string, page, pageSize int) (*domain.WalletListResponse, error) {
+	ctx, cancel := context.WithTimeout(c, wu.contextTimeout)
+	defer cancel()
+
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = defaultWalletPageSize
+	}
+	if pageSize > maxWalletPageSize {
+		pageSize = maxWalletPageSize
+	}
+
+	wallets, total, err := wu.walletRepository.GetByUserID(ctx, userID, page, pageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.WalletListResponse{
+		Wallets:    toWalletResponses(wallets),
+		TotalCount: total,
+		Page:       page,
+		PageSize:   pageSize,
+	}, nil
+}
+
+func (wu *walletUsecase) UpdateWallet(c context.Context, userID string, walletID string, name string) (*domain.WalletResponse, error) {
+	ctx, cancel := context.WithTimeout(c, wu.contextTimeout)
+	defer cancel()
+
+	wallet, err := wu.getUserWallet(ctx, userID, walletID)
+	if err != nil {
+		return nil, err
+	}
+
+	wallet.Name = name
+	err = wu.walletRepository.Update(ctx, wallet)
+	if err != nil {
+		return nil, err
+	}
+
+	return toWalletResponse(wallet), nil
+}
+
+func (wu *walletUsecase) DeleteWallet(c context.Context, userID string, walletID string) error {
+	ctx, cancel := context.WithTimeout(c, wu.contextTimeout)
+	defer cancel()
+
+	wallet, err := wu.getUserWallet(ctx, userID, walletID)
+	if err != nil {
+		return err
+	}
+
+	if wallet.Type != domain.WalletTypeWatchOnly {
+		err = wu.walletRepository.DeletePrivateData(ctx, walletID)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = wu.walletRepository.Delete(ctx, walletID)
+	if err != nil || !wallet.IsDefault {
+		return err
+	}
+
+	return wu.promoteDefault(ctx, userID, wallet.Network)
+}
+
+func (wu *walletUsecase) GetBalance(c context.Context, userID string, walletID string) (*domain.WalletBalanceResponse, error) {
+	ctx, cancel := context.WithTimeout(c, wu.contextTimeout)
+	defer cancel()
+
+	wallet, err := wu.getUserWallet(ctx, userID, walletID)
+	if err != nil {
+		return nil, err
+	}
+
+	return toWalletBalanceResponse(wallet), nil
+}
+
+func (wu *walletUsecase) RefreshBalance(c context.Context, userID string, walletID string) (*domain.WalletBalanceResponse, error) {
+	ctx, cancel := context.WithTimeout(c, wu.contextTimeout)
+	defer cancel()
+
+	wallet, err := wu.getUserWallet(ctx, userID, walletID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = wu.refreshWalletBalance(ctx, wallet)
+	if err != nil {
+		return nil, err
+	}
+
+	return toWalletBalanceResponse(wallet), nil
+}
+
+func (wu *walletUsecase) RefreshAllBalances(c context.Context, userID string) error {
+	ctx, cancel := context.WithTimeout(c, wu.contextTimeout)
+	defer cancel()
+
+	page := 1
+	for {
+		wallets, total, err := wu.walletRepository.GetByUserID(ctx, userID, page, maxWalletPageSize)
+		if err != nil {
+			return err
+		}
+
+		for i := range wallets {
+			err = wu.refreshWalletBalance(ctx, &wallets[i])
+			if err != nil {
+				return err
+			}
+		}
+
+		if page*maxWalletPageSize >= total {
+			return nil
+		}
+		page++
+	}
+}
+
+func (wu *walletUsecase) refreshWalletBalance(ctx context.Context, wallet *domain.Wallet) error {
+	ethereumService, err := wu.networks.GetClient(wallet.Network)
+	if err != nil {
+		return err
+	}
+
+	balance, err := ethereumService.GetBalance(ctx, wallet.Address)
+	if err != nil {
+		return err
+	}
+
+	wallet.Balance = balance
+	wallet.BalanceUSD = formatUSD(wu.valueUSD(ctx, wallet.Network, balance))
+	wallet.UpdatedAt = time.Now()
+	return wu.walletRepository.UpdateBalance(ctx, wallet.ID.Hex(), wallet.Balance, wallet.BalanceUSD)
+}
+
+// valueUSD 按网络原生代币的USD价格换算余额，测试网按0计算；没有价格时返回nil
+func (wu *walletUsecase) valueUSD(ctx context.Context, network string, balance string) *big.Rat {
+	config, err := wu.networks.GetNetwork(network)
+	if err != nil {
+		return nil
+	}
+
+	amount, err := units.ParseDecimal(balance)
+	if err != nil {
+		return nil
+	}
+	if config.Testnet || amount.Sign() == 0 {
+		return new(big.Rat)
+	}
+
+	price, err := wu.prices.GetPriceUSD(ctx, config.Symbol)
+	if err != nil {
+		if !errors.Is(err, domain.ErrPriceNotAvailable) {
+			log.Printf("failed to get %s price: %v", config.Symbol, err)
+		}
+		return nil
+	}
+
+	priceUSD, err := units.ParseDecimal(price)
+	if err != nil {
+		log.Printf("invalid %s price %q", config.Symbol, price)
+		return nil
+	}
+	return amount.Mul(amount, priceUSD)
+}
+
+// formatUSD 没有价格时返回空字符串
+func formatUSD(value *big.Rat) string {
+	if value == nil {
+		return ""
+	}
+	return units.FormatDecimal(value, units.USDDecimals)
+}
+
+// GetTokenBalances 查询钱包所在网络已登记代币的余额
+func (wu *walletUsecase) GetTokenBalances(c context.Context, userID string, walletID string) ([]domain.TokenBalance, error) {
+	ctx, cancel := context.WithTimeout(c, wu.contextTimeout)
+	defer cancel()
+
+	wallet, err := wu.getUserWallet(ctx, userID, walletID)
+	if err != nil {
+		return nil, err
+	}
+
+	ethereumService, err := wu.networks.GetClient(wallet.Network)
+	if err != nil {
+		return nil, err
+	}
+
+	tokens := wu.tokenRegistry.GetTokens(wallet.Network)
+	balances := make([]domain.TokenBalance, 0, len(tokens))
+	for _, token := range tokens {
+		balance, err := ethereumService.GetTokenBalance(ctx, token.ContractAddress, wallet.Address)
+		if err != nil {
+			return nil, err
+		}
+
+		balances = append(balances, domain.TokenBalance{
+			Token:   token,
+			Balance: units.FormatString(balance, token.Decimals),
+		})
+	}
+	return balances, nil
+}
+
+func (wu *walletUsecase) SetDefaultWallet(c context.Context, userID string, walletID string) error {
+	ctx, cancel := context.WithTimeout(c, wu.contextTimeout)
+	defer cancel()
+
+	_, err := wu.getUserWallet(ctx, userID, walletID)
+	if err != nil {
+		return err
+	}
+
+	return wu.walletRepository.SetDefaultWallet(ctx, userID, walletID)
+}
+
+func (wu *walletUsecase) GetDefaultWallet(c context.Context, userID string, network string) (*domain.WalletResponse, error) {
+	ctx, cancel := context.WithTimeout(c, wu.contextTimeout)
+	defer cancel()
+
+	wallet, err := wu.walletRepository.GetDefaultWallet(ctx, userID, network)
+	if err != nil {
+		return nil, err
+	}
+
+	return toWalletResponse(wallet), nil
+}
+
+func (wu *walletUsecase) GetWalletsByNetwork(c context.Context, userID string, network string) ([]domain.WalletResponse, error) {
+	ctx, cancel := context.WithTimeout(c, wu.contextTimeout)
+	defer cancel()
+
+	wallets, err := wu.walletRepository.GetWalletsByNetwork(ctx, userID, network)
+	if err != nil {
+		return nil, err
+	}
+
+	return toWalletResponses(wallets), nil
+}
+
+// SwitchNetwork 切换钱包所在网络，EVM网络间地址通用，余额需重新获取；
+// 默认钱包切走后由原网络中最早创建的钱包接替
+func (wu *walletUsecase) SwitchNetwork(c context.Context, userID string, walletID string, network string) error {
+	ctx, cancel := context.WithTimeout(c, wu.contextTimeout)
+	defer cancel()
+
+	wallet, err := wu.getUserWallet(ctx, userID, walletID)
+	if err != nil {
+		return err
+	}
+	if wallet.Network == network {
+		return nil
+	}
+
+	_, err = wu.networks.GetNetwork(network)
+	if err != nil {
+		return err
+	}
+
+	err = wu.checkDuplicate(ctx, wallet.UserID, wallet.Address, network)
+	if err != nil {
+		return err
+	}
+
+	_, err = wu.walletRepository.GetDefaultWallet(ctx, userID, network)
+	if err != nil && !errors.Is(err, domain.ErrWalletNotFound) {
+		return err
+	}
+
+	previousNetwork, wasDefault := wallet.Network, wallet.IsDefault
+	wallet.Network = network
+	wallet.Balance = "0"
+	wallet.BalanceUSD = ""
+	wallet.IsDefault = errors.Is(err, domain.ErrWalletNotFound)
+	err = wu.walletRepository.Update(ctx, wallet)
+	if err != nil || !wasDefault {
+		return err
+	}
+
+	return wu.promoteDefault(ctx, userID, previousNetwork)
+}
+
+// promoteDefault 默认钱包离开网络后，把该网络最早创建的钱包设为默认
+func (wu *walletUsecase) promoteDefault(ctx context.Context, userID string, network string) error {
+	remaining, err := wu.walletRepository.GetWalletsByNetwork(ctx, userID, network)
+	if err != nil || len(remaining) == 0 {
+		return err
+	}
+	// GetWalletsByNetwork按创建时间倒序返回
+	return wu.walletRepository.SetDefaultWallet(ctx, userID, remaining[len(remaining)-1].ID.Hex())
+}
+
+func (wu *walletUsecase) ExportPrivateKey(c context.Context, userID string, walletID string, password string) (string, error) {
+	ctx, cancel := context.WithTimeout(c, wu.contextTimeout)
+	defer cancel()
+
+	privateData, err := wu.getPrivateData(ctx, userID, walletID)
+	if err != nil {
+		return "", err
+	}
+
+	key, err := wu.unlockDataKey(ctx, privateData, password)
+	if err != nil {
+		return "", err
+	}
+
+	return wu.cryptoService.DecryptWithDataKey(privateData.EncryptedKey, key)
+}
+
+func (wu *walletUsecase) ExportMnemonic(c context.Context, userID string, walletID string, password string) (string, error) {
+	ctx, cancel := context.WithTimeout(c, wu.contextTimeout)
+	defer cancel()
+
+	privateData, err := wu.getPrivateData(ctx, userID, walletID)
+	if err != nil {
+		return "", err
+	}
+	if privateData.EncryptedMnemonic == "" {
+		return "", domain.ErrWalletNoMnemonic
+	}
+
+	key, err := wu.unlockDataKey(ctx, privateData, password)
+	if err != nil {
+		return "", err
+	}
+
+	return wu.cryptoService.DecryptWithDataKey(privateData.EncryptedMnemonic, key)
+}
+
+// GetWalletStats 由按网络聚合的结果汇总，总余额按wei、总USD价值按有理数精确累加
+func (wu *walletUsecase) GetWalletStats(c context.Context, userID string) (*domain.WalletStatsResponse, error) {
+	ctx, cancel := context.WithTimeout(c, wu.contextTimeout)
+	defer cancel()
+
+	networks, err := wu.walletRepository.GetNetworkStats(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &domain.WalletStatsResponse{Networks: networks}
+	totalBalance := new(big.Int)
+	totalUSD := new(big.Rat)
+	// 任一网络没有价格时总USD价值未知
+	priced := true
+	for i := range networks {
+		balance, err := units.ParseEther(networks[i].TotalBalance)
+		if err != nil {
+			return nil, err
+		}
+		stats.TotalWallets += networks[i].WalletCount
+		totalBalance.Add(totalBalance, balance)
+
+		value := wu.valueUSD(ctx, networks[i].Network, networks[i].TotalBalance)
+		if value == nil {
+			priced = false
+		} else {
+			totalUSD.Add(totalUSD, value)
+		}
+		networks[i].TotalBalanceUSD = formatUSD(value)
+	}
+
+	stats.TotalBalance = units.FormatEther(totalBalance)
+	if priced {
+		stats.TotalBalanceUSD = formatUSD(totalUSD)
+	}
+	return stats, nil
+}
+
+// getUserWallet 获取属于该用户的钱包，不属于该用户时与不存在同样处理
+func (wu *walletUsecase) getUserWallet(ctx context.Context, userID string, walletID string) (*domain.Wallet, error) {
+	wallet, err := wu.walletRepository.GetByID(ctx, walletID)
+	if err != nil {
+		return nil, err
+	}
+	if wallet.UserID.Hex() != userID {
+		return nil, domain.ErrWalletNotFound
+	}
+	return wallet, nil
+}
+
+func (wu *walletUsecase) getPrivateData(ctx context.Context, userID string, walletID string) (*domain.WalletPrivateData, error) {
+	wallet, err := wu.getUserWallet(ctx, userID, walletID)
+	if err != nil {
+		return nil, err
+	}
+	if wallet.Type == domain.WalletTypeWatchOnly {
+		return nil, domain.ErrWalletIsWatchOnly
+	}
+	return wu.walletRepository.GetPrivateData(ctx, walletID)
+}
+
+// encryptPrivateData 信封加密：私钥、助记词和密码短语用随机数据密钥加密，数据密钥再由密码派生密钥和主密钥包裹
+func (wu *walletUsecase) encryptPrivateData(walletID primitive.ObjectID, account walletAccount, password string) (*domain.WalletPrivateData, error) {
+	salt, err := wu.cryptoService.GenerateSalt()
+	if err != nil {
+		return nil, err
+	}
+
+	passwordKey, err := wu.cryptoService.DeriveKey(password, salt)
+	if err != nil {
+		return nil, err
+	}
+
+	dataKey, err := wu.cryptoService.GenerateDataKey()
+	if err != nil {
+		return nil, err
+	}
+
+	encryptedDataKey, masterKeyVersion, err := wu.keyManager.WrapDataKey(dataKey, passwordKey)
+	if err != nil {
+		return nil, err
+	}
+
+	encryptedKey, err := wu.cryptoService.EncryptWithDataKey(account.privateKey, dataKey)
+	if err != nil {
+		return nil, err
+	}
+
+	var encryptedMnemonic, encryptedPassphrase, derivationPath string
+	if account.mnemonic != "" {
+		encryptedMnemonic, err = wu.cryptoService.EncryptWithDataKey(account.mnemonic, dataKey)
+		if err != nil {
+			return nil, err
+		}
+		derivationPath = hdwallet.EthereumPath(account.index)
+	}
+	if account.passphrase != "" {
+		encryptedPassphrase, err = wu.cryptoService.EncryptWithDataKey(account.passphrase, dataKey)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	now := time.Now()
+	return &domain.WalletPrivateData{
+		ID:                  primitive.NewObjectID(),
+		WalletID:            walletID,
+		EncryptedDataKey:    encryptedDataKey,
+		MasterKeyVersion:    masterKeyVersion,
+		EncryptedKey:        encryptedKey,
+		EncryptedMnemonic:   encryptedMnemonic,
+		EncryptedPassphrase: encryptedPassphrase,
+		KeyDerivationPath:   derivationPath,
+		Salt:                salt,
+		CreatedAt:           now,
+		UpdatedAt:           now,
+	}, nil
+}
+
+// unlockDataKey 用钱包密码解开数据密钥，密码错误时返回ErrInvalidPassword；
+// 旧记录先迁移为信封加密，privateData随之替换为迁移后的内容
+func (wu *walletUsecase) unlockDataKey(ctx context.Context, privateData *domain.WalletPrivateData, password string) (string, error) {
+	if privateData.IsLegacy() {
+		err := wu.migrateLegacy(ctx, privateData, password)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	passwordKey, err := wu.cryptoService.DeriveKey(password, privateData.Salt)
+	if err != nil {
+		return "", err
+	}
+
+	return wu.keyManager.UnwrapDataKey(privateData.EncryptedDataKey, privateData.MasterKeyVersion, passwordKey)
+}
+
+// migrateLegacy 用旧密钥解密私密数据后重新信封加密；保存失败不影响本次解锁，下次解锁时重试
+func (wu *walletUsecase) migrateLegacy(ctx context.Context, privateData *domain.WalletPrivateData, password string) error {
+	passwordKey, err := wu.cryptoService.DeriveKey(password, privateData.Salt)
+	if err != nil {
+		return err
+	}
+
+	legacyKey, err := wu.keyManager.LegacyKey(passwordKey)
+	if err != nil {
+		return err
+	}
+
+	var account walletAccount
+	account.privateKey, err = wu.decryptLegacy(privateData.EncryptedKey, legacyKey)
+	if err != nil {
+		return err
+	}
+	if privateData.EncryptedMnemonic != "" {
+		account.mnemonic, err = wu.decryptLegacy(privateData.EncryptedMnemonic, legacyKey)
+		if err != nil {
+			return err
+		}
+	}
+	if privateData.EncryptedPassphrase != "" {
+		account.passphrase, err = wu.decryptLegacy(privateData.EncryptedPassphrase, legacyKey)
+		if err != nil {
+			return err
+		}
+	}
+
+	migrated, err := wu.encryptPrivateData(privateData.WalletID, account, password)
+	if err != nil {
+		return err
+	}
+	// 保留原记录的标识和派生路径，派生路径不能由account重新计算
+	migrated.ID = privateData.ID
+	migrated.KeyDerivationPath = privateData.KeyDerivationPath
+	migrated.CreatedAt = privateData.CreatedAt
+
+	err = wu.walletRepository.UpdatePrivateData(ctx, migrated)
+	if err != nil {
+		log.Printf("failed to migrate legacy private data of wallet %s: %v", privateData.WalletID.Hex(), err)
+	}
+
+	*privateData = *migrated
+	return nil
+}
+
+// decryptLegacy 旧记录解密失败说明钱包密码错误
+func (wu *walletUsecase) decryptLegacy(encryptedData string, legacyKey string) (string, error) {
+	data, err := wu.cryptoService.Decrypt(encryptedData, legacyKey)
+	if err != nil {
+		return "", domain.ErrInvalidPassword
+	}
+	return data, nil
+}
+
+func toWalletResponse(wallet *domain.Wallet) *domain.WalletResponse {
+	return &domain.WalletResponse{
+		ID:           wallet.ID,
+		Name:         wallet.Name,
+		Address:      wallet.Address,
+		Type:         wallet.Type,
+		Status:       wallet.Status,
+		Network:      wallet.Network,
+		Balance:      wallet.Balance,
+		BalanceUSD:   wallet.BalanceUSD,
+		IsDefault:    wallet.IsDefault,
+		AccountIndex: wallet.AccountIndex,
+		CreatedAt:    wallet.CreatedAt,
+		UpdatedAt:    wallet.UpdatedAt,
+	}
+}
+
+func toWalletResponses(wallets []domain.Wallet) []domain.WalletResponse {
+	responses := make([]domain.WalletResponse, 0, len(wallets))
+	for i := range wallets {
+		responses = append(responses, *toWalletResponse(&wallets[i]))
+	}
+	return responses
+}
+
+func toWalletBalanceResponse(wallet *domain.Wallet) *domain.WalletBalanceResponse {
+	return &domain.WalletBalanceResponse{
+		Address:    wallet.Address,
+		Balance:    wallet.Balance,
+		BalanceUSD: wallet.BalanceUSD,
+		Network:    wallet.Network,
+		UpdatedAt:  wallet.UpdatedAt,
+	}
+}
@@ -0,0 +1,626 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/littlecheny/go-backend/domain"
+	"github.com/littlecheny/go-backend/domain/mocks"
+	"github.com/littlecheny/go-backend/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestCreateWallet(t *testing.T) {
+	userID := primitive.NewObjectID()
+
+	t.Run("hd wallet", func(t *testing.T) {
+		mockWalletRepository := new(mocks.WalletRepository)
+		mockEthereumService := new(mocks.EthereumService)
+		mockCryptoService := new(mocks.CryptoService)
+		mockWalletKeyManager := new(mocks.WalletKeyManager)
+
+		mockEthereumService.On("CreateAccount", "").Return("0xabc", "private-key", "test mnemonic", nil).Once()
+		mockWalletRepository.On("GetByAddressAndNetwork", mock.Anything, "0xabc", "sepolia").Return([]domain.Wallet{}, nil).Once()
+		mockWalletRepository.On("GetDefaultWallet", mock.Anything, userID.Hex(), "sepolia").Return(nil, domain.ErrWalletNotFound).Once()
+		mockCryptoService.On("GenerateSalt").Return("salt", nil).Once()
+		mockCryptoService.On("DeriveKey", "password123", "salt").Return("password-key", nil).Once()
+		mockCryptoService.On("GenerateDataKey").Return("data-key", nil).Once()
+		mockWalletKeyManager.On("WrapDataKey", "data-key", "password-key").Return("wrapped-data-key", 2, nil).Once()
+		mockCryptoService.On("EncryptWithDataKey", "private-key", "data-key").Return("encrypted-key", nil).Once()
+		mockCryptoService.On("EncryptWithDataKey", "test mnemonic", "data-key").Return("encrypted-mnemonic", nil).Once()
+		mockWalletRepository.On("Create", mock.Anything, mock.MatchedBy(func(w *domain.Wallet) bool {
+			return w.UserID == userID && w.Address == "0xabc" && w.IsDefault && w.Status == domain.WalletStatusActive
+		})).Return(nil).Once()
+		mockWalletRepository.On("CreatePrivateData", mock.Anything, mock.MatchedBy(func(d *domain.WalletPrivateData) bool {
+			return d.EncryptedKey == "encrypted-key" && d.EncryptedMnemonic == "encrypted-mnemonic" && d.Salt == "salt" &&
+				d.EncryptedDataKey == "wrapped-data-key" && d.MasterKeyVersion == 2 && d.KeyDerivationPath == "m/44'/60'/0'/0/0"
+		})).Return(nil).Once()
+
+		u := usecase.NewWalletUsecase(mockWalletRepository, networkRegistry(mockEthereumService), mockCryptoService, mockWalletKeyManager, new(mocks.TokenRegistry), new(mocks.PriceProvider), time.Second*2)
+
+		wallet, err := u.CreateWallet(context.Background(), userID.Hex(), &domain.WalletCreateRequest{
+			Name:     "Main",
+			Type:     domain.WalletTypeHD,
+			Network:  "sepolia",
+			Password: "password123",
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "0xabc", wallet.Address)
+		assert.True(t, wallet.IsDefault)
+
+		mockWalletRepository.AssertExpectations(t)
+		mockEthereumService.AssertExpectations(t)
+		mockCryptoService.AssertExpectations(t)
+		mockWalletKeyManager.AssertExpectations(t)
+	})
+
+	t.Run("duplicate address", func(t *testing.T) {
+		mockWalletRepository := new(mocks.WalletRepository)
+		mockEthereumService := new(mocks.EthereumService)
+		mockCryptoService := new(mocks.CryptoService)
+
+		mockEthereumService.On("ImportAccount", "test mnemonic", "").Return("0xabc", "private-key", nil).Once()
+		mockWalletRepository.On("GetByAddressAndNetwork", mock.Anything, "0xabc", "sepolia").Return([]domain.Wallet{{UserID: primitive.NewObjectID()}, {UserID: userID}}, nil).Once()
+
+		u := usecase.NewWalletUsecase(mockWalletRepository, networkRegistry(mockEthereumService), mockCryptoService, new(mocks.WalletKeyManager), new(mocks.TokenRegistry), new(mocks.PriceProvider), time.Second*2)
+
+		_, err := u.ImportWallet(context.Background(), userID.Hex(), &domain.WalletImportRequest{
+			Name:     "Imported",
+			Network:  "sepolia",
+			Mnemonic: "test mnemonic",
+			Password: "password123",
+		})
+
+		assert.ErrorIs(t, err, domain.ErrWalletExists)
+
+		mockWalletRepository.AssertExpectations(t)
+		mockEthereumService.AssertExpectations(t)
+	})
+
+	t.Run("invalid type", func(t *testing.T) {
+		u := usecase.NewWalletUsecase(new(mocks.WalletRepository), networkRegistry(new(mocks.EthereumService)), new(mocks.CryptoService), new(mocks.WalletKeyManager), new(mocks.TokenRegistry), new(mocks.PriceProvider), time.Second*2)
+
+		_, err := u.CreateWallet(context.Background(), userID.Hex(), &domain.WalletCreateRequest{
+			Name:    "Main",
+			Type:    "cold",
+			Network: "sepolia",
+		})
+
+		assert.ErrorIs(t, err, domain.ErrInvalidWalletType)
+	})
+}
+
+func TestDeriveWallet(t *testing.T) {
+	userID := primitive.NewObjectID()
+	wallet := &domain.Wallet{
+		ID:      primitive.NewObjectID(),
+		UserID:  userID,
+		Type:    domain.WalletTypeHD,
+		Network: "sepolia",
+	}
+
+	t.Run("success", func(t *testing.T) {
+		mockWalletRepository := new(mocks.WalletRepository)
+		mockEthereumService := new(mocks.EthereumService)
+		mockCryptoService := new(mocks.CryptoService)
+		mockWalletKeyManager := new(mocks.WalletKeyManager)
+
+		mockWalletRepository.On("GetByID", mock.Anything, wallet.ID.Hex()).Return(wallet, nil).Once()
+		mockWalletRepository.On("GetPrivateData", mock.Anything, wallet.ID.Hex()).Return(&domain.WalletPrivateData{
+			EncryptedDataKey:    "wrapped-data-key",
+			MasterKeyVersion:    1,
+			EncryptedMnemonic:   "encrypted-mnemonic",
+			EncryptedPassphrase: "encrypted-passphrase",
+			Salt:                "salt",
+		}, nil).Once()
+		mockCryptoService.On("DeriveKey", "password123", "salt").Return("password-key", nil).Once()
+		mockWalletKeyManager.On("UnwrapDataKey", "wrapped-data-key", 1, "password-key").Return("data-key", nil).Once()
+		mockCryptoService.On("DecryptWithDataKey", "encrypted-mnemonic", "data-key").Return("test mnemonic", nil).Once()
+		mockCryptoService.On("DecryptWithDataKey", "encrypted-passphrase", "data-key").Return("secret", nil).Once()
+		mockEthereumService.On("DeriveAccount", "test mnemonic", "secret", uint32(3)).Return("0xdef", "private-key-3", nil).Once()
+		mockWalletRepository.On("GetByAddressAndNetwork", mock.Anything, "0xdef", "sepolia").Return([]domain.Wallet{}, nil).Once()
+		mockWalletRepository.On("GetDefaultWallet", mock.Anything, userID.Hex(), "sepolia").Return(wallet, nil).Once()
+		mockCryptoService.On("GenerateSalt").Return("new-salt", nil).Once()
+		mockCryptoService.On("DeriveKey", "password123", "new-salt").Return("new-password-key", nil).Once()
+		mockCryptoService.On("GenerateDataKey").Return("new-data-key", nil).Once()
+		mockWalletKeyManager.On("WrapDataKey", "new-data-key", "new-password-key").Return("new-wrapped-data-key", 2, nil).Once()
+		mockCryptoService.On("EncryptWithDataKey", mock.Anything, "new-data-key").Return("encrypted", nil).Times(3)
+		mockWalletRepository.On("Create", mock.Anything, mock.MatchedBy(func(w *domain.Wallet) bool {
+			return w.Address == "0xdef" && w.AccountIndex == 3 && w.Type == domain.WalletTypeHD && !w.IsDefault
+		})).Return(nil).Once()
+		mockWalletRepository.On("CreatePrivateData", mock.Anything, mock.MatchedBy(func(d *domain.WalletPrivateData) bool {
+			return d.KeyDerivationPath == "m/44'/60'/0'/0/3" && d.EncryptedPassphrase == "encrypted" && d.MasterKeyVersion == 2
+		})).Return(nil).Once()
+
+		u := usecase.NewWalletUsecase(mockWalletRepository, networkRegistry(mockEthereumService), mockCryptoService, mockWalletKeyManager, new(mocks.TokenRegistry), new(mocks.PriceProvider), time.Second*2)
+
+		derived, err := u.DeriveWallet(context.Background(), userID.Hex(), wallet.ID.Hex(), &domain.WalletDeriveRequest{
+			Name:     "Account 3",
+			Index:    3,
+			Password: "password123",
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "0xdef", derived.Address)
+		assert.Equal(t, uint32(3), derived.AccountIndex)
+
+		mockWalletRepository.AssertExpectations(t)
+		mockEthereumService.AssertExpectations(t)
+		mockCryptoService.AssertExpectations(t)
+		mockWalletKeyManager.AssertExpectations(t)
+	})
+
+	t.Run("no mnemonic", func(t *testing.T) {
+		mockWalletRepository := new(mocks.WalletRepository)
+		mockWalletRepository.On("GetByID", mock.Anything, wallet.ID.Hex()).Return(wallet, nil).Once()
+		mockWalletRepository.On("GetPrivateData", mock.Anything, wallet.ID.Hex()).Return(&domain.WalletPrivateData{Salt: "salt"}, nil).Once()
+
+		u := usecase.NewWalletUsecase(mockWalletRepository, new(mocks.NetworkRegistry), new(mocks.CryptoService), new(mocks.WalletKeyManager), new(mocks.TokenRegistry), new(mocks.PriceProvider), time.Second*2)
+
+		_, err := u.DeriveWallet(context.Background(), userID.Hex(), wallet.ID.Hex(), &domain.WalletDeriveRequest{
+			Name:     "Account 1",
+			Index:    1,
+			Password: "password123",
+		})
+
+		assert.ErrorIs(t, err, domain.ErrWalletNoMnemonic)
+
+		mockWalletRepository.AssertExpectations(t)
+	})
+}
+
+func TestGetWallet(t *testing.T) {
+	userID := primitive.NewObjectID()
+	wallet := &domain.Wallet{
+		ID:     primitive.NewObjectID(),
+		UserID: userID,
+		Name:   "Main",
+	}
+
+	t.Run("owner", func(t *testing.T) {
+		mockWalletRepository := new(mocks.WalletRepository)
+		mockWalletRepository.On("GetByID", mock.Anything, wallet.ID.Hex()).Return(wallet, nil).Once()
+
+		u := usecase.NewWalletUsecase(mockWalletRepository, new(mocks.NetworkRegistry), new(mocks.CryptoService), new(mocks.WalletKeyManager), new(mocks.TokenRegistry), new(mocks.PriceProvider), time.Second*2)
+
+		got, err := u.GetWallet(context.Background(), userID.Hex(), wallet.ID.Hex())
+
+		assert.NoError(t, err)
+		assert.Equal(t, wallet.ID, got.ID)
+
+		mockWalletRepository.AssertExpectations(t)
+	})
+
+	t.Run("other user", func(t *testing.T) {
+		mockWalletRepository := new(mocks.WalletRepository)
+		mockWalletRepository.On("GetByID", mock.Anything, wallet.ID.Hex()).Return(wallet, nil).Once()
+
+		u := usecase.NewWalletUsecase(mockWalletRepository, new(mocks.NetworkRegistry), new(mocks.CryptoService), new(mocks.WalletKeyManager), new(mocks.TokenRegistry), new(mocks.PriceProvider), time.Second*2)
+
+		_, err := u.GetWallet(context.Background(), primitive.NewObjectID().Hex(), wallet.ID.Hex())
+
+		assert.ErrorIs(t, err, domain.ErrWalletNotFound)
+
+		mockWalletRepository.AssertExpectations(t)
+	})
+}
+
+func TestGetWallets(t *testing.T) {
+	userID := primitive.NewObjectID().Hex()
+
+	mockWalletRepository := new(mocks.WalletRepository)
+	mockWalletRepository.On("GetByUserID", mock.Anything, userID, 1, 100).Return([]domain.Wallet{{Name: "Main"}}, 1, nil).Once()
+
+	u := usecase.NewWalletUsecase(mockWalletRepository, new(mocks.NetworkRegistry), new(mocks.CryptoService), new(mocks.WalletKeyManager), new(mocks.TokenRegistry), new(mocks.PriceProvider), time.Second*2)
+
+	list, err := u.GetWallets(context.Background(), userID, 0, 1000)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, list.TotalCount)
+	assert.Equal(t, 1, list.Page)
+	assert.Equal(t, 100, list.PageSize)
+	assert.Len(t, list.Wallets, 1)
+
+	mockWalletRepository.AssertExpectations(t)
+}
+
+func TestSwitchNetwork(t *testing.T) {
+	userID := primitive.NewObjectID()
+	newWallet := func() *domain.Wallet {
+		return &domain.Wallet{
+			ID:        primitive.NewObjectID(),
+			UserID:    userID,
+			Address:   "0xabc",
+			Network:   "sepolia",
+			Balance:   "1.5",
+			IsDefault: true,
+		}
+	}
+
+	t.Run("promotes default on previous network", func(t *testing.T) {
+		wallet := newWallet()
+		oldest := domain.Wallet{ID: primitive.NewObjectID(), UserID: userID, Network: "sepolia"}
+		mockWalletRepository := new(mocks.WalletRepository)
+		mockNetworkRegistry := new(mocks.NetworkRegistry)
+
+		mockWalletRepository.On("GetByID", mock.Anything, wallet.ID.Hex()).Return(wallet, nil).Once()
+		mockNetworkRegistry.On("GetNetwork", "mainnet").Return(&domain.NetworkConfig{Name: "mainnet"}, nil).Once()
+		mockWalletRepository.On("GetByAddressAndNetwork", mock.Anything, "0xabc", "mainnet").Return([]domain.Wallet{{UserID: primitive.NewObjectID()}}, nil).Once()
+		mockWalletRepository.On("GetDefaultWallet", mock.Anything, userID.Hex(), "mainnet").Return(&domain.Wallet{}, nil).Once()
+		mockWalletRepository.On("Update", mock.Anything, mock.MatchedBy(func(w *domain.Wallet) bool {
+			return w.Network == "mainnet" && w.Balance == "0" && !w.IsDefault
+		})).Return(nil).Once()
+		mockWalletRepository.On("GetWalletsByNetwork", mock.Anything, userID.Hex(), "sepolia").Return([]domain.Wallet{{ID: primitive.NewObjectID()}, oldest}, nil).Once()
+		mockWalletRepository.On("SetDefaultWallet", mock.Anything, userID.Hex(), oldest.ID.Hex()).Return(nil).Once()
+
+		u := usecase.NewWalletUsecase(mockWalletRepository, mockNetworkRegistry, new(mocks.CryptoService), new(mocks.WalletKeyManager), new(mocks.TokenRegistry), new(mocks.PriceProvider), time.Second*2)
+
+		err := u.SwitchNetwork(context.Background(), userID.Hex(), wallet.ID.Hex(), "mainnet")
+
+		assert.NoError(t, err)
+
+		mockWalletRepository.AssertExpectations(t)
+		mockNetworkRegistry.AssertExpectations(t)
+	})
+
+	t.Run("becomes default on new network", func(t *testing.T) {
+		wallet := newWallet()
+		mockWalletRepository := new(mocks.WalletRepository)
+		mockNetworkRegistry := new(mocks.NetworkRegistry)
+
+		mockWalletRepository.On("GetByID", mock.Anything, wallet.ID.Hex()).Return(wallet, nil).Once()
+		mockNetworkRegistry.On("GetNetwork", "mainnet").Return(&domain.NetworkConfig{Name: "mainnet"}, nil).Once()
+		mockWalletRepository.On("GetByAddressAndNetwork", mock.Anything, "0xabc", "mainnet").Return([]domain.Wallet{}, nil).Once()
+		mockWalletRepository.On("GetDefaultWallet", mock.Anything, userID.Hex(), "mainnet").Return(nil, domain.ErrWalletNotFound).Once()
+		mockWalletRepository.On("Update", mock.Anything, mock.MatchedBy(func(w *domain.Wallet) bool {
+			return w.Network == "mainnet" && w.IsDefault
+		})).Return(nil).Once()
+		mockWalletRepository.On("GetWalletsByNetwork", mock.Anything, userID.Hex(), "sepolia").Return([]domain.Wallet{}, nil).Once()
+
+		u := usecase.NewWalletUsecase(mockWalletRepository, mockNetworkRegistry, new(mocks.CryptoService), new(mocks.WalletKeyManager), new(mocks.TokenRegistry), new(mocks.PriceProvider), time.Second*2)
+
+		err := u.SwitchNetwork(context.Background(), userID.Hex(), wallet.ID.Hex(), "mainnet")
+
+		assert.NoError(t, err)
+
+		mockWalletRepository.AssertExpectations(t)
+	})
+
+	t.Run("address already on network", func(t *testing.T) {
+		wallet := newWallet()
+		mockWalletRepository := new(mocks.WalletRepository)
+		mockNetworkRegistry := new(mocks.NetworkRegistry)
+
+		mockWalletRepository.On("GetByID", mock.Anything, wallet.ID.Hex()).Return(wallet, nil).Once()
+		mockNetworkRegistry.On("GetNetwork", "mainnet").Return(&domain.NetworkConfig{Name: "mainnet"}, nil).Once()
+		mockWalletRepository.On("GetByAddressAndNetwork", mock.Anything, "0xabc", "mainnet").Return([]domain.Wallet{{UserID: userID}}, nil).Once()
+
+		u := usecase.NewWalletUsecase(mockWalletRepository, mockNetworkRegistry, new(mocks.CryptoService), new(mocks.WalletKeyManager), new(mocks.TokenRegistry), new(mocks.PriceProvider), time.Second*2)
+
+		err := u.SwitchNetwork(context.Background(), userID.Hex(), wallet.ID.Hex(), "mainnet")
+
+		assert.ErrorIs(t, err, domain.ErrWalletExists)
+
+		mockWalletRepository.AssertExpectations(t)
+	})
+}
+
+func TestDeleteWallet(t *testing.T) {
+	userID := primitive.NewObjectID()
+
+	t.Run("promotes next default", func(t *testing.T) {
+		wallet := &domain.Wallet{ID: primitive.NewObjectID(), UserID: userID, Type: domain.WalletTypeHD, Network: "sepolia", IsDefault: true}
+		oldest := domain.Wallet{ID: primitive.NewObjectID(), UserID: userID, Network: "sepolia"}
+		mockWalletRepository := new(mocks.WalletRepository)
+
+		mockWalletRepository.On("GetByID", mock.Anything, wallet.ID.Hex()).Return(wallet, nil).Once()
+		mockWalletRepository.On("DeletePrivateData", mock.Anything, wallet.ID.Hex()).Return(nil).Once()
+		mockWalletRepository.On("Delete", mock.Anything, wallet.ID.Hex()).Return(nil).Once()
+		mockWalletRepository.On("GetWalletsByNetwork", mock.Anything, userID.Hex(), "sepolia").Return([]domain.Wallet{{ID: primitive.NewObjectID()}, oldest}, nil).Once()
+		mockWalletRepository.On("SetDefaultWallet", mock.Anything, userID.Hex(), oldest.ID.Hex()).Return(nil).Once()
+
+		u := usecase.NewWalletUsecase(mockWalletRepository, new(mocks.NetworkRegistry), new(mocks.CryptoService), new(mocks.WalletKeyManager), new(mocks.TokenRegistry), new(mocks.PriceProvider), time.Second*2)
+
+		err := u.DeleteWallet(context.Background(), userID.Hex(), wallet.ID.Hex())
+
+		assert.NoError(t, err)
+
+		mockWalletRepository.AssertExpectations(t)
+	})
+
+	t.Run("not default", func(t *testing.T) {
+		wallet := &domain.Wallet{ID: primitive.NewObjectID(), UserID: userID, Type: domain.WalletTypeWatchOnly, Network: "sepolia"}
+		mockWalletRepository := new(mocks.WalletRepository)
+
+		mockWalletRepository.On("GetByID", mock.Anything, wallet.ID.Hex()).Return(wallet, nil).Once()
+		mockWalletRepository.On("Delete", mock.Anything, wallet.ID.Hex()).Return(nil).Once()
+
+		u := usecase.NewWalletUsecase(mockWalletRepository, new(mocks.NetworkRegistry), new(mocks.CryptoService), new(mocks.WalletKeyManager), new(mocks.TokenRegistry), new(mocks.PriceProvider), time.Second*2)
+
+		err := u.DeleteWallet(context.Background(), userID.Hex(), wallet.ID.Hex())
+
+		assert.NoError(t, err)
+
+		mockWalletRepository.AssertExpectations(t)
+	})
+}
+
+func TestRefreshBalance(t *testing.T) {
+	userID := primitive.NewObjectID()
+
+	tests := []struct {
+		name    string
+		network domain.NetworkConfig
+		price   string
+		want    string
+	}{
+		{"mainnet", domain.NetworkConfig{Name: "mainnet", Symbol: "ETH"}, "3000.12345678", "4500.19"},
+		{"testnet", domain.NetworkConfig{Name: "sepolia", Symbol: "ETH", Testnet: true}, "", "0.00"},
+		{"no price", domain.NetworkConfig{Name: "mainnet", Symbol: "ETH"}, "-", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wallet := &domain.Wallet{
+				ID:      primitive.NewObjectID(),
+				UserID:  userID,
+				Address: "0x1111111111111111111111111111111111111111",
+				Network: tt.network.Name,
+			}
+
+			mockWalletRepository := new(mocks.WalletRepository)
+			mockEthereumService := new(mocks.EthereumService)
+			mockPriceProvider := new(mocks.PriceProvider)
+			registry := networkRegistry(mockEthereumService)
+			registry.On("GetNetwork", tt.network.Name).Return(&tt.network, nil)
+
+			mockWalletRepository.On("GetByID", mock.Anything, wallet.ID.Hex()).Return(wallet, nil).Once()
+			mockEthereumService.On("GetBalance", mock.Anything, wallet.Address).Return("1.5", nil).Once()
+			if tt.price == "-" {
+				mockPriceProvider.On("GetPriceUSD", mock.Anything, "ETH").Return("", domain.ErrPriceNotAvailable).Once()
+			} else if tt.price != "" {
+				mockPriceProvider.On("GetPriceUSD", mock.Anything, "ETH").Return(tt.price, nil).Once()
+			}
+			mockWalletRepository.On("UpdateBalance", mock.Anything, wallet.ID.Hex(), "1.5", tt.want).Return(nil).Once()
+
+			u := usecase.NewWalletUsecase(mockWalletRepository, registry, new(mocks.CryptoService), new(mocks.WalletKeyManager), new(mocks.TokenRegistry), mockPriceProvider, time.Second*2)
+
+			balance, err := u.RefreshBalance(context.Background(), userID.Hex(), wallet.ID.Hex())
+
+			assert.NoError(t, err)
+			assert.Equal(t, "1.5", balance.Balance)
+			assert.Equal(t, tt.want, balance.BalanceUSD)
+
+			mockWalletRepository.AssertExpectations(t)
+			mockPriceProvider.AssertExpectations(t)
+		})
+	}
+}
+
+func TestGetWalletStats(t *testing.T) {
+	userID := primitive.NewObjectID().Hex()
+	networks := []domain.NetworkConfig{
+		{Name: "mainnet", Symbol: "ETH"},
+		{Name: "sepolia", Symbol: "ETH", Testnet: true},
+	}
+
+	newUsecase := func(repository *mocks.WalletRepository, prices *mocks.PriceProvider) domain.WalletUsecase {
+		registry := new(mocks.NetworkRegistry)
+		for i := range networks {
+			registry.On("GetNetwork", networks[i].Name).Return(&networks[i], nil)
+		}
+		registry.On("GetNetwork", mock.Anything).Return(nil, domain.ErrNetworkNotSupported)
+		return usecase.NewWalletUsecase(repository, registry, new(mocks.CryptoService), new(mocks.WalletKeyManager), new(mocks.TokenRegistry), prices, time.Second*2)
+	}
+
+	t.Run("success", func(t *testing.T) {
+		mockWalletRepository := new(mocks.WalletRepository)
+		mockPriceProvider := new(mocks.PriceProvider)
+
+		mockWalletRepository.On("GetNetworkStats", mock.Anything, userID).Return([]domain.NetworkStats{
+			{Network: "mainnet", WalletCount: 2, TotalBalance: "2.25"},
+			{Network: "sepolia", WalletCount: 1, TotalBalance: "0.000000000000000001"},
+		}, nil).Once()
+		mockPriceProvider.On("GetPriceUSD", mock.Anything, "ETH").Return("2000.5", nil).Once()
+
+		stats, err := newUsecase(mockWalletRepository, mockPriceProvider).GetWalletStats(context.Background(), userID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 3, stats.TotalWallets)
+		assert.Equal(t, "2.250000000000000001", stats.TotalBalance)
+		assert.Equal(t, "4501.13", stats.TotalBalanceUSD)
+		assert.Equal(t, []domain.NetworkStats{
+			{Network: "mainnet", WalletCount: 2, TotalBalance: "2.25", TotalBalanceUSD: "4501.13"},
+			{Network: "sepolia", WalletCount: 1, TotalBalance: "0.000000000000000001", TotalBalanceUSD: "0.00"},
+		}, stats.Networks)
+
+		mockWalletRepository.AssertExpectations(t)
+		mockPriceProvider.AssertExpectations(t)
+	})
+
+	// 已移除的网络没有价格，总USD价值未知
+	t.Run("unknown network", func(t *testing.T) {
+		mockWalletRepository := new(mocks.WalletRepository)
+
+		mockWalletRepository.On("GetNetworkStats", mock.Anything, userID).Return([]domain.NetworkStats{
+			{Network: "goerli", WalletCount: 1, TotalBalance: "1"},
+			{Network: "sepolia", WalletCount: 1, TotalBalance: "2"},
+		}, nil).Once()
+
+		stats, err := newUsecase(mockWalletRepository, new(mocks.PriceProvider)).GetWalletStats(context.Background(), userID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, stats.TotalWallets)
+		assert.Equal(t, "3", stats.TotalBalance)
+		assert.Empty(t, stats.TotalBalanceUSD)
+		assert.Empty(t, stats.Networks[0].TotalBalanceUSD)
+		assert.Equal(t, "0.00", stats.Networks[1].TotalBalanceUSD)
+
+		mockWalletRepository.AssertExpectations(t)
+	})
+
+	t.Run("no wallets", func(t *testing.T) {
+		mockWalletRepository := new(mocks.WalletRepository)
+		mockWalletRepository.On("GetNetworkStats", mock.Anything, userID).Return([]domain.NetworkStats{}, nil).Once()
+
+		stats, err := newUsecase(mockWalletRepository, new(mocks.PriceProvider)).GetWalletStats(context.Background(), userID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 0, stats.TotalWallets)
+		assert.Equal(t, "0", stats.TotalBalance)
+		assert.Equal(t, "0.00", stats.TotalBalanceUSD)
+		assert.Empty(t, stats.Networks)
+	})
+}
+
+func TestGetTokenBalances(t *testing.T) {
+	userID := primitive.NewObjectID()
+	wallet := &domain.Wallet{
+		ID:      primitive.NewObjectID(),
+		UserID:  userID,
+		Address: "0x1111111111111111111111111111111111111111",
+		Network: "mainnet",
+	}
+	tokens := []domain.Token{
+		{Network: "mainnet", Symbol: "DAI", ContractAddress: "0x6B175474E89094C44Da98b954EedeAC495271d0F", Decimals: 18},
+		{Network: "mainnet", Symbol: "USDC", ContractAddress: "0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48", Decimals: 6},
+	}
+
+	mockWalletRepository := new(mocks.WalletRepository)
+	mockEthereumService := new(mocks.EthereumService)
+	mockTokenRegistry := new(mocks.TokenRegistry)
+
+	mockWalletRepository.On("GetByID", mock.Anything, wallet.ID.Hex()).Return(wallet, nil).Once()
+	mockTokenRegistry.On("GetTokens", "mainnet").Return(tokens).Once()
+	mockEthereumService.On("GetTokenBalance", mock.Anything, tokens[0].ContractAddress, wallet.Address).Return("0", nil).Once()
+	mockEthereumService.On("GetTokenBalance", mock.Anything, tokens[1].ContractAddress, wallet.Address).Return("1234567", nil).Once()
+
+	u := usecase.NewWalletUsecase(mockWalletRepository, networkRegistry(mockEthereumService), new(mocks.CryptoService), new(mocks.WalletKeyManager), mockTokenRegistry, new(mocks.PriceProvider), time.Second*2)
+
+	balances, err := u.GetTokenBalances(context.Background(), userID.Hex(), wallet.ID.Hex())
+
+	assert.NoError(t, err)
+	assert.Len(t, balances, 2)
+	assert.Equal(t, "0", balances[0].Balance)
+	assert.Equal(t, "1.234567", balances[1].Balance)
+	assert.Equal(t, "USDC", balances[1].Symbol)
+
+	mockWalletRepository.AssertExpectations(t)
+	mockEthereumService.AssertExpectations(t)
+	mockTokenRegistry.AssertExpectations(t)
+}
+
+func TestExportPrivateKey(t *testing.T) {
+	userID := primitive.NewObjectID()
+	wallet := &domain.Wallet{
+		ID:     primitive.NewObjectID(),
+		UserID: userID,
+		Type:   domain.WalletTypeHD,
+	}
+	privateData := &domain.WalletPrivateData{
+		EncryptedDataKey: "wrapped-data-key",
+		MasterKeyVersion: 1,
+		EncryptedKey:     "encrypted-key",
+		Salt:             "salt",
+	}
+
+	mockWalletRepository := new(mocks.WalletRepository)
+	mockCryptoService := new(mocks.CryptoService)
+	mockWalletKeyManager := new(mocks.WalletKeyManager)
+	mockWalletRepository.On("GetByID", mock.Anything, wallet.ID.Hex()).Return(wallet, nil).Once()
+	mockWalletRepository.On("GetPrivateData", mock.Anything, wallet.ID.Hex()).Return(privateData, nil).Once()
+	mockCryptoService.On("DeriveKey", "wrong-password", "salt").Return("password-key", nil).Once()
+	mockWalletKeyManager.On("UnwrapDataKey", "wrapped-data-key", 1, "password-key").Return("", domain.ErrInvalidPassword).Once()
+
+	u := usecase.NewWalletUsecase(mockWalletRepository, new(mocks.NetworkRegistry), mockCryptoService, mockWalletKeyManager, new(mocks.TokenRegistry), new(mocks.PriceProvider), time.Second*2)
+
+	_, err := u.ExportPrivateKey(context.Background(), userID.Hex(), wallet.ID.Hex(), "wrong-password")
+
+	assert.ErrorIs(t, err, domain.ErrInvalidPassword)
+
+	mockWalletRepository.AssertExpectations(t)
+	mockCryptoService.AssertExpectations(t)
+	mockWalletKeyManager.AssertExpectations(t)
+}
+
+func TestExportPrivateKeyLegacy(t *testing.T) {
+	userID := primitive.NewObjectID()
+	wallet := &domain.Wallet{
+		ID:     primitive.NewObjectID(),
+		UserID: userID,
+		Type:   domain.WalletTypeHD,
+	}
+	newLegacy := func() *domain.WalletPrivateData {
+		return &domain.WalletPrivateData{
+			ID:                primitive.NewObjectID(),
+			WalletID:          wallet.ID,
+			EncryptedKey:      "legacy-key",
+			EncryptedMnemonic: "legacy-mnemonic",
+			KeyDerivationPath: "m/44'/60'/0'/0/2",
+			Salt:              "salt",
+		}
+	}
+
+	t.Run("migrates to envelope", func(t *testing.T) {
+		privateData := newLegacy()
+		mockWalletRepository := new(mocks.WalletRepository)
+		mockCryptoService := new(mocks.CryptoService)
+		mockWalletKeyManager := new(mocks.WalletKeyManager)
+
+		mockWalletRepository.On("GetByID", mock.Anything, wallet.ID.Hex()).Return(wallet, nil).Once()
+		mockWalletRepository.On("GetPrivateData", mock.Anything, wallet.ID.Hex()).Return(privateData, nil).Once()
+		mockCryptoService.On("DeriveKey", "password123", "salt").Return("password-key", nil).Once()
+		mockWalletKeyManager.On("LegacyKey", "password-key").Return("legacy-password-key", nil).Once()
+		mockCryptoService.On("Decrypt", "legacy-key", "legacy-password-key").Return("private-key", nil).Once()
+		mockCryptoService.On("Decrypt", "legacy-mnemonic", "legacy-password-key").Return("test mnemonic", nil).Once()
+		mockCryptoService.On("GenerateSalt").Return("new-salt", nil).Once()
+		mockCryptoService.On("DeriveKey", "password123", "new-salt").Return("new-password-key", nil).Twice()
+		mockCryptoService.On("GenerateDataKey").Return("data-key", nil).Once()
+		mockWalletKeyManager.On("WrapDataKey", "data-key", "new-password-key").Return("wrapped-data-key", 2, nil).Once()
+		mockCryptoService.On("EncryptWithDataKey", "private-key", "data-key").Return("encrypted-key", nil).Once()
+		mockCryptoService.On("EncryptWithDataKey", "test mnemonic", "data-key").Return("encrypted-mnemonic", nil).Once()
+		mockWalletRepository.On("UpdatePrivateData", mock.Anything, mock.MatchedBy(func(d *domain.WalletPrivateData) bool {
+			return d.ID == privateData.ID && d.EncryptedDataKey == "wrapped-data-key" && d.MasterKeyVersion == 2 &&
+				d.EncryptedKey == "encrypted-key" && d.Salt == "new-salt" && d.KeyDerivationPath == "m/44'/60'/0'/0/2"
+		})).Return(nil).Once()
+		mockWalletKeyManager.On("UnwrapDataKey", "wrapped-data-key", 2, "new-password-key").Return("data-key", nil).Once()
+		mockCryptoService.On("DecryptWithDataKey", "encrypted-key", "data-key").Return("private-key", nil).Once()
+
+		u := usecase.NewWalletUsecase(mockWalletRepository, new(mocks.NetworkRegistry), mockCryptoService, mockWalletKeyManager, new(mocks.TokenRegistry), new(mocks.PriceProvider), time.Second*2)
+
+		privateKey, err := u.ExportPrivateKey(context.Background(), userID.Hex(), wallet.ID.Hex(), "password123")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "private-key", privateKey)
+
+		mockWalletRepository.AssertExpectations(t)
+		mockCryptoService.AssertExpectations(t)
+		mockWalletKeyManager.AssertExpectations(t)
+	})
+
+	t.Run("wrong password", func(t *testing.T) {
+		mockWalletRepository := new(mocks.WalletRepository)
+		mockCryptoService := new(mocks.CryptoService)
+		mockWalletKeyManager := new(mocks.WalletKeyManager)
+
+		mockWalletRepository.On("GetByID", mock.Anything, wallet.ID.Hex()).Return(wallet, nil).Once()
+		mockWalletRepository.On("GetPrivateData", mock.Anything, wallet.ID.Hex()).Return(newLegacy(), nil).Once()
+		mockCryptoService.On("DeriveKey", "wrong-password", "salt").Return("password-key", nil).Once()
+		mockWalletKeyManager.On("LegacyKey", "password-key").Return("legacy-password-key", nil).Once()
+		mockCryptoService.On("Decrypt", "legacy-key", "legacy-password-key").Return("", errors.New("cipher: message authentication failed")).Once()
+
+		u := usecase.NewWalletUsecase(mockWalletRepository, new(mocks.NetworkRegistry), mockCryptoService, mockWalletKeyManager, new(mocks.TokenRegistry), new(mocks.PriceProvider), time.Second*2)
+
+		_, err := u.ExportPrivateKey(context.Background(), userID.Hex(), wallet.ID.Hex(), "wrong-password")
+
+		assert.ErrorIs(t, err, domain.ErrInvalidPassword)
+
+		mockWalletRepository.AssertExpectations(t)
+		mockCryptoService.AssertExpectations(t)
+		mockWalletKeyManager.AssertExpectations(t)
+	})
+}